@@ -109,6 +109,12 @@ func main() {
 		logger.Fatal("Failed To Verify Configuration Settings", zap.Error(err))
 	}
 
+	// Apply The Dispatcher's Per-Broker Connection Limits And Rebalance Strategy To The Sarama Config
+	err = sarama.ApplyDispatcherSettings(ctx, ekConfig)
+	if err != nil {
+		logger.Fatal("Failed To Apply Dispatcher Sarama Settings", zap.Error(err))
+	}
+
 	// Enable Sarama Logging If Specified In ConfigMap
 	sarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
 
@@ -132,7 +138,9 @@ func main() {
 	}
 
 	// Start The Metrics Reporter And Defer Shutdown
-	statsReporter := metrics.NewStatsReporter(logger)
+	statsReporter := metrics.NewStatsReporter(logger,
+		metrics.WithAllowedMetricFamilies(ekConfig.Sarama.AllowedMetrics...),
+		metrics.WithDeniedMetricFamilies(ekConfig.Sarama.DeniedMetrics...))
 	defer statsReporter.Shutdown()
 
 	// Change The CloudEvent Connection Args
@@ -155,9 +163,11 @@ func main() {
 		Brokers:         strings.Split(ekConfig.Kafka.Brokers, ","),
 		Topic:           environment.KafkaTopic,
 		ChannelKey:      environment.ChannelKey,
+		PodName:         environment.PodName,
 		StatsReporter:   statsReporter,
 		MetricsRegistry: ekConfig.Sarama.Config.MetricRegistry,
 		SaramaConfig:    ekConfig.Sarama.Config,
+		Concurrency:     ekConfig.Channel.Dispatcher.Concurrency,
 	}
 	dispatcher, managerEvents = dispatch.NewDispatcher(dispatcherConfig, controlProtocolServer)
 
@@ -207,8 +217,8 @@ func main() {
 	// Reset The Liveness and Readiness Flags In Preparation For Shutdown
 	healthServer.Shutdown()
 
-	// Shutdown The Dispatcher (Close ConsumerGroups)
-	dispatcher.Shutdown()
+	// Shutdown The Dispatcher Once SIGTERM Has Cancelled The Context (Close ConsumerGroups)
+	dispatch.ShutdownOnDone(ctx, dispatcher)
 
 	// Stop The Liveness And Readiness Servers
 	healthServer.Stop(logger)
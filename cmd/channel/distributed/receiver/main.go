@@ -135,7 +135,9 @@ func main() {
 	defer channel.Close()
 
 	// Start The Metrics Reporter And Defer Shutdown
-	statsReporter := metrics.NewStatsReporter(logger)
+	statsReporter := metrics.NewStatsReporter(logger,
+		metrics.WithAllowedMetricFamilies(ekConfig.Sarama.AllowedMetrics...),
+		metrics.WithDeniedMetricFamilies(ekConfig.Sarama.DeniedMetrics...))
 	defer statsReporter.Shutdown()
 
 	// Watch The Secret For Changes
@@ -24,6 +24,8 @@ import (
 	"knative.dev/eventing-kafka/pkg/apis/sources"
 	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
@@ -31,6 +33,7 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/source/reconciler/binding"
 	source "knative.dev/eventing-kafka/pkg/source/reconciler/mtsource"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
@@ -81,6 +84,11 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 }
 
 func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	kubeClient := kubeclient.Get(ctx)
+	getSecret := func(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+		return kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+
 	return validation.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
@@ -94,9 +102,8 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 		func(ctx context.Context) context.Context {
-			// Here is where you would infuse the context with state
-			// (e.g. attach a store with configmap data)
-			return ctx
+			// Attach a SecretGetter so that the Kafka auth secret references can be validated
+			return bindingsv1beta1.WithSecretGetter(ctx, getSecret)
 		},
 
 		// Whether to disallow unknown fields.
@@ -18,11 +18,102 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
 )
 
+// SecretGetter returns the named Secret in the specified namespace.
+type SecretGetter func(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+
+type secretGetterKey struct{}
+
+// WithSecretGetter attaches the SecretGetter used to validate the secret references of a KafkaAuthSpec.
+func WithSecretGetter(ctx context.Context, getter SecretGetter) context.Context {
+	return context.WithValue(ctx, secretGetterKey{}, getter)
+}
+
+// getSecretGetter returns the SecretGetter attached to the context, or nil if there is none.
+func getSecretGetter(ctx context.Context) SecretGetter {
+	getter, _ := ctx.Value(secretGetterKey{}).(SecretGetter)
+	return getter
+}
+
 // Validate ensures KafkaBinding is properly configured.
 func (r *KafkaBinding) Validate(ctx context.Context) *apis.FieldError {
-	return nil
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	var original *KafkaAuthSpec
+	if apis.IsInUpdate(ctx) {
+		if baseline, ok := apis.GetBaseline(ctx).(*KafkaBinding); ok && baseline != nil {
+			original = &baseline.Spec.KafkaAuthSpec
+		}
+	}
+	return r.Spec.KafkaAuthSpec.ValidateSecretReferences(ctx, r.Namespace, original).ViaField("spec")
+}
+
+// ValidateSecretReferences ensures the secret keys referenced by the enabled SASL and TLS settings of the
+// KafkaAuthSpec exist and are not empty.  The secrets are only verified when a SecretGetter has been attached to the context (see WithSecretGetter).
+// On updates (original is the KafkaAuthSpec before the update) they are only verified when the net settings change,
+// and never for status updates, so that the status and finalizers of an object whose secret is gone (e.g. while
+// its namespace is torn down) can still be updated.
+func (kas *KafkaAuthSpec) ValidateSecretReferences(ctx context.Context, namespace string, original *KafkaAuthSpec) *apis.FieldError {
+	getter := getSecretGetter(ctx)
+	if getter == nil || apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+	if original != nil && equality.Semantic.DeepEqual(original.Net, kas.Net) {
+		return nil
+	}
+
+	references := []struct {
+		path    string
+		value   SecretValueFromSource
+		enabled bool
+	}{
+		{path: "net.sasl.user", value: kas.Net.SASL.User, enabled: kas.Net.SASL.Enable},
+		{path: "net.sasl.password", value: kas.Net.SASL.Password, enabled: kas.Net.SASL.Enable},
+		{path: "net.sasl.type", value: kas.Net.SASL.Type, enabled: kas.Net.SASL.Enable},
+		{path: "net.tls.cert", value: kas.Net.TLS.Cert, enabled: kas.Net.TLS.Enable},
+		{path: "net.tls.key", value: kas.Net.TLS.Key, enabled: kas.Net.TLS.Enable},
+		{path: "net.tls.caCert", value: kas.Net.TLS.CACert, enabled: kas.Net.TLS.Enable},
+	}
+
+	var errs *apis.FieldError
+	for _, reference := range references {
+		ref := reference.value.SecretKeyRef
+		if ref == nil || !reference.enabled {
+			continue
+		}
+		path := reference.path + ".secretKeyRef"
+
+		secret, err := getter(ctx, namespace, ref.Name)
+		if apierrors.IsNotFound(err) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("secret %q not found", ref.Name),
+				Paths:   []string{path},
+			})
+			continue
+		} else if err != nil {
+			// Don't block admission on transient errors, the reconciler reports any remaining problem
+			continue
+		}
+
+		if value, ok := secret.Data[ref.Key]; !ok {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("key %q not found in secret %q", ref.Key, ref.Name),
+				Paths:   []string{path},
+			})
+		} else if len(value) == 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("key %q is empty in secret %q", ref.Key, ref.Name),
+				Paths:   []string{path},
+			})
+		}
+	}
+	return errs
 }
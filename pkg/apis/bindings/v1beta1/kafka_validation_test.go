@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+func TestKafkaBindingValidateSecretReferences(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kafka-auth"},
+		Data: map[string][]byte{
+			"user":     []byte("user"),
+			"password": []byte("password"),
+			"empty":    {},
+		},
+	}
+	getter := func(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+		if namespace == secret.Namespace && name == secret.Name {
+			return secret, nil
+		}
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	secretRef := func(name, key string) SecretValueFromSource {
+		return SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}
+	}
+	bindingWithSASL := func(user, password SecretValueFromSource) *KafkaBinding {
+		return &KafkaBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "binding"},
+			Spec: KafkaBindingSpec{
+				KafkaAuthSpec: KafkaAuthSpec{
+					BootstrapServers: []string{"servers"},
+					Net: KafkaNetSpec{
+						SASL: KafkaSASLSpec{
+							Enable:   true,
+							User:     user,
+							Password: password,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	saslDisabled := bindingWithSASL(secretRef("other", "user"), secretRef("kafka-auth", "missing"))
+	saslDisabled.Spec.Net.SASL.Enable = false
+	tlsEnabled := bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "password"))
+	tlsEnabled.Spec.Net.TLS = KafkaTLSSpec{Enable: true, CACert: secretRef("kafka-auth", "missing")}
+	tlsDisabled := bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "password"))
+	tlsDisabled.Spec.Net.TLS = KafkaTLSSpec{CACert: secretRef("kafka-auth", "missing")}
+
+	testCases := map[string]struct {
+		binding  *KafkaBinding
+		getter   SecretGetter
+		wantPath string
+	}{
+		"no secret getter": {
+			binding: bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "missing")),
+		},
+		"existing keys": {
+			binding: bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "password")),
+			getter:  getter,
+		},
+		"missing sasl password key": {
+			binding:  bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "missing")),
+			getter:   getter,
+			wantPath: "spec.net.sasl.password.secretKeyRef",
+		},
+		"empty sasl password key": {
+			binding:  bindingWithSASL(secretRef("kafka-auth", "user"), secretRef("kafka-auth", "empty")),
+			getter:   getter,
+			wantPath: "spec.net.sasl.password.secretKeyRef",
+		},
+		"missing secret": {
+			binding:  bindingWithSASL(secretRef("other", "user"), secretRef("kafka-auth", "password")),
+			getter:   getter,
+			wantPath: "spec.net.sasl.user.secretKeyRef",
+		},
+		"sasl disabled": {
+			binding: saslDisabled,
+			getter:  getter,
+		},
+		"missing tls caCert key": {
+			binding:  tlsEnabled,
+			getter:   getter,
+			wantPath: "spec.net.tls.caCert.secretKeyRef",
+		},
+		"tls disabled": {
+			binding: tlsDisabled,
+			getter:  getter,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := apis.WithinCreate(context.Background())
+			if tc.getter != nil {
+				ctx = WithSecretGetter(ctx, tc.getter)
+			}
+			err := tc.binding.Validate(ctx)
+			if tc.wantPath == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.wantPath != "" && (err == nil || !strings.Contains(err.Error(), tc.wantPath)) {
+				t.Errorf("expected error for %s, got: %v", tc.wantPath, err)
+			}
+		})
+	}
+}
+
+func TestKafkaBindingValidateSecretReferencesOnUpdate(t *testing.T) {
+	// The referenced secret is gone (e.g. its namespace is being torn down)
+	getter := func(_ context.Context, _, name string) (*corev1.Secret, error) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	original := &KafkaBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "binding"},
+		Spec: KafkaBindingSpec{
+			KafkaAuthSpec: KafkaAuthSpec{
+				BootstrapServers: []string{"servers"},
+				Net: KafkaNetSpec{
+					SASL: KafkaSASLSpec{
+						Enable: true,
+						User: SecretValueFromSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-auth"},
+								Key:                  "user",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	unchangedNet := original.DeepCopy()
+	unchangedNet.Spec.BootstrapServers = []string{"other-servers"}
+	deleted := original.DeepCopy()
+	deleted.DeletionTimestamp = &metav1.Time{}
+	deleted.Finalizers = nil
+	changedNet := original.DeepCopy()
+	changedNet.Spec.Net.SASL.User.SecretKeyRef.Key = "other-user"
+
+	testCases := map[string]struct {
+		binding      *KafkaBinding
+		statusUpdate bool
+		wantErr      bool
+	}{
+		"unchanged net": {
+			binding: unchangedNet,
+		},
+		"status update": {
+			binding:      original.DeepCopy(),
+			statusUpdate: true,
+		},
+		"finalizer removal of a deleted binding": {
+			binding: deleted,
+		},
+		"changed net": {
+			binding: changedNet,
+			wantErr: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := WithSecretGetter(apis.WithinUpdate(context.Background(), original), getter)
+			if tc.statusUpdate {
+				ctx = apis.WithinSubResourceUpdate(ctx, original, "status")
+			}
+			err := tc.binding.Validate(ctx)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
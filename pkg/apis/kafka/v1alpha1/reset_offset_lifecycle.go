@@ -162,3 +162,7 @@ func (ros *ResetOffsetStatus) GetPartitions() []OffsetMapping {
 func (ros *ResetOffsetStatus) SetPartitions(offsetMappings []OffsetMapping) {
 	ros.Partitions = offsetMappings
 }
+
+func (ros *ResetOffsetStatus) SetKafkaConfigInfo(kafkaConfigInfo *KafkaConfigInfo) {
+	ros.Kafka = kafkaConfigInfo
+}
@@ -96,7 +96,21 @@ type OffsetSpec struct {
 	// beginning and end, respectively, of the persistence window of the Topic.  There is no
 	// default value, and invalid values will result in the ResetOffset operation being
 	// rejected as failed.
-	Time string `json:"time"`
+	// +optional
+	Time string `json:"time,omitempty"`
+
+	// LagPercentage is an alternative to Time which repositions each partition relative to
+	// its current lag (the distance between the committed and the newest offsets).  The new
+	// offset is the committed offset minus this percentage (0 to 100) of the lag, but never
+	// before the oldest offset of the partition.  Exactly one of Time and LagPercentage must
+	// be specified.
+	// +optional
+	LagPercentage *int32 `json:"lagPercentage,omitempty"`
+}
+
+// IsOffsetLagPercentage returns True if the Offset is specified as a percentage of the current lag
+func (ros *ResetOffsetSpec) IsOffsetLagPercentage() bool {
+	return ros.Offset.LagPercentage != nil
 }
 
 // IsOffsetEarliest returns True if the Offset value is "earliest"
@@ -147,6 +161,10 @@ type ResetOffsetStatus struct {
 	// +optional
 	Partitions []OffsetMapping `json:"partitions,omitempty"`
 
+	// Kafka records the Kafka brokers and client settings the Offsets were repositioned with
+	// +optional
+	Kafka *KafkaConfigInfo `json:"kafka,omitempty"`
+
 	// inherits duck/v1 Status, which currently provides:
 	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
 	// * Conditions - the latest available observations of a resource's current state.
@@ -180,3 +198,11 @@ type OffsetMapping struct {
 	OldOffset int64 `json:"oldOffset"`
 	NewOffset int64 `json:"newOffset"`
 }
+
+// KafkaConfigInfo describes the Kafka brokers and the relevant Sarama settings used to reposition the Offsets.
+type KafkaConfigInfo struct {
+	Brokers       []string `json:"brokers,omitempty"`
+	Version       string   `json:"version,omitempty"`
+	TLS           bool     `json:"tls,omitempty"`
+	SASLMechanism string   `json:"saslMechanism,omitempty"`
+}
@@ -33,8 +33,14 @@ func (ros *ResetOffsetSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	var errs *apis.FieldError
 
-	// Validate The Offset String ("earliest", "latest", or valid date string)
-	if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
+	// Validate The Offset Lag Percentage (Exclusive With The Offset Time) Or The Offset String ("earliest", "latest", or valid date string)
+	if ros.IsOffsetLagPercentage() {
+		if ros.Offset.Time != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("offset.time", "offset.lagPercentage"))
+		} else if *ros.Offset.LagPercentage < 0 || *ros.Offset.LagPercentage > 100 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(*ros.Offset.LagPercentage, 0, 100, "offset.lagPercentage"))
+		}
+	} else if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
 		offsetTime, err := ros.ParseOffsetTime()
 		if err != nil || offsetTime.After(time.Now()) {
 			errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Time, "offset"))
@@ -37,6 +37,9 @@ func TestResetOffset_Validate(t *testing.T) {
 	futureTime := time.Now().Add(1 * time.Hour).Format(time.RFC3339)
 	pastTime := time.Now().Add(-1 * time.Hour).Format(time.RFC3339)
 
+	fiftyPercent := int32(50)
+	outOfBoundsPercent := int32(101)
+
 	reference := duckv1.KReference{
 		APIVersion: refAPIVersion,
 		Kind:       refKind,
@@ -91,6 +94,36 @@ func TestResetOffset_Validate(t *testing.T) {
 				return errs
 			}(),
 		},
+		{
+			name: "valid offset lag percentage",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{LagPercentage: &fiftyPercent}, Ref: reference},
+			},
+		},
+		{
+			name: "invalid offset lag percentage out of bounds",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{LagPercentage: &outOfBoundsPercent}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrOutOfBoundsValue(outOfBoundsPercent, 0, 100, "spec.offset.lagPercentage")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		{
+			name: "invalid offset time and lag percentage",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: OffsetEarliest, LagPercentage: &fiftyPercent}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrMultipleOneOf("spec.offset.time", "spec.offset.lagPercentage")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
 		{
 			name: "invalid ref nil",
 			cr: &ResetOffset{
@@ -40,9 +40,35 @@ func (in *OffsetMapping) DeepCopy() *OffsetMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConfigInfo) DeepCopyInto(out *KafkaConfigInfo) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfigInfo.
+func (in *KafkaConfigInfo) DeepCopy() *KafkaConfigInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConfigInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OffsetSpec) DeepCopyInto(out *OffsetSpec) {
 	*out = *in
+	if in.LagPercentage != nil {
+		in, out := &in.LagPercentage, &out.LagPercentage
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -61,7 +87,7 @@ func (in *ResetOffset) DeepCopyInto(out *ResetOffset) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -120,7 +146,7 @@ func (in *ResetOffsetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResetOffsetSpec) DeepCopyInto(out *ResetOffsetSpec) {
 	*out = *in
-	out.Offset = in.Offset
+	in.Offset.DeepCopyInto(&out.Offset)
 	out.Ref = in.Ref
 	return
 }
@@ -143,6 +169,11 @@ func (in *ResetOffsetStatus) DeepCopyInto(out *ResetOffsetStatus) {
 		*out = make([]OffsetMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaConfigInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -51,4 +51,7 @@ func (cs *KafkaChannelSpec) SetDefaults(ctx context.Context) {
 	if cs.ReplicationFactor == 0 {
 		cs.ReplicationFactor = constants.DefaultReplicationFactor
 	}
+	if cs.DeliveryGuarantee == "" {
+		cs.DeliveryGuarantee = DeliveryGuaranteeAtLeastOnce
+	}
 }
@@ -41,6 +41,7 @@ func TestKafkaChannelDefaults(t *testing.T) {
 				Spec: KafkaChannelSpec{
 					NumPartitions:     constants.DefaultNumPartitions,
 					ReplicationFactor: constants.DefaultReplicationFactor,
+					DeliveryGuarantee: DeliveryGuaranteeAtLeastOnce,
 				},
 			},
 		},
@@ -57,6 +58,7 @@ func TestKafkaChannelDefaults(t *testing.T) {
 				Spec: KafkaChannelSpec{
 					NumPartitions:     constants.DefaultNumPartitions,
 					ReplicationFactor: testReplicationFactor,
+					DeliveryGuarantee: DeliveryGuaranteeAtLeastOnce,
 				},
 			},
 		},
@@ -73,6 +75,26 @@ func TestKafkaChannelDefaults(t *testing.T) {
 				Spec: KafkaChannelSpec{
 					NumPartitions:     testNumPartitions,
 					ReplicationFactor: constants.DefaultReplicationFactor,
+					DeliveryGuarantee: DeliveryGuaranteeAtLeastOnce,
+				},
+			},
+		},
+		"deliveryGuarantee set": {
+			initial: KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     testNumPartitions,
+					ReplicationFactor: testReplicationFactor,
+					DeliveryGuarantee: DeliveryGuaranteeAtMostOnce,
+				},
+			},
+			expected: KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     testNumPartitions,
+					ReplicationFactor: testReplicationFactor,
+					DeliveryGuarantee: DeliveryGuaranteeAtMostOnce,
 				},
 			},
 		},
@@ -64,6 +64,10 @@ const (
 	// KafkaChannelConditionTopicReady has status True when the Kafka topic to use by the channel exists.
 	KafkaChannelConditionTopicReady apis.ConditionType = "TopicReady"
 
+	// KafkaChannelConditionTopicPartitionsReady has status True when the partition count of an existing
+	// Kafka topic matches the desired one. It is informational only and does not affect readiness.
+	KafkaChannelConditionTopicPartitionsReady apis.ConditionType = "TopicPartitionsReady"
+
 	// KafkaChannelConditionConfigReady has status True when the Kafka configuration to use by the channel exists and is valid
 	// (ie. the connection has been established).
 	KafkaChannelConditionConfigReady apis.ConditionType = "ConfigurationReady"
@@ -181,6 +185,18 @@ func (cs *KafkaChannelStatus) MarkTopicFailed(reason, messageFormat string, mess
 	cs.GetConditionSet().Manage(cs).MarkFalse(KafkaChannelConditionTopicReady, reason, messageFormat, messageA...)
 }
 
+func (cs *KafkaChannelStatus) MarkTopicPartitionsTrue() {
+	cs.GetConditionSet().Manage(cs).MarkTrue(KafkaChannelConditionTopicPartitionsReady)
+}
+
+func (cs *KafkaChannelStatus) MarkTopicPartitionsTrueWithReason(reason, messageFormat string, messageA ...interface{}) {
+	cs.GetConditionSet().Manage(cs).MarkTrueWithReason(KafkaChannelConditionTopicPartitionsReady, reason, messageFormat, messageA...)
+}
+
+func (cs *KafkaChannelStatus) MarkTopicPartitionsDrifted(reason, messageFormat string, messageA ...interface{}) {
+	cs.GetConditionSet().Manage(cs).MarkFalse(KafkaChannelConditionTopicPartitionsReady, reason, messageFormat, messageA...)
+}
+
 func (cs *KafkaChannelStatus) MarkConfigTrue() {
 	cs.GetConditionSet().Manage(cs).MarkTrue(KafkaChannelConditionConfigReady)
 }
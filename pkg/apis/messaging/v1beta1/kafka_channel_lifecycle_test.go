@@ -255,6 +255,7 @@ func TestChannelIsReady(t *testing.T) {
 		setAddress              bool
 		markEndpointsReady      bool
 		markTopicReady          bool
+		markPartitionsDrifted   bool
 		wantReady               bool
 		dispatcherStatus        *appsv1.DeploymentStatus
 	}{{
@@ -327,6 +328,17 @@ func TestChannelIsReady(t *testing.T) {
 		setAddress:              true,
 		markTopicReady:          false,
 		wantReady:               false,
+	}, {
+		name:                    "topic partitions drifted",
+		markServiceReady:        true,
+		markConfigurationReady:  true,
+		markChannelServiceReady: true,
+		markEndpointsReady:      true,
+		dispatcherStatus:        deploymentStatusReady,
+		setAddress:              true,
+		markTopicReady:          true,
+		markPartitionsDrifted:   true,
+		wantReady:               true,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -365,6 +377,9 @@ func TestChannelIsReady(t *testing.T) {
 			} else {
 				cs.MarkTopicFailed("NotReadyTopic", "testing")
 			}
+			if test.markPartitionsDrifted {
+				cs.MarkTopicPartitionsDrifted("PartitionCountDrift", "testing")
+			}
 			got := cs.IsReady()
 			if test.wantReady != got {
 				t.Errorf("unexpected readiness: want %v, got %v", test.wantReady, got)
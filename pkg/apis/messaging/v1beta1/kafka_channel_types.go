@@ -67,10 +67,45 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// RetentionDuration is the duration for which events will be retained in the Kafka Topic, expressed
+	// as an ISO-8601 duration (e.g. "PT168H"). By default, the retention configured in the ConfigMap is used.
+	// +optional
+	RetentionDuration string `json:"retentionDuration,omitempty"`
+
+	// MinInSyncReplicas is the minimum number of replicas that must acknowledge a write to the Kafka Topic
+	// (the "min.insync.replicas" topic config), and cannot exceed the ReplicationFactor. By default, the
+	// broker's configuration is used.
+	// +optional
+	MinInSyncReplicas int16 `json:"minInSyncReplicas,omitempty"`
+
+	// TopicConfig holds additional Kafka topic configs (e.g. "segment.ms" or "max.message.bytes") applied
+	// when the Kafka Topic is created. Only the topic configs not covered by the other fields are accepted.
+	// +optional
+	TopicConfig map[string]string `json:"topicConfig,omitempty"`
+
+	// DeliveryGuarantee is the default offset commit strategy of the subscriptions of the channel, either
+	// AtLeastOnce or AtMostOnce. By default, it is set to AtLeastOnce. It is only supported by the consolidated
+	// KafkaChannel, the distributed KafkaChannel always delivers the messages at least once.
+	// +optional
+	DeliveryGuarantee DeliveryGuarantee `json:"deliveryGuarantee,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
 
+// DeliveryGuarantee defines when the offset of a message is committed relative to its delivery to a subscriber.
+type DeliveryGuarantee string
+
+const (
+	// DeliveryGuaranteeAtLeastOnce commits the offset of a message once it has been delivered to the subscriber,
+	// redelivering the message if the dispatcher fails before then.
+	DeliveryGuaranteeAtLeastOnce DeliveryGuarantee = "AtLeastOnce"
+
+	// DeliveryGuaranteeAtMostOnce commits the offset of a message before delivering it to the subscriber,
+	// never redelivering the message even if its delivery fails.
+	DeliveryGuaranteeAtMostOnce DeliveryGuarantee = "AtMostOnce"
+)
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type Channelable.
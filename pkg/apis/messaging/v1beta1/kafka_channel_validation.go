@@ -19,14 +19,54 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/rickb777/date/period"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
 )
 
+// topicNameSeparator is the separator used to join the namespace and name of a KafkaChannel into its topic name
+const topicNameSeparator = "."
+
+// allowedTopicConfigKeys are the Kafka topic configs which may be passed through the TopicConfig of a KafkaChannel.
+// The topic configs set from dedicated fields (retention.ms and min.insync.replicas) are deliberately excluded.
+var allowedTopicConfigKeys = sets.NewString(
+	"cleanup.policy",
+	"compression.type",
+	"delete.retention.ms",
+	"file.delete.delay.ms",
+	"flush.messages",
+	"flush.ms",
+	"index.interval.bytes",
+	"max.compaction.lag.ms",
+	"max.message.bytes",
+	"message.downconversion.enable",
+	"message.timestamp.difference.max.ms",
+	"message.timestamp.type",
+	"min.cleanable.dirty.ratio",
+	"min.compaction.lag.ms",
+	"preallocate",
+	"retention.bytes",
+	"segment.bytes",
+	"segment.index.bytes",
+	"segment.jitter.ms",
+	"segment.ms",
+	"unclean.leader.election.enable",
+)
+
 func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 	errs := c.Spec.Validate(ctx).ViaField("spec")
 
+	// Reject names containing the topic name separator, which would make the topic name ambiguous.
+	// Existing channels are left alone since names are immutable.
+	if !apis.IsInUpdate(ctx) && strings.Contains(c.Name, topicNameSeparator) {
+		iv := apis.ErrInvalidValue(c.Name, "name")
+		iv.Details = fmt.Sprintf("name cannot contain %q, it is used to build the Kafka topic name", topicNameSeparator)
+		errs = errs.Also(iv.ViaField("metadata"))
+	}
+
 	// Validate annotations
 	if c.Annotations != nil {
 		if scope, ok := c.Annotations[eventing.ScopeAnnotationKey]; ok {
@@ -38,6 +78,11 @@ func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*KafkaChannel)
+		errs = errs.Also(c.CheckImmutableFields(ctx, original))
+	}
+
 	return errs
 }
 
@@ -54,6 +99,38 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(fe)
 	}
 
+	if cs.RetentionDuration != "" {
+		retentionDuration, err := period.Parse(cs.RetentionDuration)
+		if err != nil || retentionDuration.IsZero() || retentionDuration.IsNegative() {
+			fe := apis.ErrInvalidValue(cs.RetentionDuration, "retentionDuration")
+			errs = errs.Also(fe)
+		}
+	}
+
+	if cs.MinInSyncReplicas < 0 || (cs.ReplicationFactor > 0 && cs.MinInSyncReplicas > cs.ReplicationFactor) {
+		fe := apis.ErrInvalidValue(cs.MinInSyncReplicas, "minInSyncReplicas")
+		fe.Details = fmt.Sprintf("expected a value between 0 and the replicationFactor (%d)", cs.ReplicationFactor)
+		errs = errs.Also(fe)
+	}
+
+	for key, value := range cs.TopicConfig {
+		if !allowedTopicConfigKeys.Has(key) {
+			fe := apis.ErrInvalidKeyName(key, "topicConfig")
+			fe.Details = fmt.Sprintf("expected one of %v", allowedTopicConfigKeys.List())
+			errs = errs.Also(fe)
+		} else if value == "" {
+			errs = errs.Also(apis.ErrMissingField(fmt.Sprintf("topicConfig[%s]", key)))
+		}
+	}
+
+	switch cs.DeliveryGuarantee {
+	case "", DeliveryGuaranteeAtLeastOnce, DeliveryGuaranteeAtMostOnce:
+	default:
+		fe := apis.ErrInvalidValue(cs.DeliveryGuarantee, "deliveryGuarantee")
+		fe.Details = fmt.Sprintf("expected either %q or %q", DeliveryGuaranteeAtLeastOnce, DeliveryGuaranteeAtMostOnce)
+		errs = errs.Also(fe)
+	}
+
 	for i, subscriber := range cs.SubscribableSpec.Subscribers {
 		if subscriber.ReplyURI == nil && subscriber.SubscriberURI == nil {
 			fe := apis.ErrMissingField("replyURI", "subscriberURI")
@@ -63,3 +140,32 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 	return errs
 }
+
+// CheckImmutableFields ensures the topic configuration of an existing KafkaChannel is only changed in ways
+// that can be applied to its Kafka topic: NumPartitions may be increased but never decreased, and the
+// ReplicationFactor cannot be changed.
+func (c *KafkaChannel) CheckImmutableFields(ctx context.Context, original *KafkaChannel) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+
+	if c.Spec.NumPartitions < original.Spec.NumPartitions {
+		errs = errs.Also(&apis.FieldError{
+			Message: "NumPartitions cannot be decreased",
+			Paths:   []string{"spec.numPartitions"},
+			Details: fmt.Sprintf("%d -> %d", original.Spec.NumPartitions, c.Spec.NumPartitions),
+		})
+	}
+
+	if c.Spec.ReplicationFactor != original.Spec.ReplicationFactor {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec.replicationFactor"},
+			Details: fmt.Sprintf("%d -> %d", original.Spec.ReplicationFactor, c.Spec.ReplicationFactor),
+		})
+	}
+
+	return errs
+}
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -72,6 +73,149 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"valid retentionDuration": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "PT168H",
+				},
+			},
+			want: nil,
+		},
+		"malformed retentionDuration": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "1 week",
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("1 week", "spec.retentionDuration")
+				return fe
+			}(),
+		},
+		"valid minInSyncReplicas": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 3,
+					MinInSyncReplicas: 2,
+				},
+			},
+			want: nil,
+		},
+		"minInSyncReplicas equal to replicationFactor": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 3,
+					MinInSyncReplicas: 3,
+				},
+			},
+			want: nil,
+		},
+		"minInSyncReplicas exceeds replicationFactor": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 2,
+					MinInSyncReplicas: 3,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(3, "spec.minInSyncReplicas")
+				fe.Details = "expected a value between 0 and the replicationFactor (2)"
+				return fe
+			}(),
+		},
+		"negative minInSyncReplicas": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 2,
+					MinInSyncReplicas: -1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(-1, "spec.minInSyncReplicas")
+				fe.Details = "expected a value between 0 and the replicationFactor (2)"
+				return fe
+			}(),
+		},
+		"valid topicConfig": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					TopicConfig:       map[string]string{"segment.ms": "3600000", "max.message.bytes": "2097152"},
+				},
+			},
+			want: nil,
+		},
+		"unknown topicConfig key": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					TopicConfig:       map[string]string{"segment.millis": "3600000"},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidKeyName("segment.millis", "spec.topicConfig")
+				fe.Details = fmt.Sprintf("expected one of %v", allowedTopicConfigKeys.List())
+				return fe
+			}(),
+		},
+		"topicConfig key covered by a dedicated field": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					TopicConfig:       map[string]string{"retention.ms": "3600000"},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidKeyName("retention.ms", "spec.topicConfig")
+				fe.Details = fmt.Sprintf("expected one of %v", allowedTopicConfigKeys.List())
+				return fe
+			}(),
+		},
+		"empty topicConfig value": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					TopicConfig:       map[string]string{"segment.ms": ""},
+				},
+			},
+			want: apis.ErrMissingField("spec.topicConfig[segment.ms]"),
+		},
+		"valid deliveryGuarantee": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					DeliveryGuarantee: DeliveryGuaranteeAtMostOnce,
+				},
+			},
+			want: nil,
+		},
+		"invalid deliveryGuarantee": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					DeliveryGuarantee: "ExactlyOnce",
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(DeliveryGuarantee("ExactlyOnce"), "spec.deliveryGuarantee")
+				fe.Details = `expected either "AtLeastOnce" or "AtMostOnce"`
+				return fe
+			}(),
+		},
 		"valid subscribers array": {
 			cr: &KafkaChannel{
 				Spec: KafkaChannelSpec{
@@ -131,6 +275,34 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return errs
 			}(),
 		},
+		"name containing the topic separator": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my.channel",
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("my.channel", "metadata.name")
+				fe.Details = `name cannot contain ".", it is used to build the Kafka topic name`
+				return fe
+			}(),
+		},
+		"valid name": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-channel",
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
 		"invalid scope annotation": {
 			cr: &KafkaChannel{
 				ObjectMeta: metav1.ObjectMeta{
@@ -160,3 +332,86 @@ func TestKafkaChannelValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaChannelImmutableFields(t *testing.T) {
+
+	original := &KafkaChannel{
+		Spec: KafkaChannelSpec{
+			NumPartitions:     2,
+			ReplicationFactor: 1,
+		},
+	}
+
+	testCases := map[string]struct {
+		cr   *KafkaChannel
+		want *apis.FieldError
+	}{
+		"unchanged spec": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     2,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
+		"increased numPartitions": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     4,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
+		"decreased numPartitions": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "NumPartitions cannot be decreased",
+				Paths:   []string{"spec.numPartitions"},
+				Details: "2 -> 1",
+			},
+		},
+		"changed replicationFactor": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     2,
+					ReplicationFactor: 3,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec.replicationFactor"},
+				Details: "1 -> 3",
+			},
+		},
+		"increased numPartitions with changed replicationFactor": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     4,
+					ReplicationFactor: 2,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec.replicationFactor"},
+				Details: "1 -> 2",
+			},
+		},
+	}
+
+	for n, test := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := apis.WithinUpdate(context.Background(), original)
+			got := test.cr.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: validate (-want, +got) = %v", n, diff)
+			}
+		})
+	}
+}
@@ -54,6 +54,11 @@ func TestSetDefaults(t *testing.T) {
 			t.Fatalf("Unexpected consumers (-want, +got): %s", diff)
 		}
 	}
+	assertInitialOffset := func(t *testing.T, ks KafkaSource, expected string) {
+		if diff := cmp.Diff(Offset(expected), ks.Spec.InitialOffset); diff != "" {
+			t.Fatalf("Unexpected initialOffset (-want, +got): %s", diff)
+		}
+	}
 	testCases := []defaultKafkaTestArgs{
 		{
 			Name:       "nil spec",
@@ -82,6 +87,18 @@ func TestSetDefaults(t *testing.T) {
 			Expected:   "4",
 			AssertFunc: assertConsumers,
 		},
+		{
+			Name:       "initialOffset not set",
+			Initial:    KafkaSource{},
+			Expected:   string(OffsetLatest),
+			AssertFunc: assertInitialOffset,
+		},
+		{
+			Name:       "initialOffset set",
+			Initial:    KafkaSource{Spec: KafkaSourceSpec{InitialOffset: OffsetEarliest}},
+			Expected:   string(OffsetEarliest),
+			AssertFunc: assertInitialOffset,
+		},
 	}
 
 	for _, tc := range testCases {
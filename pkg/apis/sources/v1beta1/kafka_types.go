@@ -33,6 +33,7 @@ import (
 
 // +genclient
 // +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
 // +genreconciler
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // KafkaSource is the Schema for the kafkasources API.
@@ -72,6 +73,25 @@ type KafkaSourceSpec struct {
 	// +optional
 	ConsumerGroup string `json:"consumerGroup,omitempty"`
 
+	// InitialOffset is the offset a newly created consumer group starts consuming
+	// from, either Latest or Earliest. Defaults to Latest. It has no effect once
+	// the consumer group has committed offsets.
+	// +optional
+	InitialOffset Offset `json:"initialOffset,omitempty"`
+
+	// MaxInFlightPerPartition is the maximum number of records held in flight
+	// (not yet acknowledged) per partition. Unlimited when not specified.
+	//
+	// This is a pointer to distinguish between explicit
+	// zero and not specified.
+	// +optional
+	MaxInFlightPerPartition *int32 `json:"maxInFlightPerPartition,omitempty"`
+
+	// CloudEventAttributes overrides the type and source attributes of the
+	// CloudEvents created from Kafka records which are not CloudEvents already.
+	// +optional
+	CloudEventAttributes *KafkaCloudEventAttributes `json:"cloudEventAttributes,omitempty"`
+
 	// inherits duck/v1 SourceSpec, which currently provides:
 	// * Sink - a reference to an object that will resolve to a domain name or
 	//   a URI directly to use as the sink.
@@ -80,6 +100,30 @@ type KafkaSourceSpec struct {
 	duckv1.SourceSpec `json:",inline"`
 }
 
+// Offset is the position in a Kafka topic partition a consumer starts from.
+type Offset string
+
+const (
+	// OffsetLatest starts consuming from the newest records, skipping the existing ones.
+	OffsetLatest Offset = "Latest"
+
+	// OffsetEarliest starts consuming from the oldest records still retained.
+	OffsetEarliest Offset = "Earliest"
+)
+
+// KafkaCloudEventAttributes defines the CloudEvent attributes overriding
+// the KafkaSource defaults.
+type KafkaCloudEventAttributes struct {
+	// Type is the CloudEvent type attribute. Defaults to KafkaEventType.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source is the CloudEvent source attribute, which must be a URI-reference.
+	// Defaults to the KafkaEventSource of the record topic.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
 const (
 	// KafkaEventType is the Kafka CloudEvent type.
 	KafkaEventType = "dev.knative.kafka.event"
@@ -18,17 +18,29 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 )
 
+// consumerGroupRegexp matches the characters allowed in a new consumer group ID (the same as for Kafka topic names).
+var consumerGroupRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
 // Validate ensures KafkaSource is properly configured.
 func (ks *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := ks.Spec.Validate(ctx).ViaField("spec")
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*KafkaSource)
 		errs = errs.Also(ks.CheckImmutableFields(ctx, original))
+		if ks.DeletionTimestamp == nil {
+			errs = errs.Also(ks.Spec.KafkaAuthSpec.ValidateSecretReferences(ctx, ks.Namespace, &original.Spec.KafkaAuthSpec).ViaField("spec"))
+		}
+	} else {
+		errs = errs.Also(ks.Spec.KafkaAuthSpec.ValidateSecretReferences(ctx, ks.Namespace, nil).ViaField("spec"))
 	}
 	return errs
 }
@@ -47,6 +59,50 @@ func (kss *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(apis.ErrMissingField("bootstrapServer"))
 	}
 
+	// The consumer group is immutable, so it is only checked when the source is created.
+	// It is normally set by SetDefaults when not provided.
+	if !apis.IsInUpdate(ctx) {
+		if kss.ConsumerGroup == "" {
+			errs = errs.Also(apis.ErrMissingField("consumerGroup"))
+		} else if !consumerGroupRegexp.MatchString(kss.ConsumerGroup) {
+			fe := apis.ErrInvalidValue(kss.ConsumerGroup, "consumerGroup")
+			fe.Details = "expected only alphanumeric characters, '.', '_' and '-'"
+			errs = errs.Also(fe)
+		}
+	}
+	switch kss.InitialOffset {
+	case "", OffsetLatest, OffsetEarliest:
+	default:
+		fe := apis.ErrInvalidValue(kss.InitialOffset, "initialOffset")
+		fe.Details = fmt.Sprintf("expected either %q or %q", OffsetLatest, OffsetEarliest)
+		errs = errs.Also(fe)
+	}
+	if kss.MaxInFlightPerPartition != nil && *kss.MaxInFlightPerPartition <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*kss.MaxInFlightPerPartition, "maxInFlightPerPartition"))
+	}
+	if kss.CloudEventAttributes != nil {
+		errs = errs.Also(kss.CloudEventAttributes.Validate(ctx).ViaField("cloudEventAttributes"))
+	}
+
+	return errs
+}
+
+// Validate ensures the CloudEvent attribute overrides are valid CloudEvent attribute values.
+func (a *KafkaCloudEventAttributes) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if a.Type == "" && a.Source == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("type", "source"))
+	}
+	if strings.ContainsAny(a.Type, " \t\r\n") {
+		errs = errs.Also(apis.ErrInvalidValue(a.Type, "type"))
+	}
+	if a.Source != "" {
+		if _, err := url.Parse(a.Source); err != nil || strings.ContainsAny(a.Source, " \t\r\n") {
+			errs = errs.Also(apis.ErrInvalidValue(a.Source, "source"))
+		}
+	}
+
 	return errs
 }
 
@@ -18,8 +18,12 @@ package v1beta1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -86,14 +90,142 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 			orig: &KafkaSourceSpec{
 				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
 				Topics:        fullSpec.Topics,
+				ConsumerGroup: fullSpec.ConsumerGroup,
 				SourceSpec:    fullSpec.SourceSpec,
 			},
 			allowed: true,
 		},
+		"empty consumerGroup": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+			},
+			allowed: false,
+		},
+		"defaulted consumerGroup": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				ConsumerGroup: "knative-kafka-source-2c2ef4b1-2a5c-4ae2-8d0d-9b5b3a4a4d0f",
+				SourceSpec:    fullSpec.SourceSpec,
+			},
+			allowed: true,
+		},
+		"invalid consumerGroup": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				ConsumerGroup: "my group/1",
+				SourceSpec:    fullSpec.SourceSpec,
+			},
+			allowed: false,
+		},
 		"full source": {
 			orig:    &fullSpec,
 			allowed: true,
 		},
+		"positive maxInFlightPerPartition": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:           fullSpec.KafkaAuthSpec,
+				Topics:                  fullSpec.Topics,
+				ConsumerGroup:           fullSpec.ConsumerGroup,
+				SourceSpec:              fullSpec.SourceSpec,
+				MaxInFlightPerPartition: pointer.Int32Ptr(10),
+			},
+			allowed: true,
+		},
+		"zero maxInFlightPerPartition": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:           fullSpec.KafkaAuthSpec,
+				Topics:                  fullSpec.Topics,
+				SourceSpec:              fullSpec.SourceSpec,
+				MaxInFlightPerPartition: pointer.Int32Ptr(0),
+			},
+			allowed: false,
+		},
+		"valid cloudEventAttributes": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				ConsumerGroup: fullSpec.ConsumerGroup,
+				SourceSpec:    fullSpec.SourceSpec,
+				CloudEventAttributes: &KafkaCloudEventAttributes{
+					Type:   "com.example.order.created",
+					Source: "/orders/eu-west",
+				},
+			},
+			allowed: true,
+		},
+		"empty cloudEventAttributes": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:        fullSpec.KafkaAuthSpec,
+				Topics:               fullSpec.Topics,
+				SourceSpec:           fullSpec.SourceSpec,
+				CloudEventAttributes: &KafkaCloudEventAttributes{},
+			},
+			allowed: false,
+		},
+		"invalid cloudEventAttributes type": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				CloudEventAttributes: &KafkaCloudEventAttributes{
+					Type: "order created",
+				},
+			},
+			allowed: false,
+		},
+		"invalid cloudEventAttributes source": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				CloudEventAttributes: &KafkaCloudEventAttributes{
+					Source: "http://[::1]:namedport",
+				},
+			},
+			allowed: false,
+		},
+		"latest initialOffset": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				ConsumerGroup: fullSpec.ConsumerGroup,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: true,
+		},
+		"earliest initialOffset": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				ConsumerGroup: fullSpec.ConsumerGroup,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetEarliest,
+			},
+			allowed: true,
+		},
+		"invalid initialOffset": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: Offset("Middle"),
+			},
+			allowed: false,
+		},
+		"negative maxInFlightPerPartition": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:           fullSpec.KafkaAuthSpec,
+				Topics:                  fullSpec.Topics,
+				SourceSpec:              fullSpec.SourceSpec,
+				MaxInFlightPerPartition: pointer.Int32Ptr(-1),
+			},
+			allowed: false,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -110,6 +242,39 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 	}
 }
 
+func TestKafkaSourceValidateSecretReferences(t *testing.T) {
+	getter := func(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+		return &corev1.Secret{Data: map[string][]byte{"user": []byte("user")}}, nil
+	}
+	source := &KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source"},
+		Spec:       *fullSpec.DeepCopy(),
+	}
+	source.Spec.Net.SASL = bindingsv1beta1.KafkaSASLSpec{
+		Enable: true,
+		Password: bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-auth"},
+				Key:                  "password",
+			},
+		},
+	}
+
+	ctx := bindingsv1beta1.WithSecretGetter(apis.WithinCreate(context.TODO()), getter)
+	err := source.Validate(ctx)
+	if err == nil || !strings.Contains(err.Error(), "spec.net.sasl.password.secretKeyRef") {
+		t.Errorf("expected missing secret key error, got: %v", err)
+	}
+
+	// Updates that leave the net settings untouched are accepted even though the secret key is missing
+	updated := source.DeepCopy()
+	updated.Status.Consumers = 2
+	ctx = bindingsv1beta1.WithSecretGetter(apis.WithinUpdate(context.TODO(), source), getter)
+	if err := updated.Validate(ctx); err != nil {
+		t.Errorf("unexpected error updating a source whose secret key is gone: %v", err)
+	}
+}
+
 func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
 		orig    *KafkaSourceSpec
@@ -226,6 +391,15 @@ func TestKafkaSourceCheckImmutableFields(t *testing.T) {
 			updated: fullSpec,
 			allowed: true,
 		},
+		"consumerGroup cleared": {
+			orig: &fullSpec,
+			updated: KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+			},
+			allowed: false,
+		},
 		"consumerGroup changed": {
 			orig: &fullSpec,
 			updated: KafkaSourceSpec{
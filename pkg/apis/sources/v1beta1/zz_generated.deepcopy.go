@@ -24,6 +24,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaCloudEventAttributes) DeepCopyInto(out *KafkaCloudEventAttributes) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCloudEventAttributes.
+func (in *KafkaCloudEventAttributes) DeepCopy() *KafkaCloudEventAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaCloudEventAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSource) DeepCopyInto(out *KafkaSource) {
 	*out = *in
@@ -99,6 +115,16 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxInFlightPerPartition != nil {
+		in, out := &in.MaxInFlightPerPartition, &out.MaxInFlightPerPartition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CloudEventAttributes != nil {
+		in, out := &in.CloudEventAttributes, &out.CloudEventAttributes
+		*out = new(KafkaCloudEventAttributes)
+		**out = **in
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	return
 }
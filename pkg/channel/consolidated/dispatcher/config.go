@@ -21,6 +21,22 @@ type ChannelConfig struct {
 	Name          string
 	HostName      string
 	Subscriptions []Subscription
+
+	// SharedConsumerGroup makes all the subscriptions of the channel consume from a single consumer group,
+	// fanning out each message to every subscription.
+	SharedConsumerGroup bool
+	// ManualCommit makes all the subscriptions of the channel commit their offsets synchronously, only once
+	// the subscriber has acknowledged each message, instead of relying on the periodic auto-commit.
+	ManualCommit bool
+	// AtMostOnce makes all the subscriptions of the channel commit the offset of each message before delivering it.
+	AtMostOnce bool
+}
+
+// subscriptionWithCommitMode returns the subscription with the commit mode resulting from the channel configuration.
+func (cc ChannelConfig) subscriptionWithCommitMode(sub Subscription) Subscription {
+	sub.ManualCommit = sub.ManualCommit || cc.ManualCommit
+	sub.AtMostOnce = sub.AtMostOnce || cc.AtMostOnce
+	return sub
 }
 
 func (cc ChannelConfig) SubscriptionsUIDs() []string {
@@ -19,11 +19,13 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 	eventingchannels "knative.dev/eventing/pkg/channel"
@@ -39,6 +41,7 @@ type consumerMessageHandler struct {
 	consumerGroup     string
 	reporter          eventingchannels.StatsReporter
 	channelNs         string
+	channelName       string
 }
 
 var _ consumer.KafkaConsumerHandler = (*consumerMessageHandler)(nil)
@@ -52,6 +55,7 @@ func (c consumerMessageHandler) SetReady(partition int32, ready bool) {
 }
 
 func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
+	start := time.Now()
 	defer func() {
 		if r := recover(); r != nil {
 			c.logger.Warn("Panic happened while handling a message",
@@ -60,6 +64,13 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 			)
 		}
 	}()
+
+	// Do not dispatch new messages while the channel is being drained
+	if !c.kafkaSubscription.startDispatch() {
+		return false, nil
+	}
+	defer c.kafkaSubscription.endDispatch()
+
 	message := protocolkafka.NewMessageFromConsumerMessage(consumerMessage)
 	if message.ReadEncoding() == binding.EncodingUnknown {
 		return false, errors.New("received a message with unknown encoding")
@@ -91,6 +102,7 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 		EventType: string(te),
 	}
 	_ = fanout.ParseDispatchResultAndReportMetrics(fanout.NewDispatchResult(err, dispatchExecutionInfo), c.reporter, args)
+	reportDispatchLatency(ctx, types.NamespacedName{Namespace: c.channelNs, Name: c.channelName}, c.sub.UID, time.Since(start))
 
 	// NOTE: only return `true` here if DispatchMessage actually delivered the message.
 	return err == nil, err
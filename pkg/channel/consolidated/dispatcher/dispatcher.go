@@ -18,14 +18,17 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/google/uuid"
 	"go.opencensus.io/trace"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -45,6 +48,9 @@ import (
 
 const (
 	dispatcherReadySubHeader = "K-Subscriber-Status"
+
+	// maxStartupJitter caps the configured startup jitter
+	maxStartupJitter = time.Minute
 )
 
 type TopicFunc func(separator, namespace, name string) string
@@ -53,6 +59,21 @@ type KafkaDispatcherArgs struct {
 	Brokers   []string
 	Config    *config.EventingKafkaConfig
 	TopicFunc TopicFunc
+
+	// DrainTimeout bounds how long CleanupChannel waits for in-flight dispatches
+	// before closing the consumer groups. Zero closes them immediately.
+	DrainTimeout time.Duration
+
+	// StartupJitter is the upper bound (capped to maxStartupJitter) of the random delay
+	// waited before the consumer groups are first established, so that the dispatcher
+	// replicas restarted together don't all join their groups at once. Zero disables it.
+	StartupJitter time.Duration
+}
+
+// sharedConsumerGroup is a consumer group fanning out messages to all the subscriptions of a channel
+type sharedConsumerGroup struct {
+	consumerGroup sarama.ConsumerGroup
+	handler       *sharedConsumerMessageHandler
 }
 
 type KafkaDispatcher struct {
@@ -73,8 +94,16 @@ type KafkaDispatcher struct {
 	subscriptions        map[types.UID]Subscription
 	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
 
-	topicFunc TopicFunc
-	logger    *zap.SugaredLogger
+	// sharedConsumerGroups holds the consumer group shared by all the subscriptions
+	// of the channels configured with SharedConsumerGroup
+	sharedConsumerGroups map[types.NamespacedName]*sharedConsumerGroup
+
+	topicFunc    TopicFunc
+	drainTimeout time.Duration
+	logger       *zap.SugaredLogger
+
+	// startupDeadline is the time before which no consumer group is established
+	startupDeadline time.Time
 }
 
 func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispatcher, error) {
@@ -90,9 +119,16 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs) (*KafkaDispat
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
 		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
+		sharedConsumerGroups: make(map[types.NamespacedName]*sharedConsumerGroup),
 		kafkaSyncProducer:    producer,
 		logger:               logging.FromContext(ctx),
 		topicFunc:            args.TopicFunc,
+		drainTimeout:         args.DrainTimeout,
+	}
+
+	if delay := startupDelay(args.StartupJitter); delay > 0 {
+		dispatcher.logger.Infow("Delaying the creation of the consumer groups", zap.Duration("delay", delay))
+		dispatcher.startupDeadline = time.Now().Add(delay)
 	}
 
 	// initialize and start the subscription endpoint server
@@ -172,6 +208,11 @@ func (k UpdateError) Error() string {
 
 // ReconcileConsumers will be called by new CRD based kafka channel dispatcher controller.
 func (d *KafkaDispatcher) ReconcileConsumers(config *ChannelConfig) error {
+	// Wait for the startup jitter, if any, before joining the consumer groups
+	if wait := time.Until(d.startupDeadline); wait > 0 {
+		time.Sleep(wait)
+	}
+
 	channelNamespacedName := types.NamespacedName{
 		Namespace: config.Namespace,
 		Name:      config.Name,
@@ -187,6 +228,18 @@ func (d *KafkaDispatcher) ReconcileConsumers(config *ChannelConfig) error {
 	// This loop takes care of filling toAddSubs and toRemoveSubs for new and existing channels
 	thisChannelKafkaSubscriptions := d.channelSubscriptions[channelNamespacedName]
 
+	// Switching between shared and per subscription consumer groups, or between commit modes, requires subscribing all the subs again
+	if _, shared := d.sharedConsumerGroups[channelNamespacedName]; thisChannelKafkaSubscriptions != nil && (shared != config.SharedConsumerGroup || d.commitModeChanged(config)) {
+		d.logger.Infow("Switching the consumer group mode of the channel", zap.Any("channelRef", channelNamespacedName),
+			zap.Bool("shared", config.SharedConsumerGroup), zap.Bool("manualCommit", config.ManualCommit), zap.Bool("atMostOnce", config.AtMostOnce))
+		for _, subUid := range thisChannelKafkaSubscriptions.subs.UnsortedList() {
+			if err := d.unsubscribe(channelNamespacedName, d.subscriptions[types.UID(subUid)]); err != nil {
+				d.logger.Warnw("Error while unsubscribing", zap.Error(err))
+			}
+		}
+		thisChannelKafkaSubscriptions = nil
+	}
+
 	var existingSubsForThisChannel sets.String
 	if thisChannelKafkaSubscriptions != nil {
 		existingSubsForThisChannel = thisChannelKafkaSubscriptions.subs
@@ -206,7 +259,7 @@ func (d *KafkaDispatcher) ReconcileConsumers(config *ChannelConfig) error {
 	thisChannelToAddSubs := newSubsForThisChannel.Difference(existingSubsForThisChannel)
 	for _, subSpec := range config.Subscriptions {
 		if thisChannelToAddSubs.Has(string(subSpec.UID)) {
-			toAddSubs[subSpec.UID] = subSpec
+			toAddSubs[subSpec.UID] = config.subscriptionWithCommitMode(subSpec)
 		}
 	}
 
@@ -215,7 +268,11 @@ func (d *KafkaDispatcher) ReconcileConsumers(config *ChannelConfig) error {
 
 	failedToSubscribe := make(UpdateError)
 	for subUid, subSpec := range toAddSubs {
-		if err := d.subscribe(channelNamespacedName, subSpec); err != nil {
+		subscribe := d.subscribe
+		if config.SharedConsumerGroup {
+			subscribe = d.subscribeShared
+		}
+		if err := subscribe(channelNamespacedName, subSpec); err != nil {
 			failedToSubscribe[subUid] = err
 		}
 	}
@@ -234,6 +291,20 @@ func (d *KafkaDispatcher) ReconcileConsumers(config *ChannelConfig) error {
 	return failedToSubscribe
 }
 
+// commitModeChanged returns true if the commit mode of any existing subscription of the channel differs from the configured one.
+// commitModeChanged must be called under updateLock.
+func (d *KafkaDispatcher) commitModeChanged(config *ChannelConfig) bool {
+	for _, subSpec := range config.Subscriptions {
+		if existing, ok := d.subscriptions[subSpec.UID]; ok {
+			desired := config.subscriptionWithCommitMode(subSpec)
+			if existing.ManualCommit != desired.ManualCommit || existing.AtMostOnce != desired.AtMostOnce {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // RegisterChannelHost adds a new channel to the host-channel mapping.
 func (d *KafkaDispatcher) RegisterChannelHost(channelConfig *ChannelConfig) error {
 	old, ok := d.hostToChannelMap.LoadOrStore(channelConfig.HostName, eventingchannels.ChannelReference{
@@ -266,22 +337,52 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 	// Remove from the hostToChannel map the mapping with this channel
 	d.hostToChannelMap.Delete(hostname)
 
-	// Remove all subs
+	// Remove all subs under the lock, collecting the consumer groups to be closed
 	d.consumerUpdateLock.Lock()
-	defer d.consumerUpdateLock.Unlock()
-
-	if d.channelSubscriptions[channelRef] == nil {
+	kafkaSubscription := d.channelSubscriptions[channelRef]
+	if kafkaSubscription == nil {
 		// No subs to remove
+		d.consumerUpdateLock.Unlock()
 		return nil
 	}
+	var consumerGroups []sarama.ConsumerGroup
+	for _, s := range kafkaSubscription.subs.UnsortedList() {
+		if consumerGroup := d.removeSubscription(channelRef, d.subscriptions[types.UID(s)]); consumerGroup != nil {
+			consumerGroups = append(consumerGroups, consumerGroup)
+		}
+	}
+	d.consumerUpdateLock.Unlock()
 
-	for _, s := range d.channelSubscriptions[channelRef].subs.UnsortedList() {
-		if err := d.unsubscribe(channelRef, d.subscriptions[types.UID(s)]); err != nil {
-			return err
+	// Stop dispatching new messages and give the in-flight ones a chance to complete, without
+	// blocking the reconciliation of the other channels
+	if d.drainTimeout > 0 {
+		if !kafkaSubscription.drain(d.drainTimeout) {
+			d.logger.Warnw("Timed out waiting for in-flight messages to be dispatched", zap.Any("channelRef", channelRef), zap.Duration("drainTimeout", d.drainTimeout))
 		}
 	}
 
-	return nil
+	// The subs are already removed, so every consumer group must be closed even if closing one fails
+	var err error
+	for _, consumerGroup := range consumerGroups {
+		multierr.AppendInto(&err, consumerGroup.Close())
+	}
+
+	return err
+}
+
+// consumerHandlerOptions returns the consumer handler options required by the subscription, reporting
+// the rebalances of the channel consumer group.
+func consumerHandlerOptions(channelRef types.NamespacedName, groupID string, sub Subscription) []consumer.SaramaConsumerHandlerOption {
+	options := []consumer.SaramaConsumerHandlerOption{
+		consumer.WithSaramaConsumerLifecycleListener(rebalanceReporter{channelRef: channelRef, groupID: groupID}),
+	}
+	if sub.ManualCommit {
+		options = append(options, consumer.WithManualCommit())
+	}
+	if sub.AtMostOnce {
+		options = append(options, consumer.WithAtMostOnce())
+	}
+	return options
 }
 
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
@@ -307,10 +408,11 @@ func (d *KafkaDispatcher) subscribe(channelRef types.NamespacedName, sub Subscri
 		groupID,
 		d.reporter,
 		channelRef.Namespace,
+		channelRef.Name,
 	}
 	d.logger.Debugw("Starting consumer group", zap.Any("channelRef", channelRef),
 		zap.Any("subscription", sub.UID), zap.String("topic", topicName), zap.String("consumer group", groupID))
-	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, []string{topicName}, d.logger, handler)
+	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, []string{topicName}, d.logger, handler, consumerHandlerOptions(channelRef, groupID, sub)...)
 
 	if err != nil {
 		// we can not create a consumer - logging that, with reason
@@ -334,9 +436,82 @@ func (d *KafkaDispatcher) subscribe(channelRef types.NamespacedName, sub Subscri
 	return nil
 }
 
+// subscribeShared adds the subscription to the consumer group shared by all the subscriptions of the channel,
+// starting the consumer group for the first subscription.
+// subscribeShared must be called under updateLock.
+func (d *KafkaDispatcher) subscribeShared(channelRef types.NamespacedName, sub Subscription) error {
+	d.logger.Infow("Subscribing to Kafka Channel with a shared consumer group", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
+
+	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
+	groupID := fmt.Sprintf("kafka.%s.%s", channelRef.Namespace, channelRef.Name)
+
+	// Get or create the channel kafka subscription
+	kafkaSubscription, ok := d.channelSubscriptions[channelRef]
+	if !ok {
+		kafkaSubscription = NewKafkaSubscription(d.logger)
+		d.channelSubscriptions[channelRef] = kafkaSubscription
+	}
+
+	handler := consumerMessageHandler{
+		d.logger,
+		sub,
+		d.dispatcher,
+		kafkaSubscription,
+		groupID,
+		d.reporter,
+		channelRef.Namespace,
+		channelRef.Name,
+	}
+
+	// Get or start the shared consumer group
+	shared, ok := d.sharedConsumerGroups[channelRef]
+	if !ok {
+		sharedHandler := newSharedConsumerMessageHandler(d.logger, groupID)
+		d.logger.Debugw("Starting shared consumer group", zap.Any("channelRef", channelRef),
+			zap.String("topic", topicName), zap.String("consumer group", groupID))
+		consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(groupID, []string{topicName}, d.logger, sharedHandler, consumerHandlerOptions(channelRef, groupID, sub)...)
+		if err != nil {
+			// we can not create a consumer - logging that, with reason
+			d.logger.Infow("Could not create proper consumer", zap.Error(err))
+			return err
+		}
+
+		// sarama reports error in consumerGroup.Error() channel
+		// this goroutine logs errors incoming
+		go func() {
+			for err = range consumerGroup.Errors() {
+				d.logger.Warnw("Error in consumer group", zap.Error(err))
+			}
+		}()
+
+		shared = &sharedConsumerGroup{consumerGroup: consumerGroup, handler: sharedHandler}
+		if d.sharedConsumerGroups == nil {
+			d.sharedConsumerGroups = make(map[types.NamespacedName]*sharedConsumerGroup)
+		}
+		d.sharedConsumerGroups[channelRef] = shared
+	}
+	shared.handler.add(handler)
+
+	// Update the data structures that holds the reconciliation data
+	kafkaSubscription.subs.Insert(string(sub.UID))
+	d.subscriptions[sub.UID] = sub
+
+	return nil
+}
+
 // unsubscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // unsubscribe must be called under updateLock.
 func (d *KafkaDispatcher) unsubscribe(channelRef types.NamespacedName, sub Subscription) error {
+	if consumerGroup := d.removeSubscription(channelRef, sub); consumerGroup != nil {
+		return consumerGroup.Close()
+	}
+	return nil
+}
+
+// removeSubscription removes the subscription from the dispatcher state, returning the consumer
+// group that is no longer used and must be closed by the caller, if any.
+// removeSubscription must be called under updateLock.
+func (d *KafkaDispatcher) removeSubscription(channelRef types.NamespacedName, sub Subscription) sarama.ConsumerGroup {
 	d.logger.Infow("Unsubscribing from channel", zap.Any("channel", channelRef), zap.Any("subscription", sub.UID))
 
 	// Remove the sub spec
@@ -358,7 +533,14 @@ func (d *KafkaDispatcher) unsubscribe(channelRef types.NamespacedName, sub Subsc
 	if consumerGroup, ok := d.subsConsumerGroups[sub.UID]; ok {
 		delete(d.subsConsumerGroups, sub.UID)
 		d.logger.Debugw("Closing cached consumerGroup group", zap.Any("consumer group", consumerGroup))
-		return consumerGroup.Close()
+		return consumerGroup
+	}
+
+	// Remove the sub from the shared consumer group, closing it with the last sub
+	if shared, ok := d.sharedConsumerGroups[channelRef]; ok && shared.handler.remove(sub.UID) == 0 {
+		delete(d.sharedConsumerGroups, channelRef)
+		d.logger.Debugw("Closing shared consumer group", zap.Any("channelRef", channelRef))
+		return shared.consumerGroup
 	}
 	return nil
 }
@@ -370,3 +552,14 @@ func (d *KafkaDispatcher) getChannelReferenceFromHost(host string) (eventingchan
 	}
 	return cr.(eventingchannels.ChannelReference), nil
 }
+
+// startupDelay returns a random delay in [0, jitter), with jitter capped to maxStartupJitter
+func startupDelay(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	if jitter > maxStartupJitter {
+		jitter = maxStartupJitter
+	}
+	return time.Duration(rand.Int63n(int64(jitter)))
+}
@@ -19,9 +19,12 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"knative.dev/eventing-kafka/pkg/common/config"
 
@@ -451,6 +454,281 @@ func TestKafkaDispatcher_CleanupChannel(t *testing.T) {
 	require.NotContains(t, d.subsConsumerGroups, "subscription-2")
 }
 
+// closingConsumerGroup counts its Close calls and fails them with closeErr
+type closingConsumerGroup struct {
+	mockConsumerGroup
+	closeErr error
+	closed   int
+}
+
+func (c *closingConsumerGroup) Close() error {
+	c.closed++
+	return c.closeErr
+}
+
+func TestKafkaDispatcher_CleanupChannelCloseError(t *testing.T) {
+	channelRef := types.NamespacedName{Namespace: "default", Name: "test-channel"}
+	kafkaSubscription := NewKafkaSubscription(zaptest.NewLogger(t).Sugar())
+	kafkaSubscription.subs.Insert("subscription-1", "subscription-2")
+
+	closeErr := errors.New("close error")
+	failing := &closingConsumerGroup{closeErr: closeErr}
+	closing := &closingConsumerGroup{}
+	d := &KafkaDispatcher{
+		channelSubscriptions: map[types.NamespacedName]*KafkaSubscription{channelRef: kafkaSubscription},
+		subsConsumerGroups: map[types.UID]sarama.ConsumerGroup{
+			"subscription-1": failing,
+			"subscription-2": closing,
+		},
+		subscriptions: map[types.UID]Subscription{
+			"subscription-1": {UID: "subscription-1"},
+			"subscription-2": {UID: "subscription-2"},
+		},
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+
+	err := d.CleanupChannel(channelRef.Name, channelRef.Namespace, "a.b.c.d")
+	require.True(t, errors.Is(err, closeErr))
+
+	// Both consumer groups are closed even though closing the first one failed
+	require.Equal(t, 1, failing.closed)
+	require.Equal(t, 1, closing.closed)
+	require.Empty(t, d.subsConsumerGroups)
+	require.NotContains(t, d.channelSubscriptions, channelRef)
+}
+
+// countingKafkaConsumerFactory records the started consumer groups and the number of their handler options
+type countingKafkaConsumerFactory struct {
+	groupIDs     []string
+	optionCounts []int
+}
+
+func (c *countingKafkaConsumerFactory) StartConsumerGroup(groupID string, topics []string, logger *zap.SugaredLogger, handler consumer.KafkaConsumerHandler, options ...consumer.SaramaConsumerHandlerOption) (sarama.ConsumerGroup, error) {
+	c.groupIDs = append(c.groupIDs, groupID)
+	c.optionCounts = append(c.optionCounts, len(options))
+	return mockConsumerGroup{}, nil
+}
+
+func TestKafkaDispatcher_SharedConsumerGroup(t *testing.T) {
+	subscriber, _ := url.Parse("http://test/subscriber")
+
+	cf := &countingKafkaConsumerFactory{}
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: cf,
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	channelRef := types.NamespacedName{Namespace: "default", Name: "test-channel"}
+	channelConfig := &ChannelConfig{
+		Namespace:           channelRef.Namespace,
+		Name:                channelRef.Name,
+		HostName:            "a.b.c.d",
+		SharedConsumerGroup: true,
+	}
+	for _, uid := range []types.UID{"subscription-1", "subscription-2", "subscription-3"} {
+		channelConfig.Subscriptions = append(channelConfig.Subscriptions, Subscription{
+			UID:          uid,
+			Subscription: fanout.Subscription{Subscriber: subscriber},
+		})
+	}
+
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+
+	// A single consumer group fans out to all the subscriptions
+	require.Equal(t, []string{"kafka.default.test-channel"}, cf.groupIDs)
+	require.Len(t, d.subscriptions, 3)
+	require.Empty(t, d.subsConsumerGroups)
+	require.Contains(t, d.sharedConsumerGroups, channelRef)
+	require.Len(t, d.sharedConsumerGroups[channelRef].handler.handlers, 3)
+
+	// Partitions claimed before a subscription is added are marked ready for it
+	d.sharedConsumerGroups[channelRef].handler.SetReady(0, true)
+	channelConfig.Subscriptions = append(channelConfig.Subscriptions, Subscription{
+		UID:          "subscription-4",
+		Subscription: fanout.Subscription{Subscriber: subscriber},
+	})
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Len(t, cf.groupIDs, 1)
+	require.Equal(t, sets.NewInt32(0), d.channelSubscriptions[channelRef].channelReadySubscriptions["subscription-4"])
+
+	// Switching back to a consumer group per subscription subscribes all the subs again
+	channelConfig.SharedConsumerGroup = false
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Len(t, cf.groupIDs, 5)
+	require.Len(t, d.subsConsumerGroups, 4)
+	require.Empty(t, d.sharedConsumerGroups)
+
+	// Cleaning up the channel closes the shared consumer group
+	channelConfig.SharedConsumerGroup = true
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Empty(t, d.subsConsumerGroups)
+	require.NoError(t, d.CleanupChannel(channelConfig.Name, channelConfig.Namespace, channelConfig.HostName))
+	require.Empty(t, d.subscriptions)
+	require.Empty(t, d.sharedConsumerGroups)
+}
+
+func TestKafkaDispatcher_ManualCommit(t *testing.T) {
+	subscriber, _ := url.Parse("http://test/subscriber")
+
+	cf := &countingKafkaConsumerFactory{}
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: cf,
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	channelConfig := &ChannelConfig{
+		Namespace:    "default",
+		Name:         "test-channel",
+		HostName:     "a.b.c.d",
+		ManualCommit: true,
+		Subscriptions: []Subscription{
+			{UID: "subscription-1", Subscription: fanout.Subscription{Subscriber: subscriber}},
+			{UID: "subscription-2", Subscription: fanout.Subscription{Subscriber: subscriber}},
+		},
+	}
+
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+
+	// The channel commit mode applies to all the subscriptions (on top of the rebalance reporter)
+	require.Equal(t, []int{2, 2}, cf.optionCounts)
+	require.True(t, d.subscriptions["subscription-1"].ManualCommit)
+	require.True(t, d.subscriptions["subscription-2"].ManualCommit)
+
+	// Reconciling the same configuration doesn't restart the consumer groups
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Len(t, cf.groupIDs, 2)
+
+	// Switching back to auto-commit, except for a single subscription, subscribes all the subs again
+	channelConfig.ManualCommit = false
+	channelConfig.Subscriptions[1].ManualCommit = true
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Len(t, cf.groupIDs, 4)
+	require.ElementsMatch(t, []int{1, 2}, cf.optionCounts[2:])
+	require.False(t, d.subscriptions["subscription-1"].ManualCommit)
+	require.True(t, d.subscriptions["subscription-2"].ManualCommit)
+}
+
+// capturingKafkaConsumerFactory keeps the handlers of the started consumer groups
+type capturingKafkaConsumerFactory struct {
+	handlers []consumer.KafkaConsumerHandler
+}
+
+func (c *capturingKafkaConsumerFactory) StartConsumerGroup(groupID string, topics []string, logger *zap.SugaredLogger, handler consumer.KafkaConsumerHandler, options ...consumer.SaramaConsumerHandlerOption) (sarama.ConsumerGroup, error) {
+	c.handlers = append(c.handlers, handler)
+	return mockConsumerGroup{}, nil
+}
+
+func TestKafkaDispatcher_CleanupChannelDrain(t *testing.T) {
+	logger := klogtesting.TestLogger(t)
+
+	// The subscriber blocks until released so that the message stays in-flight
+	received := make(chan struct{})
+	release := make(chan struct{})
+	subscriberServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		close(received)
+		<-release
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer subscriberServer.Close()
+	subscriber, _ := url.Parse(subscriberServer.URL)
+
+	cf := &capturingKafkaConsumerFactory{}
+	d := &KafkaDispatcher{
+		dispatcher:           eventingchannels.NewMessageDispatcher(logger.Desugar()),
+		reporter:             eventingchannels.NewStatsReporter("test-container", "test-pod"),
+		kafkaConsumerFactory: cf,
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		drainTimeout:         time.Minute,
+		logger:               logger,
+	}
+
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		Subscriptions: []Subscription{
+			{
+				UID: "subscription-1",
+				Subscription: fanout.Subscription{
+					Subscriber: subscriber,
+				},
+			},
+		},
+	}
+	require.NoError(t, d.RegisterChannelHost(channelConfig))
+	require.NoError(t, d.ReconcileConsumers(channelConfig))
+	require.Len(t, cf.handlers, 1)
+
+	message := &sarama.ConsumerMessage{
+		Topic: utils.TopicName(utils.KafkaChannelSeparator, channelConfig.Namespace, channelConfig.Name),
+		Value: []byte("{}"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+			{Key: []byte("ce_id"), Value: []byte("test-id")},
+			{Key: []byte("ce_type"), Value: []byte("test-type")},
+			{Key: []byte("ce_source"), Value: []byte("test-source")},
+			{Key: []byte("content-type"), Value: []byte("application/json")},
+		},
+	}
+
+	// Start dispatching the in-flight message
+	var delivered bool
+	handled := make(chan struct{})
+	go func() {
+		delivered, _ = cf.handlers[0].Handle(context.Background(), message)
+		close(handled)
+	}()
+	<-received
+
+	// Cleanup waits for the in-flight message
+	cleanedUp := make(chan error)
+	go func() {
+		cleanedUp <- d.CleanupChannel(channelConfig.Name, channelConfig.Namespace, channelConfig.HostName)
+	}()
+	select {
+	case <-cleanedUp:
+		t.Fatal("CleanupChannel returned before the in-flight message was dispatched")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The other channels can be reconciled while draining
+	locked := make(chan struct{})
+	go func() {
+		d.consumerUpdateLock.Lock()
+		defer d.consumerUpdateLock.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("CleanupChannel held the consumer update lock while draining")
+	}
+
+	close(release)
+	require.NoError(t, <-cleanedUp)
+	<-handled
+	require.True(t, delivered)
+	require.Empty(t, d.subscriptions)
+
+	// New messages are not dispatched once the channel is drained
+	mustMark, err := cf.handlers[0].Handle(context.Background(), message)
+	require.NoError(t, err)
+	require.False(t, mustMark)
+}
+
 func TestSubscribeError(t *testing.T) {
 	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
@@ -505,6 +783,69 @@ func TestKafkaDispatcher_Start(t *testing.T) {
 	}
 }
 
+func TestStartupDelay(t *testing.T) {
+	testCases := map[string]struct {
+		jitter   time.Duration
+		maxDelay time.Duration
+	}{
+		"zero jitter means no delay": {
+			jitter:   0,
+			maxDelay: 0,
+		},
+		"negative jitter means no delay": {
+			jitter:   -time.Second,
+			maxDelay: 0,
+		},
+		"delay within the jitter": {
+			jitter:   100 * time.Millisecond,
+			maxDelay: 100 * time.Millisecond,
+		},
+		"jitter is capped": {
+			jitter:   time.Hour,
+			maxDelay: maxStartupJitter,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				delay := startupDelay(tc.jitter)
+				if delay < 0 || delay > tc.maxDelay || (tc.maxDelay > 0 && delay == tc.maxDelay) {
+					t.Fatalf("startupDelay(%v) = %v, want in [0, %v)", tc.jitter, delay, tc.maxDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestKafkaDispatcher_ReconcileConsumersWaitsForStartup(t *testing.T) {
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zap.NewNop().Sugar(),
+		startupDeadline:      time.Now().Add(200 * time.Millisecond),
+	}
+
+	start := time.Now()
+	if err := d.ReconcileConsumers(&ChannelConfig{Namespace: "default", Name: "test-channel", HostName: "a.b.c.d"}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected ReconcileConsumers to wait for the startup deadline, returned after %v", elapsed)
+	}
+
+	// Once the deadline has passed, reconciling doesn't wait anymore
+	start = time.Now()
+	if err := d.ReconcileConsumers(&ChannelConfig{Namespace: "default", Name: "test-channel", HostName: "a.b.c.d"}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected ReconcileConsumers not to wait after the startup deadline, returned after %v", elapsed)
+	}
+}
+
 func TestNewDispatcher(t *testing.T) {
 	args := &KafkaDispatcherArgs{
 		Config:    &config.EventingKafkaConfig{},
@@ -18,6 +18,7 @@ package dispatcher
 
 import (
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -31,6 +32,10 @@ type KafkaSubscription struct {
 	// readySubscriptionsLock must be used to synchronize access to channelReadySubscriptions
 	readySubscriptionsLock    sync.RWMutex
 	channelReadySubscriptions map[string]sets.Int32
+	// inFlightLock must be used to synchronize starting dispatches with draining
+	inFlightLock sync.Mutex
+	inFlight     sync.WaitGroup
+	draining     bool
 }
 
 func NewKafkaSubscription(logger *zap.SugaredLogger) *KafkaSubscription {
@@ -64,3 +69,40 @@ func (ks *KafkaSubscription) SetReady(subID types.UID, partition int32, ready bo
 		}
 	}
 }
+
+// startDispatch registers an in-flight dispatch, returning false if the KafkaSubscription is draining
+func (ks *KafkaSubscription) startDispatch() bool {
+	ks.inFlightLock.Lock()
+	defer ks.inFlightLock.Unlock()
+	if ks.draining {
+		return false
+	}
+	ks.inFlight.Add(1)
+	return true
+}
+
+// endDispatch unregisters an in-flight dispatch started with startDispatch
+func (ks *KafkaSubscription) endDispatch() {
+	ks.inFlight.Done()
+}
+
+// drain stops new dispatches and waits up to timeout for the in-flight ones to complete,
+// returning false if the timeout expired first
+func (ks *KafkaSubscription) drain(timeout time.Duration) bool {
+	ks.inFlightLock.Lock()
+	ks.draining = true
+	ks.inFlightLock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		ks.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+// sharedConsumerMessageHandler fans out the messages of a single consumer group
+// to all the subscriptions of a channel.
+//
+// A message is marked only when it has been delivered to every subscription, so a
+// failure delivering to one subscription redelivers the message to all of them.
+type sharedConsumerMessageHandler struct {
+	logger        *zap.SugaredLogger
+	consumerGroup string
+
+	// lock must be used to synchronize access to handlers and claimed
+	lock     sync.RWMutex
+	handlers map[types.UID]consumerMessageHandler
+	claimed  sets.Int32
+}
+
+var _ consumer.KafkaConsumerHandler = (*sharedConsumerMessageHandler)(nil)
+
+func newSharedConsumerMessageHandler(logger *zap.SugaredLogger, consumerGroup string) *sharedConsumerMessageHandler {
+	return &sharedConsumerMessageHandler{
+		logger:        logger,
+		consumerGroup: consumerGroup,
+		handlers:      make(map[types.UID]consumerMessageHandler),
+		claimed:       sets.NewInt32(),
+	}
+}
+
+func (c *sharedConsumerMessageHandler) GetConsumerGroup() string {
+	return c.consumerGroup
+}
+
+func (c *sharedConsumerMessageHandler) SetReady(partition int32, ready bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if ready {
+		c.claimed.Insert(partition)
+	} else {
+		c.claimed.Delete(partition)
+	}
+	for _, handler := range c.handlers {
+		handler.SetReady(partition, ready)
+	}
+}
+
+func (c *sharedConsumerMessageHandler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
+	c.lock.RLock()
+	handlers := make([]consumerMessageHandler, 0, len(c.handlers))
+	for _, handler := range c.handlers {
+		handlers = append(handlers, handler)
+	}
+	c.lock.RUnlock()
+
+	mustMark := true
+	var err error
+	for _, handler := range handlers {
+		delivered, handleErr := handler.Handle(ctx, consumerMessage)
+		mustMark = mustMark && delivered
+		multierr.AppendInto(&err, handleErr)
+	}
+	return mustMark, err
+}
+
+// add registers the handler of a subscription, marking it ready for the partitions already claimed
+func (c *sharedConsumerMessageHandler) add(handler consumerMessageHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.handlers[handler.sub.UID] = handler
+	for _, partition := range c.claimed.UnsortedList() {
+		handler.SetReady(partition, true)
+	}
+}
+
+// remove unregisters the handler of a subscription and returns the number of remaining handlers
+func (c *sharedConsumerMessageHandler) remove(uid types.UID) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.handlers, uid)
+	return len(c.handlers)
+}
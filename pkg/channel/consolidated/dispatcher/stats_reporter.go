@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+	commonmetrics "knative.dev/eventing-kafka/pkg/common/metrics"
+)
+
+const (
+	// KafkaChannelDispatchLatencyN is the time it takes to deliver a consumed message to a subscriber.
+	KafkaChannelDispatchLatencyN = "kafkachannel_dispatch_latencies"
+
+	// KafkaChannelRebalancesN is the number of consumer group sessions set up, ie. the rebalances of the consumer groups.
+	KafkaChannelRebalancesN = "kafkachannel_consumer_group_rebalances"
+)
+
+var (
+	dispatchLatencyStat = stats.Float64(
+		KafkaChannelDispatchLatencyN,
+		"Time it takes to deliver a consumed message to a subscriber",
+		stats.UnitMilliseconds)
+
+	rebalancesStat = stats.Int64(
+		KafkaChannelRebalancesN,
+		"Number of consumer group rebalances, including the initial join of the group",
+		stats.UnitDimensionless)
+
+	channelTagKey       = tag.MustNewKey("channel")
+	subscriptionTagKey  = tag.MustNewKey("subscription_uid")
+	consumerGroupTagKey = tag.MustNewKey("consumer_group")
+)
+
+func init() {
+	// Create the view to see our measurement. The view name defaults to the measure name.
+	err := commonmetrics.RegisterViews(
+		&view.View{
+			Description: dispatchLatencyStat.Description(),
+			Measure:     dispatchLatencyStat,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 100000)...), // 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, ... 100000
+			TagKeys:     []tag.Key{channelTagKey, subscriptionTagKey},
+		},
+		&view.View{
+			Description: rebalancesStat.Description(),
+			Measure:     rebalancesStat,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{channelTagKey, consumerGroupTagKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// reportDispatchLatency records the time it took to deliver a message of the channel to the subscription
+func reportDispatchLatency(ctx context.Context, channelRef types.NamespacedName, subUID types.UID, d time.Duration) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(channelTagKey, channelRef.String()),
+		tag.Insert(subscriptionTagKey, string(subUID)))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, dispatchLatencyStat.M(float64(d)/float64(time.Millisecond)))
+}
+
+// rebalanceReporter is a consumer lifecycle listener counting the rebalances of a consumer group of the channel
+type rebalanceReporter struct {
+	channelRef types.NamespacedName
+	groupID    string
+}
+
+var _ consumer.SaramaConsumerLifecycleListener = rebalanceReporter{}
+
+// Setup records a rebalance, since a new session is set up each time the consumer group is rebalanced
+func (r rebalanceReporter) Setup(sess sarama.ConsumerGroupSession) {
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(channelTagKey, r.channelRef.String()),
+		tag.Insert(consumerGroupTagKey, r.groupID))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rebalancesStat.M(1))
+}
+
+// Cleanup does nothing, the session ending is counted when the next one is set up
+func (r rebalanceReporter) Cleanup(sess sarama.ConsumerGroupSession) {}
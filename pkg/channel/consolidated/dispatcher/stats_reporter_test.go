@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	eventingchannels "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/channel/fanout"
+	klogtesting "knative.dev/pkg/logging/testing"
+	_ "knative.dev/pkg/metrics/testing"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+)
+
+func TestDispatchLatencyReported(t *testing.T) {
+	logger := klogtesting.TestLogger(t)
+	delay := 50 * time.Millisecond
+
+	subscriberServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer subscriberServer.Close()
+	subscriber, _ := url.Parse(subscriberServer.URL)
+
+	handler := consumerMessageHandler{
+		logger:            logger,
+		sub:               Subscription{UID: "latency-subscription", Subscription: fanout.Subscription{Subscriber: subscriber}},
+		dispatcher:        eventingchannels.NewMessageDispatcher(logger.Desugar()),
+		kafkaSubscription: NewKafkaSubscription(logger),
+		consumerGroup:     "kafka.default.latency-channel.latency-subscription",
+		reporter:          eventingchannels.NewStatsReporter("test-container", "test-pod"),
+		channelNs:         "default",
+		channelName:       "latency-channel",
+	}
+
+	delivered, err := handler.Handle(context.Background(), &sarama.ConsumerMessage{
+		Topic: "knative-messaging-kafka.default.latency-channel",
+		Value: []byte("{}"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+			{Key: []byte("ce_id"), Value: []byte("test-id")},
+			{Key: []byte("ce_type"), Value: []byte("test-type")},
+			{Key: []byte("ce_source"), Value: []byte("test-source")},
+			{Key: []byte("content-type"), Value: []byte("application/json")},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, delivered)
+
+	rows, err := view.RetrieveData(KafkaChannelDispatchLatencyN)
+	require.NoError(t, err)
+
+	var found *view.DistributionData
+	for _, row := range rows {
+		if hasTag(row.Tags, channelTagKey, "default/latency-channel") && hasTag(row.Tags, subscriptionTagKey, "latency-subscription") {
+			found = row.Data.(*view.DistributionData)
+		}
+	}
+	require.NotNil(t, found, "no dispatch latency recorded for the subscription")
+	require.Equal(t, int64(1), found.Count)
+	require.GreaterOrEqual(t, found.Max, float64(delay.Milliseconds()))
+}
+
+func TestRebalancesReported(t *testing.T) {
+	logger := klogtesting.TestLogger(t)
+	channelRef := types.NamespacedName{Namespace: "default", Name: "rebalance-channel"}
+	groupID := "kafka.default.rebalance-channel"
+
+	handler := &consumerMessageHandler{
+		logger:            logger,
+		sub:               Subscription{UID: "rebalance-subscription"},
+		kafkaSubscription: NewKafkaSubscription(logger),
+		consumerGroup:     groupID,
+	}
+	saramaHandler := consumer.NewConsumerHandler(logger, handler, make(chan error, 1), consumerHandlerOptions(channelRef, groupID, handler.sub)...)
+
+	// Each rebalance ends the current session and sets up a new one
+	session := &rebalanceSession{ctx: context.Background()}
+	require.NoError(t, saramaHandler.Setup(session))
+	require.NoError(t, saramaHandler.Cleanup(session))
+	require.NoError(t, saramaHandler.Setup(session))
+
+	rows, err := view.RetrieveData(KafkaChannelRebalancesN)
+	require.NoError(t, err)
+
+	var found *view.CountData
+	for _, row := range rows {
+		if hasTag(row.Tags, channelTagKey, "default/rebalance-channel") && hasTag(row.Tags, consumerGroupTagKey, groupID) {
+			found = row.Data.(*view.CountData)
+		}
+	}
+	require.NotNil(t, found, "no rebalance recorded for the consumer group")
+	require.Equal(t, int64(2), found.Value)
+}
+
+// rebalanceSession is a sarama.ConsumerGroupSession without any claims
+type rebalanceSession struct {
+	sarama.ConsumerGroupSession
+	ctx context.Context
+}
+
+func (s *rebalanceSession) Claims() map[string][]int32 { return nil }
+func (s *rebalanceSession) Commit()                    {}
+func (s *rebalanceSession) Context() context.Context   { return s.ctx }
+
+func hasTag(tags []tag.Tag, key tag.Key, value string) bool {
+	for _, t := range tags {
+		if t.Key == key && t.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,10 @@ import (
 type Subscription struct {
 	UID types.UID
 	fanout.Subscription
+	// ManualCommit makes the subscription commit its offsets synchronously, once the subscriber acknowledges each message.
+	ManualCommit bool
+	// AtMostOnce makes the subscription commit the offset of each message before delivering it to the subscriber.
+	AtMostOnce bool
 }
 
 func (sub Subscription) String() string {
@@ -91,12 +91,14 @@ func NewController(
 
 	r.dispatcherImage = env.Image
 	r.dispatcherServiceAccount = env.DispatcherServiceAccount
+	r.dispatcherOptionalConfigMap = env.OptionalConfigMap
+	r.dispatcherWaitForKafkaImage = env.WaitForKafkaImage
 
 	impl := kafkaChannelReconciler.NewImpl(ctx, r)
 
 	statusProber := status.NewProber(
 		logger.Named("status-manager"),
-		NewProbeTargetLister(logger, endpointsInformer.Lister()),
+		NewProbeTargetLister(logger, endpointsInformer.Lister(), env.ProbePathTemplate),
 		func(c v1beta1.KafkaChannel, s eventingduckv1.SubscriberSpec) {
 			logger.Debugf("Ready callback triggered for channel: %s/%s subscription: %s", c.Namespace, c.Name, string(s.UID))
 			impl.EnqueueKey(types.NamespacedName{Namespace: c.Namespace, Name: c.Name})
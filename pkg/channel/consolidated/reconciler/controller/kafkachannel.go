@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -118,6 +119,10 @@ type Reconciler struct {
 	systemNamespace          string
 	dispatcherImage          string
 	dispatcherServiceAccount string
+	// dispatcherOptionalConfigMap mounts the settings configmap as optional in the dispatcher deployment
+	dispatcherOptionalConfigMap bool
+	// dispatcherWaitForKafkaImage is the image of the dispatcher init container waiting for kafka (disabled when empty)
+	dispatcherWaitForKafkaImage string
 
 	kafkaConfig        *utils.KafkaConfig
 	kafkaConfigMapHash string
@@ -140,6 +145,9 @@ type Reconciler struct {
 type envConfig struct {
 	Image                    string `envconfig:"DISPATCHER_IMAGE" required:"true"`
 	DispatcherServiceAccount string `envconfig:"SERVICE_ACCOUNT" required:"true"`
+	ProbePathTemplate        string `envconfig:"DISPATCHER_PROBE_PATH_TEMPLATE"`
+	OptionalConfigMap        bool   `envconfig:"DISPATCHER_OPTIONAL_CONFIGMAP" default:"false"`
+	WaitForKafkaImage        string `envconfig:"DISPATCHER_WAIT_FOR_KAFKA_IMAGE"`
 }
 
 // Check that our Reconciler implements kafka's injection Interface
@@ -159,7 +167,11 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 		if r.kafkaConfigError == nil {
 			r.kafkaConfigError = fmt.Errorf("the config map '%s' does not exist", constants.SettingsConfigMapName)
 		}
-		kc.Status.MarkConfigFailed("MissingConfiguration", "%v", r.kafkaConfigError)
+		if errors.Is(r.kafkaConfigError, utils.ErrNoBrokers) || errors.Is(r.kafkaConfigError, utils.ErrEmptyBrokerValue) {
+			kc.Status.MarkConfigFailed("MissingBrokers", "No Kafka brokers configured in the config map '%s': %v", constants.SettingsConfigMapName, r.kafkaConfigError)
+		} else {
+			kc.Status.MarkConfigFailed("MissingConfiguration", "%v", r.kafkaConfigError)
+		}
 		return r.kafkaConfigError
 	}
 
@@ -180,7 +192,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 	// 5. K8s service representing the channel that will use ExternalName to point to the Dispatcher k8s service.
 
 	if err := r.reconcileTopic(ctx, kc, kafkaClusterAdmin); err != nil {
-		kc.Status.MarkTopicFailed("TopicCreateFailed", "error while creating topic: %s", err)
+		kc.Status.MarkTopicFailed(kafkasarama.TopicErrorReason(err, "TopicCreateFailed"), "error while creating topic: %s", err)
 		return err
 	}
 	kc.Status.MarkTopicTrue()
@@ -315,6 +327,11 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope string, disp
 		Replicas:            1,
 		ServiceAccount:      r.dispatcherServiceAccount,
 		ConfigMapHash:       r.kafkaConfigMapHash,
+		ZoneSpread:          r.kafkaConfig.EventingKafka.Channel.Dispatcher.ZoneSpread,
+		Resources:           resources.MakeResourceRequirements(r.kafkaConfig.EventingKafka.Channel.Dispatcher.EKKubernetesConfig),
+		OptionalConfigMap:   r.dispatcherOptionalConfigMap,
+		WaitForKafkaImage:   r.dispatcherWaitForKafkaImage,
+		Brokers:             r.kafkaConfig.Brokers,
 	}
 
 	expected := resources.MakeDispatcher(args)
@@ -368,12 +385,40 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope string, disp
 			needsUpdate = true
 		}
 
+		if !equality.Semantic.DeepEqual(existingCopy.Resources, expectedContainer.Resources) {
+			logging.FromContext(ctx).Infof("Dispatcher deployment resources are not what we expect them to be, updating Deployment Got: %v Expect: %v", existingCopy.Resources, expectedContainer.Resources)
+			existingCopy.Resources = expectedContainer.Resources
+			needsUpdate = true
+		}
+
+		if !initContainersMatch(deploymentCopy.Spec.Template.Spec.InitContainers, expected.Spec.Template.Spec.InitContainers) {
+			logging.FromContext(ctx).Infof("Dispatcher deployment init containers changed. Updating the dispatcher deployment.")
+			deploymentCopy.Spec.Template.Spec.InitContainers = expected.Spec.Template.Spec.InitContainers
+			needsUpdate = true
+		}
+
+		for i := range deploymentCopy.Spec.Template.Spec.Volumes {
+			volume := &deploymentCopy.Spec.Template.Spec.Volumes[i]
+			if volume.Name == constants.SettingsConfigMapName && volume.ConfigMap != nil &&
+				pointer.BoolPtrDerefOr(volume.ConfigMap.Optional, false) != r.dispatcherOptionalConfigMap {
+				logging.FromContext(ctx).Infof("Dispatcher deployment settings configmap optional flag changed. Updating the dispatcher deployment.")
+				volume.ConfigMap.Optional = pointer.BoolPtr(r.dispatcherOptionalConfigMap)
+				needsUpdate = true
+			}
+		}
+
 		if *deploymentCopy.Spec.Replicas == 0 {
 			logging.FromContext(ctx).Infof("Dispatcher deployment has 0 replica. Scaling up deployment to 1 replica")
 			deploymentCopy.Spec.Replicas = pointer.Int32Ptr(1)
 			needsUpdate = true
 		}
 
+		if !equality.Semantic.DeepEqual(deploymentCopy.Spec.Template.Spec.TopologySpreadConstraints, expected.Spec.Template.Spec.TopologySpreadConstraints) {
+			logging.FromContext(ctx).Infof("Dispatcher deployment topology spread constraints changed. Updating the dispatcher deployment.")
+			deploymentCopy.Spec.Template.Spec.TopologySpreadConstraints = expected.Spec.Template.Spec.TopologySpreadConstraints
+			needsUpdate = true
+		}
+
 		if deploymentCopy.Spec.Template.Annotations == nil {
 			logging.FromContext(ctx).Infof("Configmap hash is not set. Updating the dispatcher deployment.")
 			deploymentCopy.Spec.Template.Annotations = map[string]string{
@@ -405,6 +450,21 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope string, disp
 	}
 }
 
+// initContainersMatch compares the name, image, command and env of the init containers, ignoring the fields defaulted by the API server
+func initContainersMatch(existing, expected []corev1.Container) bool {
+	if len(existing) != len(expected) {
+		return false
+	}
+	for i := range expected {
+		if existing[i].Name != expected[i].Name || existing[i].Image != expected[i].Image ||
+			!equality.Semantic.DeepEqual(existing[i].Command, expected[i].Command) ||
+			!equality.Semantic.DeepEqual(existing[i].Env, expected[i].Env) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Reconciler) reconcileServiceAccount(ctx context.Context, dispatcherNamespace string, kc *v1beta1.KafkaChannel) (*corev1.ServiceAccount, error) {
 	sa, err := r.serviceAccountLister.ServiceAccounts(dispatcherNamespace).Get(dispatcherName)
 	if err != nil {
@@ -546,21 +606,28 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaC
 	logger.Infow("Creating topic on Kafka cluster", zap.String("topic", topicName),
 		zap.Int32("partitions", channel.Spec.NumPartitions), zap.Int16("replication", channel.Spec.ReplicationFactor))
 
-	// TODO - The eventing-kafka KafkaChannel spec does not include RetentionMillis so we're
-	//        currently just using the default value specified in the ConfigMap.  If/when the
-	//        RetentionMillis is added, any value from channel.Spec.RetentionMillis should
-	//        take precedence.
-	retentionMillisString := strconv.FormatInt(r.kafkaConfig.EventingKafka.Kafka.Topic.DefaultRetentionMillis, 10)
-
-	err := kafkaClusterAdmin.CreateTopic(topicName, &sarama.TopicDetail{
+	topicDetail := &sarama.TopicDetail{
 		ReplicationFactor: commonconfig.ReplicationFactor(channel, r.kafkaConfig.EventingKafka, logger),
 		NumPartitions:     commonconfig.NumPartitions(channel, r.kafkaConfig.EventingKafka, logger),
-		ConfigEntries: map[string]*string{
-			constants.KafkaTopicConfigRetentionMs: &retentionMillisString,
-		},
-	}, false)
+		ConfigEntries:     map[string]*string{},
+	}
+	// A zero retention defers to the broker's default retention.ms
+	if retentionMillis := commonconfig.RetentionMillis(channel, r.kafkaConfig.EventingKafka, logger); retentionMillis > 0 {
+		retentionMillisString := strconv.FormatInt(retentionMillis, 10)
+		topicDetail.ConfigEntries[constants.KafkaTopicConfigRetentionMs] = &retentionMillisString
+	}
+	if channel.Spec.MinInSyncReplicas > 0 {
+		minInSyncReplicasString := strconv.Itoa(int(channel.Spec.MinInSyncReplicas))
+		topicDetail.ConfigEntries[constants.KafkaTopicConfigMinInSyncReplicas] = &minInSyncReplicasString
+	}
+	for key, value := range channel.Spec.TopicConfig {
+		value := value
+		topicDetail.ConfigEntries[key] = &value
+	}
+
+	err := kafkaClusterAdmin.CreateTopic(topicName, topicDetail, false)
 	if e, ok := err.(*sarama.TopicError); ok && e.Err == sarama.ErrTopicAlreadyExists {
-		return nil
+		return r.reconcilePartitions(ctx, topicName, topicDetail.NumPartitions, kafkaClusterAdmin)
 	} else if err != nil {
 		logger.Errorw("Error creating topic", zap.String("topic", topicName), zap.Error(err))
 	} else {
@@ -569,6 +636,40 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaC
 	return err
 }
 
+// reconcilePartitions increases the partitions of an existing topic to the desired number.
+// Partitions can't be decreased, so a topic with more partitions is left untouched.
+func (r *Reconciler) reconcilePartitions(ctx context.Context, topicName string, partitions int32, kafkaClusterAdmin sarama.ClusterAdmin) error {
+	logger := logging.FromContext(ctx).With(zap.String("topic", topicName), zap.Int32("partitions", partitions))
+
+	metadata, err := kafkaClusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		logger.Errorw("Error describing topic", zap.Error(err))
+		return err
+	}
+	if len(metadata) != 1 {
+		return nil
+	}
+	if metadata[0].Err != sarama.ErrNoError {
+		logger.Errorw("Error describing topic", zap.Error(metadata[0].Err))
+		return metadata[0].Err
+	}
+
+	livePartitions := int32(len(metadata[0].Partitions))
+	if livePartitions >= partitions {
+		return nil
+	}
+
+	logger.Infow("Increasing topic partitions", zap.Int32("livePartitions", livePartitions))
+	err = kafkaClusterAdmin.CreatePartitions(topicName, partitions, nil, false)
+	if e, ok := err.(*sarama.TopicPartitionError); ok && e.Err == sarama.ErrInvalidPartitions {
+		// The partitions were increased concurrently
+		return nil
+	} else if err != nil {
+		logger.Errorw("Error increasing topic partitions", zap.Error(err))
+	}
+	return err
+}
+
 func (r *Reconciler) deleteTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
@@ -603,6 +704,7 @@ func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.Co
 	kafkaConfig, err := utils.GetKafkaConfig(ctx, controllerAgentName, configMap.Data, kafkasarama.LoadAuthConfig)
 	if err != nil {
 		logger.Errorw("Error reading Kafka configuration", zap.Error(err))
+		r.kafkaConfigError = err
 		return
 	}
 
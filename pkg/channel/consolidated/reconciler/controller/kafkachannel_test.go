@@ -37,6 +37,7 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/network"
 	. "knative.dev/pkg/reconciler/testing"
 
@@ -433,6 +434,66 @@ func TestTopicExists(t *testing.T) {
 	}, zap.L()))
 }
 
+func TestTopicCreateFailed(t *testing.T) {
+	kcKey := testNS + "/" + kcName
+	topicErr := &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor}
+	row := TableRow{
+		Name: "Topic creation fails with an invalid replication factor",
+		Key:  kcKey,
+		Objects: []runtime.Object{
+			reconcilertesting.NewKafkaChannel(kcName, testNS,
+				reconcilertesting.WithKafkaFinalizer(finalizerName)),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: reconcilertesting.NewKafkaChannel(kcName, testNS,
+				reconcilertesting.WithInitKafkaChannelConditions,
+				reconcilertesting.WithKafkaFinalizer(finalizerName),
+				reconcilertesting.WithKafkaChannelConfigReady(),
+				reconcilertesting.WithKafkaChannelTopicFailed("InvalidReplicationFactor", "error while creating topic: "+topicErr.Error()),
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", topicErr.Error()),
+		},
+	}
+
+	row.Test(t, reconcilertesting.MakeFactory(func(ctx context.Context, listers *reconcilertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+
+		r := &Reconciler{
+			systemNamespace:          testNS,
+			dispatcherImage:          testDispatcherImage,
+			dispatcherServiceAccount: testDispatcherserviceAccount,
+			kafkaConfigMapHash:       testConfigMapHash,
+			kafkaConfig: &KafkaConfig{
+				Brokers:       []string{brokerName},
+				EventingKafka: &config.EventingKafkaConfig{},
+			},
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			// TODO fix
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			endpointsLister:      listers.GetEndpointsLister(),
+			kafkaClusterAdmin: &mockClusterAdmin{
+				mockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+					return topicErr
+				},
+			},
+			kafkaClientSet:    fakekafkaclient.Get(ctx),
+			KubeClientSet:     kubeclient.Get(ctx),
+			EventingClientSet: eventingClient.Get(ctx),
+			statusManager: &fakeStatusManager{
+				FakeIsReady: func(ctx context.Context, channel v1beta1.KafkaChannel,
+					spec eventingduckv1.SubscriberSpec) (bool, error) {
+					return true, nil
+				},
+			},
+		}
+		return kafkachannel.NewReconciler(ctx, logging.FromContext(ctx), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, zap.L()))
+}
+
 func TestDeploymentUpdatedOnImageChange(t *testing.T) {
 	kcKey := testNS + "/" + kcName
 	row := TableRow{
@@ -742,9 +803,52 @@ func TestDeploymentUpdatedOnConfigMapHashChange(t *testing.T) {
 	}, zap.L()))
 }
 
+func TestReconcileTopicPartitions(t *testing.T) {
+	testCases := map[string]struct {
+		livePartitions  int
+		wantPartitions  int32
+		wantCreateCount int32
+	}{
+		"fewer partitions than desired": {livePartitions: 2, wantPartitions: 4, wantCreateCount: 4},
+		"desired partitions":            {livePartitions: 4, wantPartitions: 4},
+		"more partitions than desired":  {livePartitions: 6, wantPartitions: 4},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+			var createCount int32
+			kafkaClusterAdmin := &mockClusterAdmin{
+				mockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+					return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+				},
+				mockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					return []*sarama.TopicMetadata{{Name: topics[0], Partitions: make([]*sarama.PartitionMetadata, tc.livePartitions)}}, nil
+				},
+				mockCreatePartitionsFunc: func(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+					createCount = count
+					return nil
+				},
+			}
+			r := &Reconciler{kafkaConfig: &KafkaConfig{EventingKafka: &config.EventingKafkaConfig{}}}
+			channel := reconcilertesting.NewKafkaChannel(kcName, testNS)
+			channel.Spec.NumPartitions = tc.wantPartitions
+
+			if err := r.reconcileTopic(ctx, channel, kafkaClusterAdmin); err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if createCount != tc.wantCreateCount {
+				t.Errorf("got CreatePartitions count %d, want %d", createCount, tc.wantCreateCount)
+			}
+		})
+	}
+}
+
 type mockClusterAdmin struct {
-	mockCreateTopicFunc func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
-	mockDeleteTopicFunc func(topic string) error
+	mockCreateTopicFunc      func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
+	mockDeleteTopicFunc      func(topic string) error
+	mockDescribeTopicsFunc   func(topics []string) ([]*sarama.TopicMetadata, error)
+	mockCreatePartitionsFunc func(topic string, count int32, assignment [][]int32, validateOnly bool) error
 }
 
 func (ca *mockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -790,6 +894,9 @@ func (ca *mockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (ca *mockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
+	if ca.mockDescribeTopicsFunc != nil {
+		return ca.mockDescribeTopicsFunc(topics)
+	}
 	return nil, nil
 }
 
@@ -798,6 +905,9 @@ func (ca *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error)
 }
 
 func (ca *mockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	if ca.mockCreatePartitionsFunc != nil {
+		return ca.mockCreatePartitionsFunc(topic, count, assignment, validateOnly)
+	}
 	return nil
 }
 
@@ -32,11 +32,19 @@ import (
 )
 
 type DispatcherPodsLister struct {
-	logger         *zap.SugaredLogger
-	endpointLister v1.EndpointsLister
+	logger            *zap.SugaredLogger
+	endpointLister    v1.EndpointsLister
+	probePathTemplate string
 }
 
 func (t *DispatcherPodsLister) ListProbeTargets(ctx context.Context, kc v1beta1.KafkaChannel) (*status.ProbeTarget, error) {
+	return ProbeTargetFromEndpoints(t.endpointLister, kc, t.probePathTemplate)
+}
+
+// ProbeTargetFromEndpoints builds the ProbeTarget for the given channel from the ready addresses of the
+// dispatcher endpoints, in the system namespace or the channel's namespace for namespace-scoped channels.
+// The probed path of each channel is built from probePathTemplate (see status.ProbeTarget).
+func ProbeTargetFromEndpoints(endpointLister v1.EndpointsLister, kc v1beta1.KafkaChannel, probePathTemplate string) (*status.ProbeTarget, error) {
 	scope, ok := kc.Annotations[eventing.ScopeAnnotationKey]
 	if !ok {
 		scope = scopeCluster
@@ -49,7 +57,7 @@ func (t *DispatcherPodsLister) ListProbeTargets(ctx context.Context, kc v1beta1.
 
 	// Get the Dispatcher Service Endpoints and propagate the status to the Channel
 	// endpoints has the same name as the service, so not a bug.
-	eps, err := t.endpointLister.Endpoints(dispatcherNamespace).Get(dispatcherName)
+	eps, err := endpointLister.Endpoints(dispatcherNamespace).Get(dispatcherName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get internal service: %w", err)
 	}
@@ -65,19 +73,26 @@ func (t *DispatcherPodsLister) ListProbeTargets(ctx context.Context, kc v1beta1.
 		return nil, fmt.Errorf("no gateway pods available")
 	}
 
-	u, _ := url.Parse(fmt.Sprintf("http://%s.%s/%s/%s", dispatcherName, dispatcherNamespace, kc.Namespace, kc.Name))
+	u, _ := url.Parse(fmt.Sprintf("http://%s.%s", dispatcherName, dispatcherNamespace))
 
 	return &status.ProbeTarget{
-		PodIPs:  sets.NewString(readyIPs...),
-		PodPort: "8081",
-		URL:     u,
+		PodIPs:       sets.NewString(readyIPs...),
+		PodPort:      "8081",
+		URL:          u,
+		PathTemplate: probePathTemplate,
 	}, nil
 }
 
-func NewProbeTargetLister(logger *zap.SugaredLogger, lister v1.EndpointsLister) status.ProbeTargetLister {
+// NewProbeTargetLister creates a ProbeTargetLister for the dispatcher pods, probing the path built
+// from probePathTemplate (see status.ProbeTarget), or status.DefaultProbePathTemplate when empty
+func NewProbeTargetLister(logger *zap.SugaredLogger, lister v1.EndpointsLister, probePathTemplate string) status.ProbeTargetLister {
+	if probePathTemplate == "" {
+		probePathTemplate = status.DefaultProbePathTemplate
+	}
 	tl := DispatcherPodsLister{
-		logger:         logger,
-		endpointLister: lister,
+		logger:            logger,
+		endpointLister:    lister,
+		probePathTemplate: probePathTemplate,
 	}
 	return &tl
 }
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/eventing/pkg/apis/eventing"
+
+	reconcilertesting "knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/testing"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/status"
+)
+
+func TestProbeTargetFromEndpoints(t *testing.T) {
+	kc := reconcilertesting.NewKafkaChannel(kcName, testNS)
+	kc.Annotations = map[string]string{eventing.ScopeAnnotationKey: scopeNamespace}
+
+	multiAddressEndpoints := makeEmptyEndpoints()
+	multiAddressEndpoints.Subsets = []corev1.EndpointSubset{
+		{Addresses: []corev1.EndpointAddress{{IP: "1.1.1.1"}, {IP: "2.2.2.2"}}},
+		{Addresses: []corev1.EndpointAddress{{IP: "3.3.3.3"}}},
+	}
+
+	tests := []struct {
+		name         string
+		endpoints    *corev1.Endpoints
+		pathTemplate string
+		wantIPs      sets.String
+		wantPath     string
+		wantErr      bool
+	}{
+		{
+			name:         "multiple addresses",
+			endpoints:    multiAddressEndpoints,
+			pathTemplate: status.DefaultProbePathTemplate,
+			wantIPs:      sets.NewString("1.1.1.1", "2.2.2.2", "3.3.3.3"),
+			wantPath:     "/" + testNS + "/" + kcName,
+		},
+		{
+			name:         "custom path template",
+			endpoints:    makeReadyEndpoints(),
+			pathTemplate: "/healthz/{namespace}/{name}",
+			wantIPs:      sets.NewString("1.1.1.1"),
+			wantPath:     "/healthz/" + testNS + "/" + kcName,
+		},
+		{
+			name:      "no ready addresses",
+			endpoints: makeEmptyEndpoints(),
+			wantErr:   true,
+		},
+		{
+			name:    "missing endpoints",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var objs []runtime.Object
+			if tc.endpoints != nil {
+				objs = append(objs, tc.endpoints)
+			}
+			listers := reconcilertesting.NewListers(objs)
+
+			target, err := ProbeTargetFromEndpoints(listers.GetEndpointsLister(), *kc, tc.pathTemplate)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, target)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantIPs, target.PodIPs)
+			assert.Equal(t, "8081", target.PodPort)
+			assert.Equal(t, "http://"+dispatcherName+"."+testNS, target.URL.String())
+			assert.Equal(t, tc.wantPath, status.ProbePath(target.PathTemplate, *kc))
+		})
+	}
+}
@@ -17,16 +17,30 @@ limitations under the License.
 package resources
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/pkg/system"
 )
 
 const (
-	DispatcherContainerName = "dispatcher"
+	DispatcherContainerName   = "dispatcher"
+	WaitForKafkaContainerName = "wait-for-kafka"
+
+	// waitForKafkaScript polls the brokers listed in KAFKA_BROKERS until any of them accepts connections
+	waitForKafkaScript = `while true; do
+  for broker in $(echo "$KAFKA_BROKERS" | tr ',' ' '); do
+    nc -z -w 2 "${broker%:*}" "${broker##*:}" && exit 0
+  done
+  echo "waiting for kafka brokers $KAFKA_BROKERS"
+  sleep 2
+done`
 )
 
 var (
@@ -44,6 +58,16 @@ type DispatcherArgs struct {
 	Replicas            int32
 	ServiceAccount      string
 	ConfigMapHash       string
+	// ZoneSpread spreads the dispatcher replicas across the zones (topology.kubernetes.io/zone) when possible
+	ZoneSpread bool
+	// Resources are the requests and limits of the dispatcher container (see MakeResourceRequirements)
+	Resources corev1.ResourceRequirements
+	// OptionalConfigMap mounts the settings configmap as optional, the dispatcher then waits for it rather than crash looping
+	OptionalConfigMap bool
+	// WaitForKafkaImage, when not empty, adds an init container (running this image, which must provide sh and nc)
+	// waiting for any of the Brokers to accept connections before the dispatcher starts
+	WaitForKafkaImage string
+	Brokers           []string
 }
 
 // MakeDispatcher generates the dispatcher deployment for the KafKa channel
@@ -72,12 +96,15 @@ func MakeDispatcher(args DispatcherArgs) *v1.Deployment {
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: args.ServiceAccount,
+					ServiceAccountName:        args.ServiceAccount,
+					TopologySpreadConstraints: makeTopologySpreadConstraints(args),
+					InitContainers:            makeInitContainers(args),
 					Containers: []corev1.Container{
 						{
-							Name:  DispatcherContainerName,
-							Image: args.Image,
-							Env:   makeEnv(args),
+							Name:      DispatcherContainerName,
+							Image:     args.Image,
+							Env:       makeEnv(args),
+							Resources: args.Resources,
 							Ports: []corev1.ContainerPort{{
 								Name:          "metrics",
 								ContainerPort: 9090,
@@ -98,6 +125,7 @@ func MakeDispatcher(args DispatcherArgs) *v1.Deployment {
 									LocalObjectReference: corev1.LocalObjectReference{
 										Name: constants.SettingsConfigMapName,
 									},
+									Optional: optionalConfigMap(args),
 								},
 							},
 						},
@@ -108,6 +136,71 @@ func MakeDispatcher(args DispatcherArgs) *v1.Deployment {
 	}
 }
 
+// MakeResourceRequirements returns the container requests and limits configured in the given kubernetes config.
+// A zero Quantity means no request / limit for that resource, in which case the entry is left out.
+func MakeResourceRequirements(kubernetesConfig config.EKKubernetesConfig) corev1.ResourceRequirements {
+	var requirements corev1.ResourceRequirements
+	if !kubernetesConfig.CpuRequest.IsZero() || !kubernetesConfig.MemoryRequest.IsZero() {
+		requirements.Requests = corev1.ResourceList{}
+		if !kubernetesConfig.CpuRequest.IsZero() {
+			requirements.Requests[corev1.ResourceCPU] = kubernetesConfig.CpuRequest
+		}
+		if !kubernetesConfig.MemoryRequest.IsZero() {
+			requirements.Requests[corev1.ResourceMemory] = kubernetesConfig.MemoryRequest
+		}
+	}
+	if !kubernetesConfig.CpuLimit.IsZero() || !kubernetesConfig.MemoryLimit.IsZero() {
+		requirements.Limits = corev1.ResourceList{}
+		if !kubernetesConfig.CpuLimit.IsZero() {
+			requirements.Limits[corev1.ResourceCPU] = kubernetesConfig.CpuLimit
+		}
+		if !kubernetesConfig.MemoryLimit.IsZero() {
+			requirements.Limits[corev1.ResourceMemory] = kubernetesConfig.MemoryLimit
+		}
+	}
+	return requirements
+}
+
+// optionalConfigMap returns whether the settings configmap volume is optional, leaving it unset (ie. required) by default
+func optionalConfigMap(args DispatcherArgs) *bool {
+	if !args.OptionalConfigMap {
+		return nil
+	}
+	return pointer.BoolPtr(true)
+}
+
+// makeInitContainers returns the init container waiting for kafka, if enabled
+func makeInitContainers(args DispatcherArgs) []corev1.Container {
+	if args.WaitForKafkaImage == "" {
+		return nil
+	}
+	return []corev1.Container{{
+		Name:    WaitForKafkaContainerName,
+		Image:   args.WaitForKafkaImage,
+		Command: []string{"sh", "-c", waitForKafkaScript},
+		Env: []corev1.EnvVar{{
+			Name:  "KAFKA_BROKERS",
+			Value: strings.Join(args.Brokers, ","),
+		}},
+	}}
+}
+
+// makeTopologySpreadConstraints prefers spreading the dispatcher replicas evenly across the zones,
+// still scheduling them when the zones can't be balanced (eg. a single zone cluster)
+func makeTopologySpreadConstraints(args DispatcherArgs) []corev1.TopologySpreadConstraint {
+	if !args.ZoneSpread {
+		return nil
+	}
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelTopologyZone,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: dispatcherLabels,
+		},
+	}}
+}
+
 func makeEnv(args DispatcherArgs) []corev1.EnvVar {
 	vars := []corev1.EnvVar{{
 		Name:  system.NamespaceEnvKey,
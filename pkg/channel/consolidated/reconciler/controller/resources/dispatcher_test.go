@@ -23,7 +23,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/pkg/system"
 )
@@ -223,3 +225,134 @@ func TestNewNamespaceDispatcher(t *testing.T) {
 		t.Errorf("unexpected condition (-want, +got) = %v", diff)
 	}
 }
+
+func TestNewDispatcherZoneSpread(t *testing.T) {
+	os.Setenv(system.NamespaceEnvKey, "knative-testing")
+
+	args := DispatcherArgs{
+		DispatcherScope:     "cluster",
+		DispatcherNamespace: testNS,
+		Image:               imageName,
+		Replicas:            3,
+		ServiceAccount:      serviceAccount,
+		ConfigMapHash:       testConfigMapHash,
+	}
+
+	// Disabled by default
+	got := MakeDispatcher(args)
+	if len(got.Spec.Template.Spec.TopologySpreadConstraints) != 0 {
+		t.Errorf("unexpected topology spread constraints %v", got.Spec.Template.Spec.TopologySpreadConstraints)
+	}
+
+	args.ZoneSpread = true
+	want := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: dispatcherLabels,
+		},
+	}}
+	got = MakeDispatcher(args)
+	if diff := cmp.Diff(want, got.Spec.Template.Spec.TopologySpreadConstraints); diff != "" {
+		t.Errorf("unexpected topology spread constraints (-want, +got) = %v", diff)
+	}
+}
+
+func TestNewDispatcherResources(t *testing.T) {
+	os.Setenv(system.NamespaceEnvKey, "knative-testing")
+
+	want := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("50Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("200Mi"),
+		},
+	}
+	args := DispatcherArgs{
+		DispatcherScope:     "cluster",
+		DispatcherNamespace: testNS,
+		Image:               imageName,
+		Replicas:            1,
+		ServiceAccount:      serviceAccount,
+		ConfigMapHash:       testConfigMapHash,
+		Resources: MakeResourceRequirements(config.EKKubernetesConfig{
+			CpuRequest:    resource.MustParse("100m"),
+			MemoryRequest: resource.MustParse("50Mi"),
+			MemoryLimit:   resource.MustParse("200Mi"),
+		}),
+	}
+
+	got := MakeDispatcher(args)
+	if diff := cmp.Diff(want, got.Spec.Template.Spec.Containers[0].Resources); diff != "" {
+		t.Errorf("unexpected resources (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakeResourceRequirementsUnset(t *testing.T) {
+	if diff := cmp.Diff(corev1.ResourceRequirements{}, MakeResourceRequirements(config.EKKubernetesConfig{})); diff != "" {
+		t.Errorf("unexpected resources (-want, +got) = %v", diff)
+	}
+}
+
+func TestNewDispatcherOptionalConfigMap(t *testing.T) {
+	os.Setenv(system.NamespaceEnvKey, "knative-testing")
+
+	args := DispatcherArgs{
+		DispatcherScope:     "cluster",
+		DispatcherNamespace: testNS,
+		Image:               imageName,
+		Replicas:            1,
+		ServiceAccount:      serviceAccount,
+		ConfigMapHash:       testConfigMapHash,
+	}
+
+	// Required by default
+	got := MakeDispatcher(args)
+	if optional := got.Spec.Template.Spec.Volumes[0].ConfigMap.Optional; optional != nil {
+		t.Errorf("unexpected optional flag %v", *optional)
+	}
+
+	args.OptionalConfigMap = true
+	got = MakeDispatcher(args)
+	volume := got.Spec.Template.Spec.Volumes[0]
+	if volume.Name != "config-kafka" || volume.ConfigMap.Optional == nil || !*volume.ConfigMap.Optional {
+		t.Errorf("expected the config-kafka volume to be optional, got %v", volume)
+	}
+}
+
+func TestNewDispatcherWaitForKafka(t *testing.T) {
+	os.Setenv(system.NamespaceEnvKey, "knative-testing")
+
+	args := DispatcherArgs{
+		DispatcherScope:     "cluster",
+		DispatcherNamespace: testNS,
+		Image:               imageName,
+		Replicas:            1,
+		ServiceAccount:      serviceAccount,
+		ConfigMapHash:       testConfigMapHash,
+		Brokers:             []string{"kafkabroker1.kafka:9092", "kafkabroker2.kafka:9092"},
+	}
+
+	// Disabled by default
+	got := MakeDispatcher(args)
+	if len(got.Spec.Template.Spec.InitContainers) != 0 {
+		t.Errorf("unexpected init containers %v", got.Spec.Template.Spec.InitContainers)
+	}
+
+	args.WaitForKafkaImage = "busybox"
+	got = MakeDispatcher(args)
+	if len(got.Spec.Template.Spec.InitContainers) != 1 {
+		t.Fatalf("expected one init container, got %v", got.Spec.Template.Spec.InitContainers)
+	}
+	initContainer := got.Spec.Template.Spec.InitContainers[0]
+	if initContainer.Name != "wait-for-kafka" || initContainer.Image != "busybox" {
+		t.Errorf("unexpected init container %v", initContainer)
+	}
+	wantEnv := []corev1.EnvVar{{Name: "KAFKA_BROKERS", Value: "kafkabroker1.kafka:9092,kafkabroker2.kafka:9092"}}
+	if diff := cmp.Diff(wantEnv, initContainer.Env); diff != "" {
+		t.Errorf("unexpected init container env (-want, +got) = %v", diff)
+	}
+}
@@ -19,8 +19,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/apis/eventing"
@@ -48,7 +50,12 @@ import (
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 )
 
-const dispatcherClientId = "kafka-ch-dispatcher"
+const (
+	dispatcherClientId = "kafka-ch-dispatcher"
+
+	// settingsPollInterval is how often the settings are reloaded while the configmap mount is empty
+	settingsPollInterval = 5 * time.Second
+)
 
 func init() {
 	// Add run types to the default Kubernetes Scheme so Events can be
@@ -84,7 +91,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		logger.Fatal("Failed To Get ConfigmapLoader From Context - Terminating!", zap.Error(err))
 	}
 
-	configMap, err := configmapLoader(constants.SettingsConfigMapMountPath)
+	// Wait for the settings while the mount is empty, as it is while an optional configmap volume hasn't been created yet
+	configMap, err := configmaploader.WaitFor(ctx, configmapLoader, constants.SettingsConfigMapMountPath, settingsPollInterval)
 	if err != nil {
 		logger.Fatalw("error loading configuration", zap.Error(err))
 	}
@@ -94,6 +102,11 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		logger.Fatalw("Error loading kafka config", zap.Error(err))
 	}
 
+	// Bound the per-broker concurrency and set the rebalance strategy as configured for the dispatcher
+	if err := kafkasarama.ApplyDispatcherSettings(ctx, kafkaConfig.EventingKafka); err != nil {
+		logger.Fatalw("Error applying dispatcher sarama settings", zap.Error(err))
+	}
+
 	// Configure connection arguments - to be done exactly once per process
 	kncloudevents.ConfigureConnectionArgs(&kncloudevents.ConnectionArgs{
 		MaxIdleConns:        kafkaConfig.EventingKafka.CloudEvents.MaxIdleConns,
@@ -105,6 +118,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Brokers:   kafkaConfig.Brokers,
 		Config:    kafkaConfig.EventingKafka,
 		TopicFunc: utils.TopicName,
+
+		DrainTimeout:  time.Duration(kafkaConfig.EventingKafka.Channel.Dispatcher.DrainTimeoutMillis) * time.Millisecond,
+		StartupJitter: time.Duration(kafkaConfig.EventingKafka.Channel.Dispatcher.StartupJitterMillis) * time.Millisecond,
 	}
 	kafkaDispatcher, err := dispatcher.NewDispatcher(ctx, args)
 	if err != nil {
@@ -201,6 +217,10 @@ func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatc
 		Namespace: c.Namespace,
 		Name:      c.Name,
 		HostName:  c.Status.Address.URL.Host,
+
+		SharedConsumerGroup: c.Annotations[utils.SharedConsumerGroupAnnotation] == "true",
+		ManualCommit:        c.Annotations[utils.ManualCommitAnnotation] == "true",
+		AtMostOnce:          c.Spec.DeliveryGuarantee == v1beta1.DeliveryGuaranteeAtMostOnce,
 	}
 	if c.Spec.SubscribableSpec.Subscribers != nil {
 		newSubs := make([]dispatcher.Subscription, 0, len(c.Spec.SubscribableSpec.Subscribers))
@@ -217,3 +237,12 @@ func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatc
 
 	return &channelConfig
 }
+
+// topicsFromKafkaChannels returns the distinct Kafka topics backing the given kafka channels.
+func (r *Reconciler) topicsFromKafkaChannels(kcs []*v1beta1.KafkaChannel) sets.String {
+	topics := sets.NewString()
+	for _, kc := range kcs {
+		topics.Insert(utils.TopicName(utils.KafkaChannelSeparator, kc.Namespace, kc.Name))
+	}
+	return topics
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/dispatcher"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+)
+
+func TestTopicsFromKafkaChannels(t *testing.T) {
+	kc := func(namespace, name string) *v1beta1.KafkaChannel {
+		return &v1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	testCases := map[string]struct {
+		channels []*v1beta1.KafkaChannel
+		want     []string
+	}{
+		"no channels": {
+			want: []string{},
+		},
+		"distinct channels": {
+			channels: []*v1beta1.KafkaChannel{kc("ns1", "a"), kc("ns2", "a"), kc("ns1", "b")},
+			want:     []string{"knative-messaging-kafka.ns1.a", "knative-messaging-kafka.ns1.b", "knative-messaging-kafka.ns2.a"},
+		},
+		"overlapping channels": {
+			channels: []*v1beta1.KafkaChannel{kc("ns1", "a"), kc("ns1", "a"), kc("ns2", "b"), kc("ns2", "b")},
+			want:     []string{"knative-messaging-kafka.ns1.a", "knative-messaging-kafka.ns2.b"},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			r := &Reconciler{}
+			got := r.topicsFromKafkaChannels(tc.channels).List()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected topics (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestNewConfigFromKafkaChannel(t *testing.T) {
+	kc := func(deliveryGuarantee v1beta1.DeliveryGuarantee, annotations map[string]string) *v1beta1.KafkaChannel {
+		c := &v1beta1.KafkaChannel{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc", Annotations: annotations},
+			Spec:       v1beta1.KafkaChannelSpec{DeliveryGuarantee: deliveryGuarantee},
+		}
+		c.Status.Address = &duckv1.Addressable{URL: apis.HTTP("kc-kn-channel.ns.svc.cluster.local")}
+		return c
+	}
+
+	testCases := map[string]struct {
+		channel *v1beta1.KafkaChannel
+		want    *dispatcher.ChannelConfig
+	}{
+		"default delivery guarantee": {
+			channel: kc("", nil),
+			want:    &dispatcher.ChannelConfig{Namespace: "ns", Name: "kc", HostName: "kc-kn-channel.ns.svc.cluster.local"},
+		},
+		"at least once": {
+			channel: kc(v1beta1.DeliveryGuaranteeAtLeastOnce, nil),
+			want:    &dispatcher.ChannelConfig{Namespace: "ns", Name: "kc", HostName: "kc-kn-channel.ns.svc.cluster.local"},
+		},
+		"at most once": {
+			channel: kc(v1beta1.DeliveryGuaranteeAtMostOnce, nil),
+			want:    &dispatcher.ChannelConfig{Namespace: "ns", Name: "kc", HostName: "kc-kn-channel.ns.svc.cluster.local", AtMostOnce: true},
+		},
+		"manual commit": {
+			channel: kc(v1beta1.DeliveryGuaranteeAtLeastOnce, map[string]string{utils.ManualCommitAnnotation: "true"}),
+			want:    &dispatcher.ChannelConfig{Namespace: "ns", Name: "kc", HostName: "kc-kn-channel.ns.svc.cluster.local", ManualCommit: true},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			r := &Reconciler{}
+			got := r.newConfigFromKafkaChannel(tc.channel)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected channel config (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
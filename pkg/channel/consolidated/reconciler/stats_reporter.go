@@ -25,6 +25,8 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"knative.dev/pkg/metrics"
+
+	commonmetrics "knative.dev/eventing-kafka/pkg/common/metrics"
 )
 
 type Measurement int
@@ -90,10 +92,10 @@ func init() {
 			ReadyLatencyStat: readyLatencyStat,
 		}
 
-		// Create views to see our measurements. This can return an error if
-		// a previously-registered view has the same name with a different value.
+		// Create views to see our measurements. Views that are already registered
+		// (e.g. by another component in the same process) are skipped.
 		// View name defaults to the measure name if unspecified.
-		err = view.Register(
+		err = commonmetrics.RegisterViews(
 			&view.View{
 				Description: readyLatencyStat.Description(),
 				Measure:     readyLatencyStat,
@@ -68,6 +68,12 @@ func WithKafkaChannelTopicReady() KafkaChannelOption {
 	}
 }
 
+func WithKafkaChannelTopicFailed(reason, message string) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Status.MarkTopicFailed(reason, message)
+	}
+}
+
 func WithKafkaChannelConfigReady() KafkaChannelOption {
 	return func(nc *v1beta1.KafkaChannel) {
 		nc.Status.MarkConfigTrue()
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +50,9 @@ const (
 	// initialDelay defines the delay before enqueuing a probing request the first time.
 	// It gives times for the change to propagate and prevents unnecessary retries.
 	initialDelay = 200 * time.Millisecond
+
+	// DefaultProbePathTemplate is the path of the dispatcher readiness endpoint for a channel
+	DefaultProbePathTemplate = "/{namespace}/{name}"
 )
 
 var dialContext = (&net.Dialer{Timeout: probeTimeout}).DialContext
@@ -98,6 +102,15 @@ type ProbeTarget struct {
 	PodIPs  sets.String
 	PodPort string
 	URL     *url.URL
+
+	// PathTemplate, when not empty, replaces the path of the URL. The "{namespace}" and "{name}"
+	// placeholders are replaced by the namespace and name of the probed channel.
+	PathTemplate string
+}
+
+// ProbePath expands the probe path template for the given channel
+func ProbePath(pathTemplate string, ch messagingv1beta1.KafkaChannel) string {
+	return strings.NewReplacer("{namespace}", ch.Namespace, "{name}", ch.Name).Replace(pathTemplate)
 }
 
 // ProbeTargetLister lists all the targets that requires probing.
@@ -214,12 +227,18 @@ func (m *Prober) probeTarget(ctx context.Context, ch messagingv1beta1.KafkaChann
 		cancel:       cancel,
 	}
 
+	probeURL := target.URL
+	if target.PathTemplate != "" {
+		probeURL = deepCopy(target.URL)
+		probeURL.Path = ProbePath(target.PathTemplate, ch)
+	}
+
 	// Group the probe targets by IP
 	workItems := make(map[string][]*workItem)
 	for ip := range target.PodIPs {
 		workItems[ip] = append(workItems[ip], &workItem{
 			targetStates: subscriptionState,
-			url:          target.URL,
+			url:          probeURL,
 			podIP:        ip,
 			podPort:      target.PodPort,
 			logger:       logger,
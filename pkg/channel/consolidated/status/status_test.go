@@ -95,6 +95,51 @@ func TestProbeSinglePod(t *testing.T) {
 	assertEventuallyReady(t, prober, ch, sub, ready, &succeed, &probeRequests)
 }
 
+func TestProbeConfiguredPath(t *testing.T) {
+	ch := getChannel(1)
+	sub := getSubscription()
+	var subscriptions = map[string][]int{
+		string(sub.UID): {0},
+	}
+
+	successHandler := http.HandlerFunc(readyJSONHandler(t, subscriptions))
+
+	var succeed atomic.Bool
+
+	// Probes on any other path than the configured one never succeed
+	const expectedPath = "/readyz/default/chan4prober"
+	probeRequests := make(chan *http.Request)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		probeRequests <- r
+		if !succeed.Load() || r.URL.Path != expectedPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		successHandler.ServeHTTP(w, r)
+	}
+
+	ts := getDispatcherServer(handler)
+	defer ts.Close()
+
+	target := getTargetLister(t, ts.URL)
+	target.PathTemplate = "/readyz/{namespace}/{name}"
+	lister := fakeProbeTargetLister{
+		target: target,
+	}
+
+	prober, ready := getProber(t, &lister)
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	assertEventuallyReady(t, prober, ch, sub, ready, &succeed, &probeRequests)
+}
+
 func TestProbeListerFail(t *testing.T) {
 	ch := getChannel(1)
 	sub := getSubscription()
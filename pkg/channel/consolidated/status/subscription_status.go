@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ReasonNoProbeResults is the reason of a subscription without any dispatcher pod probe result yet
+	ReasonNoProbeResults = "NoProbeResults"
+	// ReasonDispatcherNotReady is the default reason of a subscription not ready on some dispatcher pods
+	ReasonDispatcherNotReady = "DispatcherNotReady"
+)
+
+// SubscriptionReadiness is the readiness of a subscription aggregated across all the dispatcher pods
+type SubscriptionReadiness struct {
+	Ready   bool
+	Reason  string
+	Message string
+}
+
+// podResult is the last probe result of a dispatcher pod for a subscription
+type podResult struct {
+	ready  bool
+	reason string
+}
+
+// SubscriptionStatusAggregator accumulates the probe results of the dispatcher pods and
+// derives a single settled readiness per subscription:
+// - a subscription without any probe result is not ready (NoProbeResults)
+// - a subscription is not ready as long as one of the probed pods is not ready
+// - a subscription is ready once all of the probed pods are ready
+type SubscriptionStatusAggregator struct {
+	// mu guards results and readiness
+	mu        sync.Mutex
+	results   map[types.UID]map[string]podResult
+	readiness map[types.UID]SubscriptionReadiness
+}
+
+// NewSubscriptionStatusAggregator creates a new, empty SubscriptionStatusAggregator
+func NewSubscriptionStatusAggregator() *SubscriptionStatusAggregator {
+	return &SubscriptionStatusAggregator{
+		results:   make(map[types.UID]map[string]podResult),
+		readiness: make(map[types.UID]SubscriptionReadiness),
+	}
+}
+
+// SetPodResult records the probe result of a dispatcher pod for a subscription. An empty reason
+// for a not ready result defaults to ReasonDispatcherNotReady. It returns the aggregated readiness
+// of the subscription and whether it changed.
+func (a *SubscriptionStatusAggregator) SetPodResult(sub types.UID, pod string, ready bool, reason string) (SubscriptionReadiness, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !ready && reason == "" {
+		reason = ReasonDispatcherNotReady
+	}
+	if ready {
+		reason = ""
+	}
+
+	pods, ok := a.results[sub]
+	if !ok {
+		pods = make(map[string]podResult)
+		a.results[sub] = pods
+	}
+	pods[pod] = podResult{ready: ready, reason: reason}
+
+	return a.updateReadinessUnsafe(sub)
+}
+
+// RemovePod forgets the probe results of a dispatcher pod, e.g. when it is deleted.
+// It returns the subscriptions whose aggregated readiness changed.
+func (a *SubscriptionStatusAggregator) RemovePod(pod string) map[types.UID]SubscriptionReadiness {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	changed := make(map[types.UID]SubscriptionReadiness)
+	for sub, pods := range a.results {
+		if _, ok := pods[pod]; !ok {
+			continue
+		}
+		delete(pods, pod)
+		if readiness, ok := a.updateReadinessUnsafe(sub); ok {
+			changed[sub] = readiness
+		}
+	}
+	return changed
+}
+
+// RemoveSubscription forgets all the probe results of a subscription
+func (a *SubscriptionStatusAggregator) RemoveSubscription(sub types.UID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.results, sub)
+	delete(a.readiness, sub)
+}
+
+// Readiness returns the aggregated readiness of a subscription
+func (a *SubscriptionStatusAggregator) Readiness(sub types.UID) SubscriptionReadiness {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return aggregate(a.results[sub])
+}
+
+// updateReadinessUnsafe recomputes the aggregated readiness of a subscription, returning it
+// and whether it changed. It must be called with mu held.
+func (a *SubscriptionStatusAggregator) updateReadinessUnsafe(sub types.UID) (SubscriptionReadiness, bool) {
+	readiness := aggregate(a.results[sub])
+	previous, ok := a.readiness[sub]
+	a.readiness[sub] = readiness
+	return readiness, !ok || previous != readiness
+}
+
+// aggregate derives the readiness of a subscription from the probe results of its pods
+func aggregate(pods map[string]podResult) SubscriptionReadiness {
+	if len(pods) == 0 {
+		return SubscriptionReadiness{
+			Reason:  ReasonNoProbeResults,
+			Message: "No dispatcher pod has been probed yet",
+		}
+	}
+
+	notReady := make([]string, 0, len(pods))
+	for pod, result := range pods {
+		if !result.ready {
+			notReady = append(notReady, pod)
+		}
+	}
+	if len(notReady) == 0 {
+		return SubscriptionReadiness{Ready: true}
+	}
+
+	// Report the reason of the first not ready pod, for a stable result
+	sort.Strings(notReady)
+	return SubscriptionReadiness{
+		Reason:  pods[notReady[0]].reason,
+		Message: fmt.Sprintf("%d of %d dispatcher pods not ready: %v", len(notReady), len(pods), notReady),
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSubscriptionStatusAggregator(t *testing.T) {
+	const sub = types.UID("test-sub")
+	a := NewSubscriptionStatusAggregator()
+
+	// No probe results yet
+	assert.Equal(t, SubscriptionReadiness{Reason: ReasonNoProbeResults, Message: "No dispatcher pod has been probed yet"}, a.Readiness(sub))
+
+	// One ready pod and one pod not ready (yet)
+	readiness, changed := a.SetPodResult(sub, "10.0.0.1", true, "")
+	assert.True(t, changed)
+	assert.True(t, readiness.Ready)
+
+	readiness, changed = a.SetPodResult(sub, "10.0.0.2", false, "")
+	assert.True(t, changed)
+	assert.Equal(t, SubscriptionReadiness{Reason: ReasonDispatcherNotReady, Message: "1 of 2 dispatcher pods not ready: [10.0.0.2]"}, readiness)
+
+	// Another not ready pod with its own reason; the reason of the first not ready pod is kept
+	readiness, changed = a.SetPodResult(sub, "10.0.0.3", false, "PartitionsNotClaimed")
+	assert.True(t, changed)
+	assert.Equal(t, SubscriptionReadiness{Reason: ReasonDispatcherNotReady, Message: "2 of 3 dispatcher pods not ready: [10.0.0.2 10.0.0.3]"}, readiness)
+
+	// Repeated results do not change the aggregated readiness
+	_, changed = a.SetPodResult(sub, "10.0.0.3", false, "PartitionsNotClaimed")
+	assert.False(t, changed)
+
+	// The pods become ready one after the other
+	readiness, _ = a.SetPodResult(sub, "10.0.0.2", true, "")
+	assert.Equal(t, SubscriptionReadiness{Reason: "PartitionsNotClaimed", Message: "1 of 3 dispatcher pods not ready: [10.0.0.3]"}, readiness)
+	assert.False(t, a.Readiness(sub).Ready)
+
+	// Removing the last not ready pod settles the subscription as ready
+	changes := a.RemovePod("10.0.0.3")
+	assert.Equal(t, map[types.UID]SubscriptionReadiness{sub: {Ready: true}}, changes)
+	assert.True(t, a.Readiness(sub).Ready)
+
+	// Removing the subscription forgets its results
+	a.RemoveSubscription(sub)
+	assert.Equal(t, ReasonNoProbeResults, a.Readiness(sub).Reason)
+}
@@ -41,9 +41,24 @@ const (
 
 	KafkaChannelSeparator = "."
 
+	// SharedConsumerGroupAnnotation makes all the subscriptions of a channel share a single consumer group when set to "true"
+	SharedConsumerGroupAnnotation = "kafka.eventing.knative.dev/shared-consumer-group"
+
+	// ManualCommitAnnotation makes the subscriptions of a channel commit their offsets only once the subscriber
+	// has acknowledged each message (instead of relying on the periodic auto-commit) when set to "true"
+	ManualCommitAnnotation = "kafka.eventing.knative.dev/manual-commit"
+
 	knativeKafkaTopicPrefix = "knative-messaging-kafka"
 )
 
+var (
+	// ErrNoBrokers is returned by GetKafkaConfig when no Kafka brokers are configured
+	ErrNoBrokers = errors.New("missing or empty brokers in configuration")
+
+	// ErrEmptyBrokerValue is returned by GetKafkaConfig when the configured Kafka brokers contain an empty value
+	ErrEmptyBrokerValue = errors.New("empty brokers value in configuration")
+)
+
 type KafkaConfig struct {
 	Brokers       []string
 	EventingKafka *config.EventingKafkaConfig
@@ -99,14 +114,9 @@ func GetKafkaConfig(ctx context.Context, clientId string, configMap map[string]s
 	// Enable Sarama logging if specified in the ConfigMap
 	sarama.EnableSaramaLogging(eventingKafkaConfig.Sarama.EnableLogging)
 
-	if eventingKafkaConfig.Kafka.Brokers == "" {
-		return nil, errors.New("missing or empty brokers in configuration")
-	}
-	bootstrapServersSplitted := strings.Split(eventingKafkaConfig.Kafka.Brokers, ",")
-	for _, s := range bootstrapServersSplitted {
-		if len(s) == 0 {
-			return nil, errors.New("empty brokers value in configuration")
-		}
+	bootstrapServersSplitted, err := splitBrokers(eventingKafkaConfig.Kafka.Brokers)
+	if err != nil {
+		return nil, err
 	}
 
 	return &KafkaConfig{
@@ -115,6 +125,61 @@ func GetKafkaConfig(ctx context.Context, clientId string, configMap map[string]s
 	}, nil
 }
 
+// KafkaConfigFromEventingKafkaConfig converts the EventingKafkaConfig of a distributed channel into the
+// KafkaConfig used by the consolidated channel, to ease migrating between the two implementations.
+// The settings that only apply to the distributed channel (receiver, admin type and topic deletion) are
+// dropped. The returned config is a shallow copy of the original: the Sarama config, auth and allowed /
+// denied metrics are shared with it and must not be modified.
+func KafkaConfigFromEventingKafkaConfig(ekConfig *config.EventingKafkaConfig) (*KafkaConfig, error) {
+	if ekConfig == nil {
+		return nil, fmt.Errorf("missing configuration")
+	}
+
+	brokers, err := splitBrokers(ekConfig.Kafka.Brokers)
+	if err != nil {
+		return nil, err
+	}
+
+	consolidatedConfig := *ekConfig
+	consolidatedConfig.Channel.Receiver = config.EKReceiverConfig{}
+	consolidatedConfig.Channel.AdminType = ""
+	consolidatedConfig.Channel.DisableTopicDeletion = false
+
+	return &KafkaConfig{
+		Brokers:       brokers,
+		EventingKafka: &consolidatedConfig,
+	}, nil
+}
+
+// ToEventingKafkaConfig converts the KafkaConfig of the consolidated channel into the EventingKafkaConfig
+// used by the distributed channel, joining the brokers back into a comma-separated list. The dispatcher
+// settings that only apply to the consolidated channel (drain timeout and startup jitter) are dropped.
+// As with KafkaConfigFromEventingKafkaConfig, the returned config is a shallow copy.
+func (c *KafkaConfig) ToEventingKafkaConfig() *config.EventingKafkaConfig {
+	distributedConfig := config.EventingKafkaConfig{}
+	if c.EventingKafka != nil {
+		distributedConfig = *c.EventingKafka
+	}
+	distributedConfig.Kafka.Brokers = strings.Join(c.Brokers, ",")
+	distributedConfig.Channel.Dispatcher.DrainTimeoutMillis = 0
+	distributedConfig.Channel.Dispatcher.StartupJitterMillis = 0
+	return &distributedConfig
+}
+
+// splitBrokers splits a comma-separated list of Kafka brokers, rejecting empty values.
+func splitBrokers(brokers string) ([]string, error) {
+	if brokers == "" {
+		return nil, ErrNoBrokers
+	}
+	bootstrapServersSplitted := strings.Split(brokers, ",")
+	for _, s := range bootstrapServersSplitted {
+		if len(s) == 0 {
+			return nil, ErrEmptyBrokerValue
+		}
+	}
+	return bootstrapServersSplitted, nil
+}
+
 func TopicName(separator, namespace, name string) string {
 	topic := []string{knativeKafkaTopicPrefix, namespace, name}
 	return strings.Join(topic, separator)
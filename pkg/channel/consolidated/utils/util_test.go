@@ -253,6 +253,7 @@ func TestGetKafkaConfig(t *testing.T) {
 		data     map[string]string
 		getError string
 		expected *KafkaConfig
+		errIs    error
 	}{
 		{
 			name:     "invalid config path",
@@ -268,11 +269,13 @@ func TestGetKafkaConfig(t *testing.T) {
 			name:     "configmap with no bootstrapServers key (backwards-compatibility)",
 			data:     map[string]string{"key": "value"},
 			getError: "missing or empty brokers in configuration",
+			errIs:    ErrNoBrokers,
 		},
 		{
 			name:     "configmap with empty bootstrapServers value (backwards-compatibility)",
 			data:     map[string]string{"bootstrapServers": ""},
 			getError: "missing or empty brokers in configuration",
+			errIs:    ErrNoBrokers,
 		},
 		{
 			name: "single bootstrapServers (backwards-compatibility)",
@@ -376,8 +379,18 @@ func TestGetKafkaConfig(t *testing.T) {
 			name:     "empty string in brokers",
 			data:     map[string]string{"bootstrapServers": "kafkabroker.kafka:9092,"},
 			getError: "empty brokers value in configuration",
+			errIs:    ErrEmptyBrokerValue,
 		},
 		// Tests for the versioned/consolidated configmap are light, as the LoadSettings function has its own unit tests
+		{
+			name: "versioned, empty brokers",
+			data: map[string]string{
+				constants.VersionConfigKey:               constants.CurrentConfigVersion,
+				constants.EventingKafkaSettingsConfigKey: "kafka:\n  brokers: \"\"",
+			},
+			getError: "missing or empty brokers in configuration",
+			errIs:    ErrNoBrokers,
+		},
 		{
 			name: "versioned, multiple brokers, empty sarama field",
 			data: map[string]string{
@@ -417,6 +430,9 @@ func TestGetKafkaConfig(t *testing.T) {
 			if tc.getError != "" {
 				assert.NotNil(t, err)
 				assert.Equal(t, tc.getError, err.Error())
+				if tc.errIs != nil {
+					assert.ErrorIs(t, err, tc.errIs)
+				}
 			} else {
 				assert.Nil(t, err)
 				if diff := cmp.Diff(tc.expected, got, saramaIgnoreOpts...); diff != "" {
@@ -428,6 +444,66 @@ func TestGetKafkaConfig(t *testing.T) {
 	}
 }
 
+func TestKafkaConfigConversionRoundTrip(t *testing.T) {
+	distributedConfig := &config.EventingKafkaConfig{
+		Channel: config.EKChannelConfig{
+			Dispatcher: config.EKDispatcherConfig{
+				EKKubernetesConfig: config.EKKubernetesConfig{Replicas: 2},
+				MaxOpenRequests:    3,
+			},
+			Receiver:             config.EKReceiverConfig{EKKubernetesConfig: config.EKKubernetesConfig{Replicas: 4}},
+			AdminType:            "kafka",
+			DisableTopicDeletion: true,
+		},
+		CloudEvents: config.EKCloudEventConfig{MaxIdleConns: 1000, MaxIdleConnsPerHost: 100},
+		Kafka: config.EKKafkaConfig{
+			Brokers:             "broker-1:9092,broker-2:9092",
+			AuthSecretName:      secretName,
+			AuthSecretNamespace: secretNamespace,
+			Topic: config.EKKafkaTopicConfig{
+				DefaultNumPartitions:     4,
+				DefaultReplicationFactor: 3,
+				DefaultRetentionMillis:   604800000,
+			},
+		},
+	}
+
+	// Distributed -> Consolidated drops the distributed-only settings
+	kafkaConfig, err := KafkaConfigFromEventingKafkaConfig(distributedConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"broker-1:9092", "broker-2:9092"}, kafkaConfig.Brokers)
+	assert.Equal(t, distributedConfig.Kafka, kafkaConfig.EventingKafka.Kafka)
+	assert.Equal(t, distributedConfig.CloudEvents, kafkaConfig.EventingKafka.CloudEvents)
+	assert.Equal(t, distributedConfig.Channel.Dispatcher, kafkaConfig.EventingKafka.Channel.Dispatcher)
+	assert.Equal(t, config.EKReceiverConfig{}, kafkaConfig.EventingKafka.Channel.Receiver)
+	assert.Empty(t, kafkaConfig.EventingKafka.Channel.AdminType)
+	assert.False(t, kafkaConfig.EventingKafka.Channel.DisableTopicDeletion)
+
+	// The original config must not be modified
+	assert.Equal(t, "kafka", distributedConfig.Channel.AdminType)
+
+	// Consolidated -> Distributed restores the shared settings
+	kafkaConfig.EventingKafka.Channel.Dispatcher.DrainTimeoutMillis = 5000
+	kafkaConfig.EventingKafka.Channel.Dispatcher.StartupJitterMillis = 1000
+	roundTripped := kafkaConfig.ToEventingKafkaConfig()
+	expected := *distributedConfig
+	expected.Channel.Receiver = config.EKReceiverConfig{}
+	expected.Channel.AdminType = ""
+	expected.Channel.DisableTopicDeletion = false
+	assert.Equal(t, &expected, roundTripped)
+}
+
+func TestKafkaConfigFromEventingKafkaConfig_Errors(t *testing.T) {
+	_, err := KafkaConfigFromEventingKafkaConfig(nil)
+	assert.NotNil(t, err)
+
+	_, err = KafkaConfigFromEventingKafkaConfig(&config.EventingKafkaConfig{})
+	assert.Equal(t, ErrNoBrokers, err)
+
+	_, err = KafkaConfigFromEventingKafkaConfig(&config.EventingKafkaConfig{Kafka: config.EKKafkaConfig{Brokers: "broker-1:9092,"}})
+	assert.Equal(t, ErrEmptyBrokerValue, err)
+}
+
 func TestFindContainer(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -154,6 +154,24 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Creating Partitions - Not Supported By The Sidecar Endpoints
+func (c *CustomAdminClient) CreatePartitions(_ context.Context, topicName string, _ int32) *sarama.TopicError {
+	c.logger.Warn("Custom Sidecar Does Not Support Creating Partitions - Skipping Partition Creation", zap.String("TopicName", topicName))
+	return util.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar does not support creating partitions for topic '%s'", topicName))
+}
+
+// Custom REST Pass-Through Function For Listing Topics - Not Supported By The Sidecar Endpoints
+func (c *CustomAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+	c.logger.Warn("Custom Sidecar Does Not Support Listing Topics")
+	return nil, fmt.Errorf("custom sidecar does not support listing topics")
+}
+
+// Custom REST Pass-Through Function For Describing A Topic - Not Supported By The Sidecar Endpoints
+func (c *CustomAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicMetadata, error) {
+	c.logger.Warn("Custom Sidecar Does Not Support Describing Topics")
+	return nil, fmt.Errorf("custom sidecar does not support describing topic '%s'", topicName)
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
@@ -132,6 +132,48 @@ func TestDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Create A New Custom AdminClient To Test
+	adminClient := &CustomAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultTopicError := adminClient.CreatePartitions(context.TODO(), "TestTopicName", int32(8))
+
+	// Verify The Results (Not Supported)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidRequest, resultTopicError.Err)
+}
+
+// Test The ListTopics() Functionality
+func TestListTopics(t *testing.T) {
+
+	// Create A New Custom AdminClient To Test
+	adminClient := &CustomAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultTopics, err := adminClient.ListTopics(context.TODO())
+
+	// Verify The Results (Not Supported)
+	assert.Nil(t, resultTopics)
+	assert.NotNil(t, err)
+}
+
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Create A New Custom AdminClient To Test
+	adminClient := &CustomAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultMetadata, err := adminClient.DescribeTopic(context.TODO(), "test-topic")
+
+	// Verify The Results (Not Supported)
+	assert.Nil(t, resultMetadata)
+	assert.NotNil(t, err)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -90,16 +90,18 @@ func NewAdminClient(ctx context.Context, config *sarama.Config) (types.AdminClie
 func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
 
 	// Extract The Kafka TopicSpecification Configuration
-	topicNumPartitions := topicDetail.NumPartitions
-	topicRetentionMillis, err := strconv.ParseInt(*topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs], 10, 64)
-	if err != nil {
-		c.logger.Error("Failed To Parse Retention Millis From TopicDetail", zap.Error(err))
-		return util.NewTopicError(sarama.ErrInvalidConfig, "failed to parse retention millis from TopicDetail")
+	hubOptions := []eventhub.HubManagementOption{eventhub.HubWithPartitionCount(topicDetail.NumPartitions)}
+
+	// Convert Kafka Retention Millis To Azure EventHub Retention Days (If Absent The EventHub Default Applies)
+	if topicRetentionMillisString := topicDetail.ConfigEntries[constants.TopicDetailConfigRetentionMs]; topicRetentionMillisString != nil {
+		topicRetentionMillis, err := strconv.ParseInt(*topicRetentionMillisString, 10, 64)
+		if err != nil {
+			c.logger.Error("Failed To Parse Retention Millis From TopicDetail", zap.Error(err))
+			return util.NewTopicError(sarama.ErrInvalidConfig, "failed to parse retention millis from TopicDetail")
+		}
+		hubOptions = append(hubOptions, eventhub.HubWithMessageRetentionInDays(convertMillisToDays(topicRetentionMillis)))
 	}
 
-	// Convert Kafka Retention Millis To Azure EventHub Retention Days
-	topicRetentionDays := convertMillisToDays(topicRetentionMillis)
-
 	// If The HubManager Is Not Valid Then Return Error
 	if c.hubManager == nil {
 		c.logger.Warn("Failed To Find EventHub Namespace With Valid HubManager - Skipping Topic Creation", zap.String("Topic", topicName))
@@ -107,9 +109,7 @@ func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string,
 	}
 
 	// Create The EventHub (Topic) Via The PUT Rest Endpoint
-	_, err = c.hubManager.Put(ctx, topicName,
-		eventhub.HubWithPartitionCount(topicNumPartitions),
-		eventhub.HubWithMessageRetentionInDays(topicRetentionDays))
+	_, err := c.hubManager.Put(ctx, topicName, hubOptions...)
 	if err != nil {
 
 		// Handle Specific EventHub Error Codes (To Emulate Kafka Admin Behavior)
@@ -159,6 +159,24 @@ func (c *EventHubAdminClient) DeleteTopic(ctx context.Context, topicName string)
 	return util.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
 }
 
+// Kafka AdminClient CreatePartitions Implementation - Azure EventHubs Do Not Support Changing The Partition Count
+func (c *EventHubAdminClient) CreatePartitions(_ context.Context, topicName string, _ int32) *sarama.TopicError {
+	c.logger.Warn("Azure EventHubs Do Not Support Changing The Partition Count - Skipping Partition Creation", zap.String("Topic", topicName))
+	return util.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("azure eventhub '%s' partition count cannot be changed", topicName))
+}
+
+// Kafka AdminClient ListTopics Implementation - Not Supported Across The Azure EventHub Namespace Cache
+func (c *EventHubAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+	c.logger.Warn("Azure EventHubs Do Not Support Listing Topics")
+	return nil, fmt.Errorf("azure eventhub admin client does not support listing topics")
+}
+
+// Kafka AdminClient DescribeTopic Implementation - Not Supported By The Azure EventHub Namespace Cache
+func (c *EventHubAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicMetadata, error) {
+	c.logger.Warn("Azure EventHubs Do Not Support Describing Topics")
+	return nil, fmt.Errorf("azure eventhub admin client does not support describing topic '%s'", topicName)
+}
+
 // Kafka AdminClient Close Implementation Using Azure EventHub API
 func (c *EventHubAdminClient) Close() error {
 	return nil // Nothing to "close" in the HubManager (just a REST client) so this is just a compatibility no-op.
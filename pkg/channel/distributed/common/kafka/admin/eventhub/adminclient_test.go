@@ -306,6 +306,51 @@ func TestDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Create A Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A New EventHub AdminClient To Test
+	adminClient := &EventHubAdminClient{logger: logger}
+
+	// Perform The Test
+	resultTopicError := adminClient.CreatePartitions(context.TODO(), "TestTopicName", int32(8))
+
+	// Verify The Results (Not Supported)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidRequest, resultTopicError.Err)
+}
+
+// Test The ListTopics() Functionality
+func TestListTopics(t *testing.T) {
+
+	// Create A New EventHub AdminClient To Test
+	adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultTopics, err := adminClient.ListTopics(context.TODO())
+
+	// Verify The Results (Not Supported)
+	assert.Nil(t, resultTopics)
+	assert.NotNil(t, err)
+}
+
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Create A New EventHub AdminClient To Test
+	adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultMetadata, err := adminClient.DescribeTopic(context.TODO(), "test-topic")
+
+	// Verify The Results (Not Supported)
+	assert.Nil(t, resultMetadata)
+	assert.NotNil(t, err)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
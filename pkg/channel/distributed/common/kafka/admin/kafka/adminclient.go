@@ -92,6 +92,46 @@ func (k KafkaAdminClient) DeleteTopic(_ context.Context, topicName string) *sara
 	}
 }
 
+// Sarama Pass-Through Function For Increasing The Number Of Partitions Of A Topic
+func (k KafkaAdminClient) CreatePartitions(_ context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Create Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return util.NewUnknownTopicError("unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		err := k.clusterAdmin.CreatePartitions(topicName, numPartitions, nil, false)
+		return util.PromoteErrorToTopicError(err)
+	}
+}
+
+// Sarama Pass-Through Function For Listing Topics
+func (k KafkaAdminClient) ListTopics(_ context.Context) (map[string]sarama.TopicDetail, error) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To List Topics Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, fmt.Errorf("unable to list topics due to invalid ClusterAdmin - check Kafka authorization secrets")
+	} else {
+		return k.clusterAdmin.ListTopics()
+	}
+}
+
+// Sarama Pass-Through Function For Describing A Single Topic
+func (k KafkaAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicMetadata, error) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, fmt.Errorf("unable to describe topic due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	topicMetadata, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return nil, err
+	}
+	if len(topicMetadata) != 1 || topicMetadata[0] == nil {
+		return nil, fmt.Errorf("unexpected metadata returned when describing topic '%s'", topicName)
+	}
+	if topicMetadata[0].Err != sarama.ErrNoError {
+		return nil, topicMetadata[0].Err
+	}
+	return topicMetadata[0], nil
+}
+
 // Sarama Pass-Through Function For Closing ClusterAdmin
 func (k KafkaAdminClient) Close() error {
 	if k.clusterAdmin == nil {
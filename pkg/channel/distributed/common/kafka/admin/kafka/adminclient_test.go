@@ -108,6 +108,173 @@ func TestCreateTopic(t *testing.T) {
 	mockClusterAdmin.AssertExpectations(t)
 }
 
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	topicNumPartitions := int32(8)
+
+	// Create The Kafka TopicPartitionError To Return
+	errMsg := "test CreatePartitions() success"
+	testTopicPartitionError := &sarama.TopicPartitionError{
+		Err:    sarama.ErrNoError,
+		ErrMsg: &errMsg,
+	}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("CreatePartitions", topicName, topicNumPartitions).Return(testTopicPartitionError)
+
+	// Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logger,
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultTopicError := adminClient.CreatePartitions(ctx, topicName, topicNumPartitions)
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, errMsg, *resultTopicError.ErrMsg)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The CreatePartitions() Without ClusterAdmin Functionality
+func TestCreatePartitionsInvalidAdminClient(t *testing.T) {
+
+	// Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A New Kafka AdminClient To Test (No ClusterAdmin)
+	adminClient := &KafkaAdminClient{logger: logger}
+
+	// Perform The Test
+	resultTopicError := adminClient.CreatePartitions(context.TODO(), "TestTopicName", int32(8))
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+	assert.Equal(t, "unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets", *resultTopicError.ErrMsg)
+}
+
+// Test The ListTopics() Functionality
+func TestListTopics(t *testing.T) {
+
+	// Test Data
+	topics := map[string]sarama.TopicDetail{
+		"test-namespace.test-name": {NumPartitions: 4, ReplicationFactor: 1},
+		"__consumer_offsets":       {NumPartitions: 50, ReplicationFactor: 1},
+	}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("ListTopics").Return(topics, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultTopics, err := adminClient.ListTopics(context.TODO())
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, topics, resultTopics)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The ListTopics() Without ClusterAdmin Functionality
+func TestListTopicsInvalidAdminClient(t *testing.T) {
+
+	// Create A New Kafka AdminClient To Test (No ClusterAdmin)
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultTopics, err := adminClient.ListTopics(context.TODO())
+
+	// Verify The Results
+	assert.Nil(t, resultTopics)
+	assert.NotNil(t, err)
+}
+
+// Test The DescribeTopic() Functionality
+func TestDescribeTopic(t *testing.T) {
+
+	// Test Data
+	topicName := "test-namespace.test-name"
+	topicMetadata := &sarama.TopicMetadata{
+		Name:       topicName,
+		Partitions: []*sarama.PartitionMetadata{{ID: 0}, {ID: 1}},
+	}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{topicMetadata}, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultMetadata, err := adminClient.DescribeTopic(context.TODO(), topicName)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, topicMetadata, resultMetadata)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The DescribeTopic() Functionality For A Topic Reporting An Error
+func TestDescribeTopicMetadataError(t *testing.T) {
+
+	// Test Data
+	topicName := "test-namespace.test-name"
+	topicMetadata := &sarama.TopicMetadata{Name: topicName, Err: sarama.ErrUnknownTopicOrPartition}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return([]*sarama.TopicMetadata{topicMetadata}, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{
+		logger:       logtesting.TestLogger(t).Desugar(),
+		clusterAdmin: mockClusterAdmin,
+	}
+
+	// Perform The Test
+	resultMetadata, err := adminClient.DescribeTopic(context.TODO(), topicName)
+
+	// Verify The Results
+	assert.Nil(t, resultMetadata)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, err)
+	mockClusterAdmin.AssertExpectations(t)
+}
+
+// Test The DescribeTopic() Without ClusterAdmin Functionality
+func TestDescribeTopicInvalidAdminClient(t *testing.T) {
+
+	// Create A New Kafka AdminClient To Test (No ClusterAdmin)
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultMetadata, err := adminClient.DescribeTopic(context.TODO(), "test-topic")
+
+	// Verify The Results
+	assert.Nil(t, resultMetadata)
+	assert.NotNil(t, err)
+}
+
 // Test The CreateTopic() Without ClusterAdmin Functionality
 func TestCreateTopicInvalidAdminClient(t *testing.T) {
 
@@ -268,11 +435,13 @@ func (m *MockClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail,
 }
 
 func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
-	panic("implement me")
+	args := m.Called()
+	return args.Get(0).(map[string]sarama.TopicDetail), args.Error(1)
 }
 
 func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
-	panic("implement me")
+	args := m.Called(topics)
+	return args.Get(0).([]*sarama.TopicMetadata), args.Error(1)
 }
 
 func (m *MockClusterAdmin) DeleteTopic(topic string) error {
@@ -281,7 +450,8 @@ func (m *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(topic, count)
+	return args.Get(0).(*sarama.TopicPartitionError)
 }
 
 func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -44,6 +44,18 @@ func (c MockAdminClient) DeleteTopic(context.Context, string) *sarama.TopicError
 	return nil
 }
 
+func (c MockAdminClient) CreatePartitions(context.Context, string, int32) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) ListTopics(context.Context) (map[string]sarama.TopicDetail, error) {
+	return map[string]sarama.TopicDetail{}, nil
+}
+
+func (c MockAdminClient) DescribeTopic(_ context.Context, topicName string) (*sarama.TopicMetadata, error) {
+	return &sarama.TopicMetadata{Name: topicName}, nil
+}
+
 func (c MockAdminClient) Close() error {
 	return nil
 }
@@ -36,5 +36,8 @@ const (
 type AdminClientInterface interface {
 	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
 	DeleteTopic(context.Context, string) *sarama.TopicError
+	CreatePartitions(context.Context, string, int32) *sarama.TopicError
+	ListTopics(context.Context) (map[string]sarama.TopicDetail, error)
+	DescribeTopic(context.Context, string) (*sarama.TopicMetadata, error)
 	Close() error
 }
@@ -17,6 +17,9 @@ limitations under the License.
 package util
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/Shopify/sarama"
 )
 
@@ -27,6 +30,81 @@ const (
 	maxKError = sarama.ErrUnstableOffsetCommit
 )
 
+// Typed Kafka Topic Errors (Use errors.Is() To Match Errors Returned By TranslateTopicError())
+var (
+	ErrTopicAlreadyExists       = errors.New("kafka topic already exists")
+	ErrTopicNotFound            = errors.New("kafka topic or partition not found")
+	ErrInvalidTopic             = errors.New("invalid kafka topic name")
+	ErrInvalidPartitions        = errors.New("invalid number of kafka topic partitions")
+	ErrInvalidReplicationFactor = errors.New("invalid kafka topic replication factor")
+	ErrInvalidTopicConfig       = errors.New("invalid kafka topic configuration")
+	ErrTopicAuthorizationFailed = errors.New("not authorized to access kafka topic")
+	ErrKafkaUnavailable         = errors.New("kafka cluster unavailable")
+	ErrTopicOperationFailed     = errors.New("kafka topic operation failed")
+)
+
+// Mapping Of Common Kafka Error Codes To Typed Kafka Topic Errors (Others Map To ErrTopicOperationFailed)
+var topicErrorsByKError = map[sarama.KError]error{
+	sarama.ErrTopicAlreadyExists:         ErrTopicAlreadyExists,
+	sarama.ErrUnknownTopicOrPartition:    ErrTopicNotFound,
+	sarama.ErrInvalidTopic:               ErrInvalidTopic,
+	sarama.ErrInvalidPartitions:          ErrInvalidPartitions,
+	sarama.ErrInvalidReplicationFactor:   ErrInvalidReplicationFactor,
+	sarama.ErrInvalidReplicaAssignment:   ErrInvalidReplicationFactor,
+	sarama.ErrInvalidConfig:              ErrInvalidTopicConfig,
+	sarama.ErrTopicAuthorizationFailed:   ErrTopicAuthorizationFailed,
+	sarama.ErrClusterAuthorizationFailed: ErrTopicAuthorizationFailed,
+	sarama.ErrBrokerNotAvailable:         ErrKafkaUnavailable,
+	sarama.ErrLeaderNotAvailable:         ErrKafkaUnavailable,
+	sarama.ErrNotController:              ErrKafkaUnavailable,
+	sarama.ErrRequestTimedOut:            ErrKafkaUnavailable,
+}
+
+// KafkaTopicError Is A Typed, User-Facing Representation Of A Sarama TopicError
+type KafkaTopicError struct {
+	Kind   error         // One Of The Typed Kafka Topic Errors Above
+	KError sarama.KError // The Original Kafka Error Code
+	Detail string        // The Original (Optional) Error Message
+}
+
+// Error Implements The error Interface With A Message Suitable For Surfacing To Users
+func (e *KafkaTopicError) Error() string {
+	if len(e.Detail) > 0 {
+		return fmt.Sprintf("%s: %s (%s)", e.Kind.Error(), e.Detail, e.KError.Error())
+	}
+	return fmt.Sprintf("%s (%s)", e.Kind.Error(), e.KError.Error())
+}
+
+// Unwrap Exposes The Typed Kind Of The Error To errors.Is()
+func (e *KafkaTopicError) Unwrap() error {
+	return e.Kind
+}
+
+// Utility Function For Translating A Sarama TopicError Into A Typed KafkaTopicError (Nil On Success)
+func TranslateTopicError(topicError *sarama.TopicError) error {
+	if topicError == nil || topicError.Err == sarama.ErrNoError {
+		return nil
+	}
+	kind, ok := topicErrorsByKError[topicError.Err]
+	if !ok {
+		kind = ErrTopicOperationFailed
+	}
+	var detail string
+	if topicError.ErrMsg != nil {
+		detail = *topicError.ErrMsg
+	}
+	return &KafkaTopicError{Kind: kind, KError: topicError.Err, Detail: detail}
+}
+
+// Utility Function Returning Whether The Specified Error Indicates That A Kafka Topic Already Exists
+func IsTopicAlreadyExists(err error) bool {
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) {
+		err = TranslateTopicError(topicError)
+	}
+	return errors.Is(err, ErrTopicAlreadyExists)
+}
+
 // Utility Function To Up-Convert Basic Errors Into TopicErrors (With Message Matching For Pertinent Errors)
 func PromoteErrorToTopicError(err error) *sarama.TopicError {
 	if err == nil {
@@ -35,6 +113,8 @@ func PromoteErrorToTopicError(err error) *sarama.TopicError {
 		switch err := err.(type) {
 		case *sarama.TopicError:
 			return err
+		case *sarama.TopicPartitionError:
+			return &sarama.TopicError{Err: err.Err, ErrMsg: err.ErrMsg}
 		default:
 			for kError := minKError; kError <= maxKError; kError++ {
 				if err.Error() == kError.Error() {
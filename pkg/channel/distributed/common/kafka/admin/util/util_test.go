@@ -61,6 +61,13 @@ func TestPromoteErrorToTopicError(t *testing.T) {
 	assert.NotNil(t, topicError)
 	assert.Equal(t, sarama.ErrInvalidConfig, topicError.Err)
 	assert.Equal(t, topicErrorMessage, *topicError.ErrMsg)
+
+	// Test Valid TopicPartitionError
+	topicPartitionErrorMessage := "TopicPartitionErrorMessage"
+	topicError = PromoteErrorToTopicError(&sarama.TopicPartitionError{Err: sarama.ErrInvalidPartitions, ErrMsg: &topicPartitionErrorMessage})
+	assert.NotNil(t, topicError)
+	assert.Equal(t, sarama.ErrInvalidPartitions, topicError.Err)
+	assert.Equal(t, topicPartitionErrorMessage, *topicError.ErrMsg)
 }
 
 // Test The NewUnknownTopicError() Functionality
@@ -92,3 +99,81 @@ func TestNewTopicError(t *testing.T) {
 	assert.Equal(t, sarama.ErrInvalidConfig, topicError.Err)
 	assert.Equal(t, errMsg, *topicError.ErrMsg)
 }
+
+// Test The TranslateTopicError() Functionality
+func TestTranslateTopicError(t *testing.T) {
+
+	// Test Data
+	errMsg := "test error message"
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		Name        string
+		TopicError  *sarama.TopicError
+		ExpectedErr error
+		ExpectedMsg string
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{Name: "Nil TopicError", TopicError: nil, ExpectedErr: nil},
+		{Name: "ErrNoError", TopicError: NewTopicError(sarama.ErrNoError, errMsg), ExpectedErr: nil},
+		{
+			Name:        "ErrTopicAlreadyExists",
+			TopicError:  NewTopicError(sarama.ErrTopicAlreadyExists, errMsg),
+			ExpectedErr: ErrTopicAlreadyExists,
+			ExpectedMsg: "kafka topic already exists: " + errMsg + " (" + sarama.ErrTopicAlreadyExists.Error() + ")",
+		},
+		{
+			Name:        "ErrUnknownTopicOrPartition",
+			TopicError:  NewTopicError(sarama.ErrUnknownTopicOrPartition, errMsg),
+			ExpectedErr: ErrTopicNotFound,
+			ExpectedMsg: "kafka topic or partition not found: " + errMsg + " (" + sarama.ErrUnknownTopicOrPartition.Error() + ")",
+		},
+		{
+			Name:        "ErrInvalidReplicationFactor Without Message",
+			TopicError:  &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor},
+			ExpectedErr: ErrInvalidReplicationFactor,
+			ExpectedMsg: "invalid kafka topic replication factor (" + sarama.ErrInvalidReplicationFactor.Error() + ")",
+		},
+		{
+			Name:        "ErrBrokerNotAvailable",
+			TopicError:  NewTopicError(sarama.ErrBrokerNotAvailable, errMsg),
+			ExpectedErr: ErrKafkaUnavailable,
+			ExpectedMsg: "kafka cluster unavailable: " + errMsg + " (" + sarama.ErrBrokerNotAvailable.Error() + ")",
+		},
+		{
+			Name:        "Unmapped KError",
+			TopicError:  NewTopicError(sarama.ErrOffsetOutOfRange, errMsg),
+			ExpectedErr: ErrTopicOperationFailed,
+			ExpectedMsg: "kafka topic operation failed: " + errMsg + " (" + sarama.ErrOffsetOutOfRange.Error() + ")",
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := TranslateTopicError(testCase.TopicError)
+			if testCase.ExpectedErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, testCase.ExpectedErr))
+				assert.Equal(t, testCase.ExpectedMsg, err.Error())
+				var kafkaTopicError *KafkaTopicError
+				assert.True(t, errors.As(err, &kafkaTopicError))
+				assert.Equal(t, testCase.TopicError.Err, kafkaTopicError.KError)
+			}
+		})
+	}
+}
+
+// Test The IsTopicAlreadyExists() Functionality
+func TestIsTopicAlreadyExists(t *testing.T) {
+	assert.False(t, IsTopicAlreadyExists(nil))
+	assert.False(t, IsTopicAlreadyExists(errors.New("test error")))
+	assert.False(t, IsTopicAlreadyExists(NewTopicError(sarama.ErrInvalidTopic, "invalid")))
+	assert.False(t, IsTopicAlreadyExists(TranslateTopicError(NewTopicError(sarama.ErrInvalidTopic, "invalid"))))
+	assert.True(t, IsTopicAlreadyExists(NewTopicError(sarama.ErrTopicAlreadyExists, "exists")))
+	assert.True(t, IsTopicAlreadyExists(TranslateTopicError(NewTopicError(sarama.ErrTopicAlreadyExists, "exists"))))
+	assert.True(t, IsTopicAlreadyExists(fmt.Errorf("wrapped: %w", TranslateTopicError(NewTopicError(sarama.ErrTopicAlreadyExists, "exists")))))
+}
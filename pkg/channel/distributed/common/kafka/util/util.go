@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
 
@@ -28,6 +31,27 @@ func TopicName(namespace string, name string) string {
 	return fmt.Sprintf("%s.%s", namespace, name)
 }
 
+// IsManagedTopicName returns true if the specified Kafka Topic name follows the "<namespace>.<name>"
+// convention used by TopicName(), and is therefore considered to be owned by eventing-kafka.
+func IsManagedTopicName(topicName string) bool {
+	parts := strings.SplitN(topicName, ".", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	return len(validation.IsDNS1123Label(parts[0])) == 0 && len(validation.IsDNS1123Subdomain(parts[1])) == 0
+}
+
+// ManagedTopics returns the subset of the specified Kafka Topics which are owned by eventing-kafka.
+func ManagedTopics(topics map[string]sarama.TopicDetail) map[string]sarama.TopicDetail {
+	managedTopics := make(map[string]sarama.TopicDetail)
+	for topicName, topicDetail := range topics {
+		if IsManagedTopicName(topicName) {
+			managedTopics[topicName] = topicDetail
+		}
+	}
+	return managedTopics
+}
+
 // GroupId returns a formatted string representing the Kafka ConsumerGroup ID.
 func GroupId(uid string) string {
 	return fmt.Sprintf("kafka.%s", uid)
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
@@ -39,6 +40,47 @@ func TestTopicName(t *testing.T) {
 	assert.Equal(t, expectedTopicName, actualTopicName)
 }
 
+// Test The IsManagedTopicName() Functionality
+func TestIsManagedTopicName(t *testing.T) {
+	tests := []struct {
+		name      string
+		topicName string
+		expected  bool
+	}{
+		{name: "Managed Topic", topicName: TopicName("test-namespace", "test-name"), expected: true},
+		{name: "Managed Topic With Dotted Name", topicName: TopicName("test-namespace", "test.name"), expected: true},
+		{name: "No Separator", topicName: "test-topic", expected: false},
+		{name: "Empty Namespace", topicName: ".test-name", expected: false},
+		{name: "Empty Name", topicName: "test-namespace.", expected: false},
+		{name: "Uppercase Namespace", topicName: "TestNamespace.test-name", expected: false},
+		{name: "Internal Topic", topicName: "__consumer_offsets", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsManagedTopicName(test.topicName))
+		})
+	}
+}
+
+// Test The ManagedTopics() Functionality
+func TestManagedTopics(t *testing.T) {
+
+	// Test Data
+	managedTopicName := TopicName("test-namespace", "test-name")
+	topics := map[string]sarama.TopicDetail{
+		managedTopicName:     {NumPartitions: 4, ReplicationFactor: 1},
+		"__consumer_offsets": {NumPartitions: 50, ReplicationFactor: 1},
+		"unmanaged-topic":    {NumPartitions: 1, ReplicationFactor: 1},
+	}
+
+	// Perform The Test
+	actualTopics := ManagedTopics(topics)
+
+	// Verify The Results
+	assert.Len(t, actualTopics, 1)
+	assert.Equal(t, topics[managedTopicName], actualTopics[managedTopicName])
+}
+
 // Test The GroupId() Functionality
 func TestGroupId(t *testing.T) {
 
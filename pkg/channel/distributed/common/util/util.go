@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"os"
 	"os/signal"
 
@@ -25,14 +26,29 @@ import (
 
 // Block Waiting For Any Of The Specified Signals
 func WaitForSignal(logger *zap.Logger, signals ...os.Signal) {
+	WaitForSignalReturning(logger, signals...)
+}
 
-	// Register For Signal Notification On Channel
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, signals...)
+// Block Waiting For Any Of The Specified Signals And Return The One Received
+func WaitForSignalReturning(logger *zap.Logger, signals ...os.Signal) os.Signal {
+	return WaitForSignalWithContext(context.Background(), logger, signals...)
+}
 
-	// Block Waiting For Signal Channel Notification
-	sig := <-signalChan
+// Block Waiting For Any Of The Specified Signals Or Context Cancellation (Returns Nil Signal If Cancelled)
+func WaitForSignalWithContext(ctx context.Context, logger *zap.Logger, signals ...os.Signal) os.Signal {
 
-	// Log Signal Receipt
-	logger.Info("Received Signal", zap.String("Signal", sig.String()))
+	// Register For Signal Notification On Channel (Deregistering When Done)
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, signals...)
+	defer signal.Stop(signalChan)
+
+	// Block Waiting For Signal Channel Notification Or Context Cancellation
+	select {
+	case sig := <-signalChan:
+		logger.Info("Received Signal", zap.String("Signal", sig.String()))
+		return sig
+	case <-ctx.Done():
+		logger.Info("Context Cancelled While Waiting For Signal", zap.Error(ctx.Err()))
+		return nil
+	}
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"context"
+	"os"
 	"syscall"
 	"testing"
 	"time"
@@ -60,3 +62,67 @@ func TestWaitForSignal(t *testing.T) {
 	// Done!  (If The Test Completes (Doesn't Hang) Then It Was Successful)
 	logger.Info("Done!")
 }
+
+// Test The WaitForSignalReturning Functionality
+func TestWaitForSignalReturning(t *testing.T) {
+
+	// Logger Reference
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A Channel To Receive The Returned Signal
+	sigChan := make(chan os.Signal, 1)
+
+	// Start Async Go Routine
+	go func() {
+
+		// Perform The Test - Wait For SIGINT or SIGTERM
+		logger.Info("Waiting For Signal")
+		sigChan <- WaitForSignalReturning(logger, syscall.SIGINT, syscall.SIGTERM)
+	}()
+
+	// Wait A Short Bit To Let Async Function Start
+	logger.Info("Sleeping")
+	time.Sleep(500 * time.Millisecond)
+
+	// Issue The Signal
+	logger.Info("Sending Signal")
+	err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	assert.Nil(t, err)
+
+	// Verify The Received Signal Was Returned
+	select {
+	case sig := <-sigChan:
+		assert.Equal(t, syscall.SIGTERM, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed Out Waiting For Signal")
+	}
+}
+
+// Test The WaitForSignalWithContext Functionality When The Context Is Cancelled
+func TestWaitForSignalWithContextCancelled(t *testing.T) {
+
+	// Logger Reference
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A Cancellable Context
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	// Create A Channel To Receive The Returned Signal
+	sigChan := make(chan os.Signal, 1)
+
+	// Start Async Go Routine
+	go func() {
+		sigChan <- WaitForSignalWithContext(ctx, logger, syscall.SIGINT)
+	}()
+
+	// Cancel The Context
+	cancel()
+
+	// Verify Prompt Return With A Nil Signal
+	select {
+	case sig := <-sigChan:
+		assert.Nil(t, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed Out Waiting For Context Cancellation")
+	}
+}
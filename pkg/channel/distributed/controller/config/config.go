@@ -17,18 +17,32 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/Shopify/sarama"
+
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 )
 
 // ControllerConfigurationError is the type of error returned from VerifyConfiguration
 // when a setting is missing or invalid
-type ControllerConfigurationError string
+type ControllerConfigurationError struct {
+	// Field is the path of the invalid setting (e.g. "Kafka.Topic.DefaultNumPartitions")
+	Field string
+	// Reason describes why the setting is invalid (e.g. "must be > 0")
+	Reason string
+	// description, if set, replaces the "<Field> <Reason>" text of the error message
+	description string
+}
 
 func (err ControllerConfigurationError) Error() string {
-	return "controller: invalid configuration (" + string(err) + ")"
+	if err.description != "" {
+		return "controller: invalid configuration (" + err.description + ")"
+	}
+	return "controller: invalid configuration (" + err.Field + " " + err.Reason + ")"
 }
 
 // VerifyConfiguration returns an error if mandatory fields in the EventingKafkaConfig have not been set either
@@ -41,21 +55,67 @@ func VerifyConfiguration(configuration *commonconfig.EventingKafkaConfig) error
 	case constants.KafkaAdminTypeValueKafka, constants.KafkaAdminTypeValueAzure, constants.KafkaAdminTypeValueCustom:
 		configuration.Channel.AdminType = lowercaseKafkaAdminType
 	default:
-		return ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: " + configuration.Channel.AdminType)
+		return ControllerConfigurationError{
+			Field:       "Channel.AdminType",
+			Reason:      "is an invalid / unknown Kafka Admin Type: " + configuration.Channel.AdminType,
+			description: "Invalid / Unknown Kafka Admin Type: " + configuration.Channel.AdminType,
+		}
 	}
 
 	// Verify mandatory configuration settings
 	switch {
 	case configuration.Kafka.Topic.DefaultNumPartitions < 1:
-		return ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be > 0")
+		return ControllerConfigurationError{Field: "Kafka.Topic.DefaultNumPartitions", Reason: "must be > 0"}
 	case configuration.Kafka.Topic.DefaultReplicationFactor < 1:
-		return ControllerConfigurationError("Kafka.Topic.DefaultReplicationFactor must be > 0")
-	case configuration.Kafka.Topic.DefaultRetentionMillis < 1:
-		return ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be > 0")
+		return ControllerConfigurationError{Field: "Kafka.Topic.DefaultReplicationFactor", Reason: "must be > 0"}
+	case configuration.Kafka.Topic.DefaultRetentionMillis < 0:
+		return ControllerConfigurationError{Field: "Kafka.Topic.DefaultRetentionMillis", Reason: "must be >= 0 (0 uses the broker default)"}
 	case configuration.Channel.Dispatcher.Replicas < 1:
-		return ControllerConfigurationError("Distributed.Dispatcher.Replicas must be > 0")
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.Replicas", Reason: "must be > 0"}
 	case configuration.Channel.Receiver.Replicas < 1:
-		return ControllerConfigurationError("Distributed.Receiver.Replicas must be > 0")
+		return ControllerConfigurationError{Field: "Distributed.Receiver.Replicas", Reason: "must be > 0"}
+	case configuration.Channel.Dispatcher.MaxReplicas < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.MaxReplicas", Reason: "must be >= 0"}
+	case configuration.Channel.Receiver.MaxReplicas < 0:
+		return ControllerConfigurationError{Field: "Distributed.Receiver.MaxReplicas", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.MaxReplicas > 0 && configuration.Channel.Dispatcher.Replicas > configuration.Channel.Dispatcher.MaxReplicas:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.Replicas", Reason: fmt.Sprintf("must be <= %d", configuration.Channel.Dispatcher.MaxReplicas)}
+	case configuration.Channel.Receiver.MaxReplicas > 0 && configuration.Channel.Receiver.Replicas > configuration.Channel.Receiver.MaxReplicas:
+		return ControllerConfigurationError{Field: "Distributed.Receiver.Replicas", Reason: fmt.Sprintf("must be <= %d", configuration.Channel.Receiver.MaxReplicas)}
+	case configuration.Channel.Dispatcher.Concurrency < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.Concurrency", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.MaxOpenRequests < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.MaxOpenRequests", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.DialTimeoutMillis < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.DialTimeoutMillis", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.KeepAliveMillis < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.KeepAliveMillis", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.SessionTimeoutMillis < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.SessionTimeoutMillis", Reason: "must be >= 0"}
+	case configuration.Channel.Dispatcher.HeartbeatIntervalMillis < 0:
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.HeartbeatIntervalMillis", Reason: "must be >= 0"}
+	case !validGroupTimeouts(configuration.Channel.Dispatcher):
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.HeartbeatIntervalMillis", Reason: "must be < Distributed.Dispatcher.SessionTimeoutMillis / 3"}
+	case !client.IsValidRebalanceStrategy(configuration.Channel.Dispatcher.RebalanceStrategy):
+		return ControllerConfigurationError{Field: "Distributed.Dispatcher.RebalanceStrategy", Reason: "must be one of " + strings.Join(client.RebalanceStrategyNames(), ", ")}
 	}
 	return nil // no problems found
 }
+
+// validGroupTimeouts returns whether the heartbeat interval is below a third of the session timeout, using the
+// Sarama defaults for an unset value, as the ConfigBuilder does when either value is set
+func validGroupTimeouts(dispatcherConfig commonconfig.EKDispatcherConfig) bool {
+	if dispatcherConfig.SessionTimeoutMillis == 0 && dispatcherConfig.HeartbeatIntervalMillis == 0 {
+		return true
+	}
+	defaults := sarama.NewConfig()
+	sessionTimeoutMillis := defaults.Consumer.Group.Session.Timeout.Milliseconds()
+	if dispatcherConfig.SessionTimeoutMillis > 0 {
+		sessionTimeoutMillis = dispatcherConfig.SessionTimeoutMillis
+	}
+	heartbeatIntervalMillis := defaults.Consumer.Group.Heartbeat.Interval.Milliseconds()
+	if dispatcherConfig.HeartbeatIntervalMillis > 0 {
+		heartbeatIntervalMillis = dispatcherConfig.HeartbeatIntervalMillis
+	}
+	return heartbeatIntervalMillis*3 < sessionTimeoutMillis
+}
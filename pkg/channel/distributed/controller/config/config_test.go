@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,7 @@ const (
 	defaultRetentionMillis   = 13579
 
 	dispatcherReplicas      = 1
+	dispatcherConcurrency   = 10
 	dispatcherMemoryRequest = "20Mi"
 	dispatcherCpuRequest    = "100m"
 	dispatcherMemoryLimit   = "50Mi"
@@ -65,8 +67,17 @@ type TestCase struct {
 	receiverMemoryLimit                resource.Quantity
 	receiverMemoryRequest              resource.Quantity
 	receiverReplicas                   int
-
-	expectedError error
+	dispatcherMaxReplicas              int
+	receiverMaxReplicas                int
+	dispatcherConcurrency              int
+	dispatcherMaxOpenRequests          int
+	dispatcherRebalanceStrategy        string
+	dispatcherDialTimeoutMillis        int64
+	dispatcherKeepAliveMillis          int64
+	dispatcherSessionTimeoutMillis     int64
+	dispatcherHeartbeatIntervalMillis  int64
+
+	expectedErrorField string
 }
 
 // Get The Base / Valid Test Case - All Config Specified / No Errors
@@ -82,12 +93,12 @@ func getValidTestCase(name string) TestCase {
 		dispatcherMemoryLimit:              resource.MustParse(dispatcherMemoryLimit),
 		dispatcherMemoryRequest:            resource.MustParse(dispatcherMemoryRequest),
 		dispatcherReplicas:                 dispatcherReplicas,
+		dispatcherConcurrency:              dispatcherConcurrency,
 		receiverCpuLimit:                   resource.MustParse(receiverCpuLimit),
 		receiverCpuRequest:                 resource.MustParse(receiverCpuRquest),
 		receiverMemoryLimit:                resource.MustParse(receiverMemoryLimit),
 		receiverMemoryRequest:              resource.MustParse(receiverMemoryRequest),
 		receiverReplicas:                   receiverReplicas,
-		expectedError:                      nil,
 	}
 }
 
@@ -102,17 +113,21 @@ func TestVerifyConfiguration(t *testing.T) {
 
 	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultNumPartitions")
 	testCase.kafkaTopicDefaultNumPartitions = -1
-	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be > 0")
+	testCase.expectedErrorField = "Kafka.Topic.DefaultNumPartitions"
 	testCases = append(testCases, testCase)
 
 	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultReplicationFactor")
 	testCase.kafkaTopicDefaultReplicationFactor = -1
-	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultReplicationFactor must be > 0")
+	testCase.expectedErrorField = "Kafka.Topic.DefaultReplicationFactor"
 	testCases = append(testCases, testCase)
 
 	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis")
 	testCase.kafkaTopicDefaultRetentionMillis = -1
-	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be > 0")
+	testCase.expectedErrorField = "Kafka.Topic.DefaultRetentionMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultRetentionMillis = Zero (broker default)")
+	testCase.kafkaTopicDefaultRetentionMillis = 0
 	testCases = append(testCases, testCase)
 
 	testCase = getValidTestCase("Valid Config - Dispatcher.CpuLimit = Zero (unlimited)")
@@ -133,7 +148,32 @@ func TestVerifyConfiguration(t *testing.T) {
 
 	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.Replicas")
 	testCase.dispatcherReplicas = -1
-	testCase.expectedError = ControllerConfigurationError("Distributed.Dispatcher.Replicas must be > 0")
+	testCase.expectedErrorField = "Distributed.Dispatcher.Replicas"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Dispatcher.Replicas = Large Without MaxReplicas (unbounded)")
+	testCase.dispatcherReplicas = 1000
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Dispatcher.Replicas Below MaxReplicas")
+	testCase.dispatcherMaxReplicas = 3
+	testCase.dispatcherReplicas = 2
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Dispatcher.Replicas At MaxReplicas")
+	testCase.dispatcherMaxReplicas = 3
+	testCase.dispatcherReplicas = 3
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.Replicas Above MaxReplicas")
+	testCase.dispatcherMaxReplicas = 3
+	testCase.dispatcherReplicas = 4
+	testCase.expectedErrorField = "Distributed.Dispatcher.Replicas"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.MaxReplicas")
+	testCase.dispatcherMaxReplicas = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.MaxReplicas"
 	testCases = append(testCases, testCase)
 
 	testCase = getValidTestCase("Valid Config - Receiver.CpuLimit = Zero (unlimited)")
@@ -152,14 +192,113 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.receiverMemoryRequest = resource.Quantity{}
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Distributed Dispatcher.Concurrency = Zero (unbounded)")
+	testCase.dispatcherConcurrency = 0
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.Concurrency")
+	testCase.dispatcherConcurrency = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.Concurrency"
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Distributed Receiver.Replicas")
 	testCase.receiverReplicas = -1
-	testCase.expectedError = ControllerConfigurationError("Distributed.Receiver.Replicas must be > 0")
+	testCase.expectedErrorField = "Distributed.Receiver.Replicas"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Receiver.Replicas = Large Without MaxReplicas (unbounded)")
+	testCase.receiverReplicas = 1000
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Receiver.Replicas Below MaxReplicas")
+	testCase.receiverMaxReplicas = 3
+	testCase.receiverReplicas = 2
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Receiver.Replicas At MaxReplicas")
+	testCase.receiverMaxReplicas = 3
+	testCase.receiverReplicas = 3
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Receiver.Replicas Above MaxReplicas")
+	testCase.receiverMaxReplicas = 3
+	testCase.receiverReplicas = 4
+	testCase.expectedErrorField = "Distributed.Receiver.Replicas"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Receiver.MaxReplicas")
+	testCase.receiverMaxReplicas = -1
+	testCase.expectedErrorField = "Distributed.Receiver.MaxReplicas"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher Connection Limits")
+	testCase.dispatcherMaxOpenRequests = 1
+	testCase.dispatcherDialTimeoutMillis = 10000
+	testCase.dispatcherKeepAliveMillis = 30000
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.MaxOpenRequests")
+	testCase.dispatcherMaxOpenRequests = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.MaxOpenRequests"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.DialTimeoutMillis")
+	testCase.dispatcherDialTimeoutMillis = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.DialTimeoutMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.KeepAliveMillis")
+	testCase.dispatcherKeepAliveMillis = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.KeepAliveMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher Group Timeouts")
+	testCase.dispatcherSessionTimeoutMillis = 30000
+	testCase.dispatcherHeartbeatIntervalMillis = 5000
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.SessionTimeoutMillis")
+	testCase.dispatcherSessionTimeoutMillis = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.SessionTimeoutMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.HeartbeatIntervalMillis")
+	testCase.dispatcherHeartbeatIntervalMillis = -1
+	testCase.expectedErrorField = "Distributed.Dispatcher.HeartbeatIntervalMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.HeartbeatIntervalMillis Not Below A Third Of SessionTimeoutMillis")
+	testCase.dispatcherSessionTimeoutMillis = 30000
+	testCase.dispatcherHeartbeatIntervalMillis = 10000
+	testCase.expectedErrorField = "Distributed.Dispatcher.HeartbeatIntervalMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.SessionTimeoutMillis Not Above Three Default Heartbeats")
+	testCase.dispatcherSessionTimeoutMillis = 6000
+	testCase.expectedErrorField = "Distributed.Dispatcher.HeartbeatIntervalMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.HeartbeatIntervalMillis Not Below A Third Of Default SessionTimeout")
+	testCase.dispatcherHeartbeatIntervalMillis = 4000
+	testCase.expectedErrorField = "Distributed.Dispatcher.HeartbeatIntervalMillis"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Distributed Dispatcher.SessionTimeoutMillis Only")
+	testCase.dispatcherSessionTimeoutMillis = 30000
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher RebalanceStrategy")
+	testCase.dispatcherRebalanceStrategy = "sticky"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Distributed Dispatcher.RebalanceStrategy")
+	testCase.dispatcherRebalanceStrategy = "cooperative-sticky"
+	testCase.expectedErrorField = "Distributed.Dispatcher.RebalanceStrategy"
 	testCases = append(testCases, testCase)
 
 	testCase = getValidTestCase("Invalid Config - Kafka.Provider")
 	testCase.kafkaAdminType = "invalidadmintype"
-	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: invalidadmintype")
+	testCase.expectedErrorField = "Channel.AdminType"
 	testCases = append(testCases, testCase)
 
 	for _, testCase := range testCases {
@@ -174,17 +313,26 @@ func TestVerifyConfiguration(t *testing.T) {
 			testConfig.Channel.Dispatcher.MemoryLimit = testCase.dispatcherMemoryLimit
 			testConfig.Channel.Dispatcher.MemoryRequest = testCase.dispatcherMemoryRequest
 			testConfig.Channel.Dispatcher.Replicas = testCase.dispatcherReplicas
+			testConfig.Channel.Dispatcher.Concurrency = testCase.dispatcherConcurrency
 			testConfig.Channel.Receiver.CpuLimit = testCase.receiverCpuLimit
 			testConfig.Channel.Receiver.CpuRequest = testCase.receiverCpuRequest
 			testConfig.Channel.Receiver.MemoryLimit = testCase.receiverMemoryLimit
 			testConfig.Channel.Receiver.MemoryRequest = testCase.receiverMemoryRequest
 			testConfig.Channel.Receiver.Replicas = testCase.receiverReplicas
+			testConfig.Channel.Dispatcher.MaxReplicas = testCase.dispatcherMaxReplicas
+			testConfig.Channel.Receiver.MaxReplicas = testCase.receiverMaxReplicas
+			testConfig.Channel.Dispatcher.MaxOpenRequests = testCase.dispatcherMaxOpenRequests
+			testConfig.Channel.Dispatcher.DialTimeoutMillis = testCase.dispatcherDialTimeoutMillis
+			testConfig.Channel.Dispatcher.KeepAliveMillis = testCase.dispatcherKeepAliveMillis
+			testConfig.Channel.Dispatcher.RebalanceStrategy = testCase.dispatcherRebalanceStrategy
+			testConfig.Channel.Dispatcher.SessionTimeoutMillis = testCase.dispatcherSessionTimeoutMillis
+			testConfig.Channel.Dispatcher.HeartbeatIntervalMillis = testCase.dispatcherHeartbeatIntervalMillis
 
 			// Perform The Test
 			err := VerifyConfiguration(testConfig)
 
 			// Verify The Results
-			if testCase.expectedError == nil {
+			if testCase.expectedErrorField == "" {
 				assert.Nil(t, err)
 				assert.Equal(t, testCase.kafkaTopicDefaultNumPartitions, testConfig.Kafka.Topic.DefaultNumPartitions)
 				assert.Equal(t, testCase.kafkaTopicDefaultReplicationFactor, testConfig.Kafka.Topic.DefaultReplicationFactor)
@@ -195,19 +343,34 @@ func TestVerifyConfiguration(t *testing.T) {
 				assert.Equal(t, testCase.dispatcherMemoryLimit, testConfig.Channel.Dispatcher.MemoryLimit)
 				assert.Equal(t, testCase.dispatcherMemoryRequest, testConfig.Channel.Dispatcher.MemoryRequest)
 				assert.Equal(t, testCase.dispatcherReplicas, testConfig.Channel.Dispatcher.Replicas)
+				assert.Equal(t, testCase.dispatcherConcurrency, testConfig.Channel.Dispatcher.Concurrency)
 				assert.Equal(t, testCase.receiverCpuLimit, testConfig.Channel.Receiver.CpuLimit)
 				assert.Equal(t, testCase.receiverCpuRequest, testConfig.Channel.Receiver.CpuRequest)
 				assert.Equal(t, testCase.receiverMemoryLimit, testConfig.Channel.Receiver.MemoryLimit)
 				assert.Equal(t, testCase.receiverMemoryRequest, testConfig.Channel.Receiver.MemoryRequest)
 				assert.Equal(t, testCase.receiverReplicas, testConfig.Channel.Receiver.Replicas)
+				assert.Equal(t, testCase.dispatcherMaxReplicas, testConfig.Channel.Dispatcher.MaxReplicas)
+				assert.Equal(t, testCase.receiverMaxReplicas, testConfig.Channel.Receiver.MaxReplicas)
+				assert.Equal(t, testCase.dispatcherMaxOpenRequests, testConfig.Channel.Dispatcher.MaxOpenRequests)
+				assert.Equal(t, testCase.dispatcherDialTimeoutMillis, testConfig.Channel.Dispatcher.DialTimeoutMillis)
+				assert.Equal(t, testCase.dispatcherKeepAliveMillis, testConfig.Channel.Dispatcher.KeepAliveMillis)
+				assert.Equal(t, testCase.dispatcherRebalanceStrategy, testConfig.Channel.Dispatcher.RebalanceStrategy)
+				assert.Equal(t, testCase.dispatcherSessionTimeoutMillis, testConfig.Channel.Dispatcher.SessionTimeoutMillis)
+				assert.Equal(t, testCase.dispatcherHeartbeatIntervalMillis, testConfig.Channel.Dispatcher.HeartbeatIntervalMillis)
 			} else {
-				assert.Equal(t, testCase.expectedError, err)
+				var configurationError ControllerConfigurationError
+				assert.True(t, errors.As(err, &configurationError))
+				assert.Equal(t, testCase.expectedErrorField, configurationError.Field)
 			}
 		})
 	}
 }
 
 func TestControllerConfigurationError_Error(t *testing.T) {
-	err := ControllerConfigurationError("test")
-	assert.Equal(t, "controller: invalid configuration (test)", err.Error())
+	err := ControllerConfigurationError{Field: "Test.Field", Reason: "must be > 0"}
+	assert.Equal(t, "controller: invalid configuration (Test.Field must be > 0)", err.Error())
+
+	// The Admin Type Error Keeps Its Original Wording
+	adminTypeErr := VerifyConfiguration(&commonconfig.EventingKafkaConfig{Channel: commonconfig.EKChannelConfig{AdminType: "invalidadmintype"}})
+	assert.EqualError(t, adminTypeErr, "controller: invalid configuration (Invalid / Unknown Kafka Admin Type: invalidadmintype)")
 }
@@ -58,6 +58,9 @@ const (
 	ReconciliationFailedError = "reconciliation failed"
 	FinalizationFailedError   = "finalization failed"
 
+	// KafkaChannel Annotation Overriding The Channel.DisableTopicDeletion Config ("true" / "false")
+	DeleteTopicOnFinalizeAnnotation = "kafka.eventing.knative.dev/delete-topic-on-finalize"
+
 	// Eventing-Kafka Finalizers Prefix
 	EventingKafkaFinalizerPrefix = "eventing-kafka/"
 	KafkaChannelFinalizerSuffix  = "kafkachannels.messaging.knative.dev" // Matches default value in client/injection/reconciler/messaging/v1beta1/kafkachannel
@@ -320,6 +320,10 @@ func TestReconcile(t *testing.T) {
 			withDispatcherDeployment(controllertesting.WithConfigMapHash("initial-hash-to-be-overridden-by-controller")),
 			wantUpdateDispatcherDeployment),
 
+		newStableSystemTest("Reconcile Receiver Deployment - Redeployment on ConfigMapHash change",
+			withReceiverDeployment(controllertesting.WithConfigMapHash("initial-hash-to-be-overridden-by-controller")),
+			wantUpdateReceiverDeployment),
+
 		newStableSystemTest("Missing KafkaSecret - Error", withoutSecret,
 			replaceStatusUpdates(getReadyKafkaChannel(controllertesting.WithKafkaChannelConfigurationFailedNoSecret)),
 			withReconcilerOptions(withEmptyKafkaSecret),
@@ -567,6 +571,12 @@ func wantUpdateDispatcherDeployment(test *TableRow) {
 	test.WantEvents = append([]string{controllertesting.NewKafkaChannelDispatcherDeploymentUpdatedEvent()}, test.WantEvents...)
 }
 
+// wantUpdateReceiverDeployment adds to the WantUpdates and WantEvents fields the entries involved in a Receiver deployment update
+func wantUpdateReceiverDeployment(test *TableRow) {
+	test.WantUpdates = append(test.WantUpdates, controllertesting.NewDeploymentUpdateActionImpl(controllertesting.NewKafkaChannelReceiverDeployment()))
+	test.WantEvents = append([]string{controllertesting.NewKafkaChannelReceiverDeploymentUpdatedEvent()}, test.WantEvents...)
+}
+
 // wantCreateService returns a function that replaces a named service in the WantCreates field
 // with the one provided.
 func wantCreateService(name string, newService *corev1.Service) testOption {
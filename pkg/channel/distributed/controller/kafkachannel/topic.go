@@ -24,6 +24,7 @@ import (
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 
@@ -33,6 +34,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 )
 
 // reconcileKafkaTopic Reconciles The Kafka Topic Associated With The Specified Channel
@@ -47,21 +49,16 @@ func (r *Reconciler) reconcileKafkaTopic(ctx context.Context, channel *kafkav1be
 	// Get The Topic Configuration (First From Channel With Failover To Environment)
 	numPartitions := config.NumPartitions(channel, r.config, logger)
 	replicationFactor := config.ReplicationFactor(channel, r.config, logger)
-
-	// TODO - The eventing-kafka KafkaChannel spec does not include RetentionMillis so we're
-	//        currently just using the default value specified in the ConfigMap.  If/when the
-	//        RetentionMillis is added, any value from channel.Spec.RetentionMillis should
-	//        take precedence.
-	retentionMillis := r.config.Kafka.Topic.DefaultRetentionMillis
+	retentionMillis := config.RetentionMillis(channel, r.config, logger)
 
 	// Create The Topic (Handles Case Where Already Exists)
-	err := r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	err := r.createTopic(ctx, channel, topicName, numPartitions, replicationFactor, retentionMillis)
 
 	// Log Results & Return Status
 	if err != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicReconciliationFailed.String(), "Failed To Reconcile Kafka Topic For Channel: %v", err)
 		logger.Error("Failed To Reconcile Kafka Topic", zap.Error(err))
-		channel.Status.MarkTopicFailed("TopicFailed", fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
+		channel.Status.MarkTopicFailed(kafkasarama.TopicErrorReason(err, "TopicFailed"), fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
 	} else {
 		logger.Info("Successfully Reconciled Kafka Topic")
 		channel.Status.MarkTopicTrue()
@@ -78,8 +75,24 @@ func (r *Reconciler) finalizeKafkaTopic(ctx context.Context, channel *kafkav1bet
 	// Get Channel Specific Logger (Provided Via Context) & Add Topic Name
 	logger := logging.FromContext(ctx).Desugar().With(zap.String("TopicName", topicName))
 
+	// Leave The Kafka Topic In Place If Deletion Is Disabled For This Channel
+	if !r.topicDeletionEnabled(channel, logger) {
+		logger.Info("Kafka Topic Deletion Disabled - Retaining Kafka Topic")
+		return nil
+	}
+
+	// Never Delete A Kafka Topic Still In Use By Another KafkaChannel (ie - Recreated With The Same Name)
+	shared, err := r.topicSharedWithOtherChannel(channel, topicName)
+	if err != nil {
+		logger.Error("Failed To Determine Whether Kafka Topic Is Shared", zap.Error(err))
+		return err
+	} else if shared {
+		logger.Info("Kafka Topic Shared With Another KafkaChannel - Retaining Kafka Topic")
+		return nil
+	}
+
 	// Delete The Kafka Topic & Handle Error Response
-	err := r.deleteTopic(ctx, topicName)
+	err = r.deleteTopic(ctx, topicName)
 	if err != nil {
 		logger.Error("Failed To Finalize Kafka Topic", zap.Error(err))
 		return err
@@ -89,21 +102,68 @@ func (r *Reconciler) finalizeKafkaTopic(ctx context.Context, channel *kafkav1bet
 	}
 }
 
-// createTopic Creates The Specified Kafka Topic
-func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, retentionMillis int64) error {
+// topicDeletionEnabled Returns Whether The Kafka Topic Should Be Deleted When The Specified Channel Is Finalized
+// (The Channel's DeleteTopicOnFinalizeAnnotation, If Valid, Takes Precedence Over The Global Configuration)
+func (r *Reconciler) topicDeletionEnabled(channel *kafkav1beta1.KafkaChannel, logger *zap.Logger) bool {
+	deleteTopic := !r.config.Channel.DisableTopicDeletion
+	if value, ok := channel.Annotations[constants.DeleteTopicOnFinalizeAnnotation]; ok {
+		annotationValue, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Warn("Ignoring Invalid Topic Deletion Annotation", zap.String("Annotation", constants.DeleteTopicOnFinalizeAnnotation), zap.String("Value", value))
+		} else {
+			deleteTopic = annotationValue
+		}
+	}
+	return deleteTopic
+}
+
+// topicSharedWithOtherChannel Returns Whether Any Other (Non-Deleted) KafkaChannel Uses The Specified Kafka Topic
+func (r *Reconciler) topicSharedWithOtherChannel(channel *kafkav1beta1.KafkaChannel, topicName string) (bool, error) {
+	channels, err := r.kafkachannelLister.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, otherChannel := range channels {
+		if otherChannel.UID == channel.UID || otherChannel.DeletionTimestamp != nil {
+			continue
+		}
+		if util.TopicName(otherChannel) == topicName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// createTopic Creates The Specified Kafka Topic (Reconciling The Partitions Of An Existing Topic)
+func (r *Reconciler) createTopic(ctx context.Context, channel *kafkav1beta1.KafkaChannel, topicName string, partitions int32, replicationFactor int16, retentionMillis int64) error {
 
 	// Get The Logger From The Context
 	logger := logging.FromContext(ctx)
 
 	// Create The TopicDefinition
-	retentionMillisString := strconv.FormatInt(retentionMillis, 10)
 	topicDetail := &sarama.TopicDetail{
 		NumPartitions:     partitions,
 		ReplicationFactor: replicationFactor,
 		ReplicaAssignment: nil, // Currently Not Assigning Partitions To Replicas
-		ConfigEntries: map[string]*string{
-			commonconstants.KafkaTopicConfigRetentionMs: &retentionMillisString,
-		},
+		ConfigEntries:     map[string]*string{},
+	}
+
+	// Include The RetentionMillis Topic Config Unless Zero (Otherwise Broker Default Applies)
+	if retentionMillis > 0 {
+		retentionMillisString := strconv.FormatInt(retentionMillis, 10)
+		topicDetail.ConfigEntries[commonconstants.KafkaTopicConfigRetentionMs] = &retentionMillisString
+	}
+
+	// Include The Optional MinInSyncReplicas Topic Config (Otherwise Broker Default Applies)
+	if channel.Spec.MinInSyncReplicas > 0 {
+		minInSyncReplicasString := strconv.Itoa(int(channel.Spec.MinInSyncReplicas))
+		topicDetail.ConfigEntries[commonconstants.KafkaTopicConfigMinInSyncReplicas] = &minInSyncReplicasString
+	}
+
+	// Include Any Additional Topic Configs From The KafkaChannel Spec (Validated By The Webhook)
+	for key, value := range channel.Spec.TopicConfig {
+		value := value
+		topicDetail.ConfigEntries[key] = &value
 	}
 
 	// Attempt To Create The Topic & Process TopicError Results (Including Success ;)
@@ -116,6 +176,9 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 			return nil
 		case sarama.ErrTopicAlreadyExists:
 			logger.Info("Kafka Topic Already Exists - No Creation Required")
+			if r.config.Channel.AdminType == constants.KafkaAdminTypeValueKafka {
+				return r.reconcilePartitions(ctx, channel, topicName, partitions)
+			}
 			return nil
 		default:
 			logger.Error("Failed To Create Topic")
@@ -127,6 +190,68 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 	}
 }
 
+// reconcilePartitions Converges The Partition Count Of The Specified (Existing) Kafka Topic (Never Decreasing)
+func (r *Reconciler) reconcilePartitions(ctx context.Context, channel *kafkav1beta1.KafkaChannel, topicName string, partitions int32) error {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).With(zap.Int32("NumPartitions", partitions))
+
+	// Describe The Existing Topic To Determine Its Live Partition Count
+	topicMetadata, err := r.adminClient.DescribeTopic(ctx, topicName)
+	if err != nil {
+		logger.Error("Failed To Describe Existing Kafka Topic", zap.Error(err))
+		return err
+	}
+	livePartitions := int32(len(topicMetadata.Partitions))
+	logger = logger.With(zap.Int32("LivePartitions", livePartitions))
+
+	// Grow The Topic If It Has Fewer Partitions Than Desired (Partition Count Can Only Be Increased)
+	switch {
+	case livePartitions < partitions:
+		logger.Info("Kafka Topic Has Fewer Partitions Than Desired - Creating Partitions")
+		err = r.createPartitions(ctx, topicName, partitions)
+		if err != nil {
+			channel.Status.MarkTopicPartitionsDrifted("PartitionCountDrift", "Kafka topic has %d partitions, failed to increase to %d: %v", livePartitions, partitions, err)
+			return err
+		}
+		channel.Status.MarkTopicPartitionsTrueWithReason("PartitionsIncreased", "Kafka topic partitions increased from %d to %d", livePartitions, partitions)
+	case livePartitions > partitions:
+		logger.Warn("Kafka Topic Has More Partitions Than Desired - Partitions Cannot Be Decreased")
+		channel.Status.MarkTopicPartitionsDrifted("PartitionCountDrift", "Kafka topic has %d partitions, more than the desired %d (partitions cannot be decreased)", livePartitions, partitions)
+	default:
+		logger.Debug("Kafka Topic Has The Desired Number Of Partitions - No Creation Required")
+		channel.Status.MarkTopicPartitionsTrue()
+	}
+	return nil
+}
+
+// createPartitions Increases The Number Of Partitions Of The Specified (Existing) Kafka Topic
+func (r *Reconciler) createPartitions(ctx context.Context, topicName string, partitions int32) error {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx).With(zap.Int32("NumPartitions", partitions))
+
+	// Attempt To Grow The Topic & Process TopicError Results (Partition Count Can Only Be Increased)
+	err := r.adminClient.CreatePartitions(ctx, topicName, partitions)
+	if err != nil {
+		logger := logger.With(zap.Int16("KError", int16(err.Err)))
+		switch err.Err {
+		case sarama.ErrNoError:
+			logger.Info("Successfully Created New Kafka Topic Partitions (ErrNoError)")
+			return nil
+		case sarama.ErrInvalidPartitions:
+			logger.Debug("Kafka Topic Already Has The Desired Number Of Partitions - No Creation Required")
+			return nil
+		default:
+			logger.Error("Failed To Create Topic Partitions")
+			return err
+		}
+	} else {
+		logger.Info("Successfully Created New Kafka Topic Partitions (Nil TopicError)")
+		return nil
+	}
+}
+
 // deleteTopic Deletes The Specified Kafka Topic
 func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 
@@ -24,32 +24,42 @@ import (
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/controller"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 )
 
 // Define The Topic TestCase Type
 type TopicTestCase struct {
-	Name            string
-	Channel         *kafkav1beta1.KafkaChannel
-	WantTopicDetail *sarama.TopicDetail
-	MockErrorCode   sarama.KError
-	WantError       string
-	WantCreate      bool
-	WantDelete      bool
+	Name                 string
+	Channel              *kafkav1beta1.KafkaChannel
+	WantTopicDetail      *sarama.TopicDetail
+	MockErrorCode        sarama.KError
+	MockPartitionsCode   sarama.KError
+	MockLivePartitions   int32
+	MockDescribeError    error
+	WantError            string
+	WantCreate           bool
+	WantCreatePartitions bool
+	WantDelete           bool
+	WantRetain           bool
+	WantPartitionsStatus corev1.ConditionStatus
+	WantTopicStatus      corev1.ConditionStatus
+	WantTopicReason      string
+	ConfigOptions        []controllertesting.KafkaConfigOption
+	OtherChannels        []*kafkav1beta1.KafkaChannel
 }
 
-//
 // Test The Kafka Topic Reconciliation
 //
 // Ideally the Knative Eventing test runner implementation would have provided a hook for additional
 // channel-type-specific (ie Kafka, NATS, etc) validation, but unfortunately it is solely focused
 // on the K8S objects existing/not.  Therefore we're left to test the actual Topic handling separately.
-//
 func TestReconcileTopic(t *testing.T) {
 
 	// Define & Initialize The TopicTestCases
@@ -87,6 +97,93 @@ func TestReconcileTopic(t *testing.T) {
 				ReplicationFactor: controllertesting.ReplicationFactor,
 				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
 			},
+			WantTopicStatus: corev1.ConditionTrue,
+		},
+		{
+			Name: "Create New Topic With RetentionDuration",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithRetentionDuration,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
+			},
+		},
+		{
+			Name: "Create New Topic With Broker Default Retention",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			ConfigOptions: []controllertesting.KafkaConfigOption{controllertesting.WithBrokerDefaultRetention},
+			WantCreate:    true,
+			WantDelete:    false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{},
+			},
+		},
+		{
+			Name: "Create New Topic With MinInSyncReplicas",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithMinInSyncReplicas,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries: map[string]*string{
+					commonconstants.KafkaTopicConfigRetentionMs:       &controllertesting.DefaultRetentionMillisString,
+					commonconstants.KafkaTopicConfigMinInSyncReplicas: &controllertesting.MinInSyncReplicasString,
+				},
+			},
+		},
+		{
+			Name: "Create New Topic With TopicConfig",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithTopicConfig,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries: map[string]*string{
+					commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString,
+					"segment.ms": &controllertesting.SegmentMillisString,
+				},
+			},
 		},
 		{
 			Name: "Create Preexisting Topic",
@@ -106,7 +203,91 @@ func TestReconcileTopic(t *testing.T) {
 				ReplicationFactor: controllertesting.ReplicationFactor,
 				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
 			},
-			MockErrorCode: sarama.ErrTopicAlreadyExists,
+			MockErrorCode:        sarama.ErrTopicAlreadyExists,
+			MockLivePartitions:   controllertesting.NumPartitions - 1,
+			WantCreatePartitions: true,
+			WantPartitionsStatus: corev1.ConditionTrue,
+		},
+		{
+			Name: "Create Preexisting Topic With Desired Partitions",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockErrorCode:        sarama.ErrTopicAlreadyExists,
+			MockLivePartitions:   controllertesting.NumPartitions,
+			WantPartitionsStatus: corev1.ConditionTrue,
+		},
+		{
+			Name: "Create Preexisting Topic With More Partitions",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+			),
+			WantCreate: true,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockErrorCode:        sarama.ErrTopicAlreadyExists,
+			MockLivePartitions:   controllertesting.NumPartitions + 1,
+			WantPartitionsStatus: corev1.ConditionFalse,
+		},
+		{
+			Name: "Error Describing Preexisting Topic",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+			),
+			WantCreate: true,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockErrorCode:     sarama.ErrTopicAlreadyExists,
+			MockDescribeError: sarama.ErrBrokerNotAvailable,
+			WantError:         sarama.ErrBrokerNotAvailable.Error(),
+		},
+		{
+			Name: "Error Creating Preexisting Topic Partitions",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockErrorCode:        sarama.ErrTopicAlreadyExists,
+			MockPartitionsCode:   sarama.ErrBrokerNotAvailable,
+			MockLivePartitions:   controllertesting.NumPartitions - 1,
+			WantCreatePartitions: true,
+			WantPartitionsStatus: corev1.ConditionFalse,
+			WantError:            sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
 		},
 		{
 			Name: "Error Creating Topic",
@@ -126,8 +307,33 @@ func TestReconcileTopic(t *testing.T) {
 				ReplicationFactor: controllertesting.ReplicationFactor,
 				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
 			},
-			MockErrorCode: sarama.ErrBrokerNotAvailable,
-			WantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
+			MockErrorCode:   sarama.ErrBrokerNotAvailable,
+			WantError:       sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
+			WantTopicStatus: corev1.ConditionFalse,
+			WantTopicReason: "TopicFailed",
+		},
+		{
+			Name: "Error Creating Topic With Invalid Replication Factor",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			},
+			MockErrorCode:   sarama.ErrInvalidReplicationFactor,
+			WantError:       sarama.ErrInvalidReplicationFactor.Error() + " - " + controllertesting.ErrorString,
+			WantTopicStatus: corev1.ConditionFalse,
+			WantTopicReason: "InvalidReplicationFactor",
 		},
 		{
 			Name: "Delete Existing Topic",
@@ -174,6 +380,60 @@ func TestReconcileTopic(t *testing.T) {
 			MockErrorCode: sarama.ErrBrokerNotAvailable,
 			WantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
 		},
+		{
+			Name: "Retain Topic When Deletion Disabled",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+			),
+			ConfigOptions: []controllertesting.KafkaConfigOption{controllertesting.WithTopicDeletionDisabled},
+			WantRetain:    true,
+		},
+		{
+			Name: "Delete Topic When Deletion Disabled But Channel Annotated",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithDeleteTopicOnFinalize("true"),
+			),
+			ConfigOptions: []controllertesting.KafkaConfigOption{controllertesting.WithTopicDeletionDisabled},
+			WantDelete:    true,
+		},
+		{
+			Name: "Retain Topic When Channel Annotated",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithDeleteTopicOnFinalize("false"),
+			),
+			WantRetain: true,
+		},
+		{
+			Name: "Delete Topic When Channel Annotation Invalid",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithDeleteTopicOnFinalize("maybe"),
+			),
+			WantDelete: true,
+		},
+		{
+			Name: "Retain Topic Used By Recreated Channel",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithDeleteTopicOnFinalize("true"),
+			),
+			OtherChannels: []*kafkav1beta1.KafkaChannel{
+				controllertesting.NewKafkaChannel(controllertesting.WithUID("other-uid")),
+			},
+			WantRetain: true,
+		},
 	}
 
 	// Run All The TopicTestCases
@@ -193,10 +453,19 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 		// Create A Mock Kafka AdminClient For Current TopicTestCase
 		mockAdminClient := createMockAdminClientForTestCase(t, tc)
 
+		// Create A KafkaChannel Lister Containing The TopicTestCase's Channels
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, channel := range append([]*kafkav1beta1.KafkaChannel{tc.Channel}, tc.OtherChannels...) {
+			if err := indexer.Add(channel); err != nil {
+				t.Fatalf("failed to add KafkaChannel to indexer: %v", err)
+			}
+		}
+
 		// Initialize The Reconciler For The Current TopicTestCase
 		r := &Reconciler{
-			adminClient: mockAdminClient,
-			config:      controllertesting.NewConfig(),
+			adminClient:        mockAdminClient,
+			config:             controllertesting.NewConfig(tc.ConfigOptions...),
+			kafkachannelLister: kafkalisters.NewKafkaChannelLister(indexer),
 		}
 
 		// Track Any Error Responses
@@ -208,6 +477,24 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 			if !mockAdminClient.CreateTopicsCalled() {
 				t.Errorf("expected CreateTopics() called to be %t", tc.WantCreate)
 			}
+			if mockAdminClient.CreatePartitionsCalled() != tc.WantCreatePartitions {
+				t.Errorf("expected CreatePartitions() called to be %t", tc.WantCreatePartitions)
+			}
+			if mockAdminClient.DescribeTopicCalled() != (tc.MockErrorCode == sarama.ErrTopicAlreadyExists) {
+				t.Errorf("expected DescribeTopic() called to be %t", tc.MockErrorCode == sarama.ErrTopicAlreadyExists)
+			}
+			partitionsCondition := tc.Channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicPartitionsReady)
+			if tc.WantPartitionsStatus == "" && partitionsCondition != nil {
+				t.Errorf("unexpected TopicPartitionsReady condition %+v", partitionsCondition)
+			} else if tc.WantPartitionsStatus != "" && (partitionsCondition == nil || partitionsCondition.Status != tc.WantPartitionsStatus) {
+				t.Errorf("expected TopicPartitionsReady condition status %s, got %+v", tc.WantPartitionsStatus, partitionsCondition)
+			}
+			if tc.WantTopicStatus != "" {
+				topicCondition := tc.Channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady)
+				if topicCondition == nil || topicCondition.Status != tc.WantTopicStatus || topicCondition.Reason != tc.WantTopicReason {
+					t.Errorf("expected TopicReady condition status %s with reason %q, got %+v", tc.WantTopicStatus, tc.WantTopicReason, topicCondition)
+				}
+			}
 		}
 
 		// Perform The Test (Delete) - Called By Knative FinalizeKind() Directly
@@ -218,6 +505,14 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 			}
 		}
 
+		// Perform The Test (Retain) - Finalization Must Not Delete The Topic
+		if tc.WantRetain {
+			err = r.finalizeKafkaTopic(ctx, tc.Channel)
+			if mockAdminClient.DeleteTopicsCalled() {
+				t.Error("expected DeleteTopics() not to be called")
+			}
+		}
+
 		// Validate TestCase Expected Error State
 		var errorString string
 		if err != nil {
@@ -260,6 +555,41 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 			return topicError
 		},
 
+		// Mock CreatePartitions Behavior - Validate Parameters & Return MockError
+		MockCreatePartitionsFunc: func(ctx context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+			if !tc.WantCreatePartitions {
+				t.Error("Unexpected CreatePartitions() Call")
+			}
+			if topicName != controllertesting.TopicName {
+				t.Errorf("unexpected topic name '%s'", topicName)
+			}
+			if numPartitions != controllertesting.NumPartitions {
+				t.Errorf("unexpected number of partitions '%d'", numPartitions)
+			}
+			errMsg := controllertesting.SuccessString
+			if tc.MockPartitionsCode != sarama.ErrNoError {
+				errMsg = controllertesting.ErrorString
+			}
+			return &sarama.TopicError{
+				Err:    tc.MockPartitionsCode,
+				ErrMsg: &errMsg,
+			}
+		},
+
+		// Mock DescribeTopic Behavior - Validate Parameters & Return The Live Partitions Or MockDescribeError
+		MockDescribeTopicFunc: func(ctx context.Context, topicName string) (*sarama.TopicMetadata, error) {
+			if topicName != controllertesting.TopicName {
+				t.Errorf("unexpected topic name '%s'", topicName)
+			}
+			if tc.MockDescribeError != nil {
+				return nil, tc.MockDescribeError
+			}
+			return &sarama.TopicMetadata{
+				Name:       topicName,
+				Partitions: make([]*sarama.PartitionMetadata, tc.MockLivePartitions),
+			}, nil
+		},
+
 		// Mock DeleteTopic Behavior - Validate Parameters & Return MockError
 		MockDeleteTopicFunc: func(ctx context.Context, topicName string) *sarama.TopicError {
 			if !tc.WantDelete {
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/utils/pointer"
@@ -89,6 +90,10 @@ const (
 	NumPartitions = 123
 	// ReplicationFactor - ChannelSpec Test Data
 	ReplicationFactor = 456
+	// RetentionDuration - ChannelSpec Test Data
+	RetentionDuration = "PT1H"
+	// MinInSyncReplicas - ChannelSpec Test Data
+	MinInSyncReplicas = 2
 
 	// ErrorString - Mock Test Error MetaData
 	ErrorString = "Expected Mock Test Error"
@@ -166,6 +171,9 @@ Producer:
 
 var (
 	DefaultRetentionMillisString = strconv.FormatInt(DefaultRetentionMillis, 10)
+	RetentionMillisString        = strconv.FormatInt(time.Hour.Milliseconds(), 10) // Matches RetentionDuration
+	MinInSyncReplicasString      = strconv.Itoa(MinInSyncReplicas)
+	SegmentMillisString          = "3600000"
 	DeletionTimestamp            = metav1.Now()
 )
 
@@ -456,6 +464,16 @@ func WithNoDispatcherResources(kafkaConfig *commonconfig.EventingKafkaConfig) {
 	kafkaConfig.Channel.Dispatcher.EKKubernetesConfig.MemoryRequest = resource.Quantity{}
 }
 
+// WithBrokerDefaultRetention Zeroes The Default RetentionMillis So That The Broker's Default Applies
+func WithBrokerDefaultRetention(kafkaConfig *commonconfig.EventingKafkaConfig) {
+	kafkaConfig.Kafka.Topic.DefaultRetentionMillis = 0
+}
+
+// WithTopicDeletionDisabled Disables Deletion Of Kafka Topics When KafkaChannels Are Finalized
+func WithTopicDeletionDisabled(kafkaConfig *commonconfig.EventingKafkaConfig) {
+	kafkaConfig.Channel.DisableTopicDeletion = true
+}
+
 //
 // Kafka Secret Resources
 //
@@ -521,7 +539,7 @@ func NewKafkaChannel(options ...KafkaChannelOption) *kafkav1beta1.KafkaChannel {
 		Spec: kafkav1beta1.KafkaChannelSpec{
 			NumPartitions:     NumPartitions,
 			ReplicationFactor: ReplicationFactor,
-			// TODO RetentionMillis:   RetentionMillis,
+			DeliveryGuarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce,
 		},
 	}
 
@@ -550,6 +568,21 @@ func WithEmptySpec(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Spec = kafkav1beta1.KafkaChannelSpec{}
 }
 
+// WithRetentionDuration Sets The KafkaChannel's RetentionDuration
+func WithRetentionDuration(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.RetentionDuration = RetentionDuration
+}
+
+// WithMinInSyncReplicas Sets The KafkaChannel's MinInSyncReplicas
+func WithMinInSyncReplicas(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.MinInSyncReplicas = MinInSyncReplicas
+}
+
+// WithTopicConfig Sets The KafkaChannel's Additional TopicConfig
+func WithTopicConfig(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.TopicConfig = map[string]string{"segment.ms": SegmentMillisString}
+}
+
 // WithDeletionTimestamp Sets The KafkaChannel's DeletionTimestamp To Current Time
 func WithDeletionTimestamp(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.ObjectMeta.SetDeletionTimestamp(&DeletionTimestamp)
@@ -560,6 +593,23 @@ func WithFinalizer(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.ObjectMeta.Finalizers = []string{constants.KafkaChannelFinalizerSuffix}
 }
 
+// WithUID Sets The KafkaChannel's UID
+func WithUID(uid types.UID) KafkaChannelOption {
+	return func(kafkachannel *kafkav1beta1.KafkaChannel) {
+		kafkachannel.ObjectMeta.UID = uid
+	}
+}
+
+// WithDeleteTopicOnFinalize Sets The KafkaChannel's Topic Deletion Annotation To The Specified Value
+func WithDeleteTopicOnFinalize(value string) KafkaChannelOption {
+	return func(kafkachannel *kafkav1beta1.KafkaChannel) {
+		if kafkachannel.ObjectMeta.Annotations == nil {
+			kafkachannel.ObjectMeta.Annotations = make(map[string]string)
+		}
+		kafkachannel.ObjectMeta.Annotations[constants.DeleteTopicOnFinalizeAnnotation] = value
+	}
+}
+
 // WithMetaData Sets The KafkaChannel's MetaData
 func WithMetaData(kafkachannel *kafkav1beta1.KafkaChannel) {
 	WithAnnotations(kafkachannel)
@@ -1192,6 +1242,11 @@ func NewFinalizerPatchActionImpl() clientgotesting.PatchActionImpl {
 	}
 }
 
+// NewKafkaChannelReceiverDeploymentUpdatedEvent Creates A Receiver Deployment Updated Event
+func NewKafkaChannelReceiverDeploymentUpdatedEvent() string {
+	return reconcilertesting.Eventf(corev1.EventTypeNormal, event.ReceiverDeploymentUpdated.String(), "Receiver Deployment Updated")
+}
+
 // NewKafkaChannelDispatcherDeploymentUpdatedEvent Creates A Dispatcher Deployment Updated Event
 func NewKafkaChannelDispatcherDeploymentUpdatedEvent() string {
 	return reconcilertesting.Eventf(corev1.EventTypeNormal, event.DispatcherDeploymentUpdated.String(), "Dispatcher Deployment Updated")
@@ -32,12 +32,18 @@ var _ types.AdminClientInterface = &MockAdminClient{}
 
 // Mock Kafka AdminClient Implementation
 type MockAdminClient struct {
-	closeCalled         bool
-	createTopicsCalled  bool
-	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
-	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
-	MockCloseFunc       func() error
+	closeCalled              bool
+	createTopicsCalled       bool
+	deleteTopicsCalled       bool
+	createPartitionsCalled   bool
+	listTopicsCalled         bool
+	describeTopicCalled      bool
+	MockCreateTopicFunc      func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockDeleteTopicFunc      func(context.Context, string) *sarama.TopicError
+	MockCreatePartitionsFunc func(context.Context, string, int32) *sarama.TopicError
+	MockListTopicsFunc       func(context.Context) (map[string]sarama.TopicDetail, error)
+	MockDescribeTopicFunc    func(context.Context, string) (*sarama.TopicMetadata, error)
+	MockCloseFunc            func() error
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
@@ -70,6 +76,49 @@ func (m *MockAdminClient) DeleteTopicsCalled() bool {
 	return m.deleteTopicsCalled
 }
 
+// Mock Kafka AdminClient CreatePartitions() Function - Calls Custom CreatePartitions() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) CreatePartitions(ctx context.Context, topicName string, numPartitions int32) *sarama.TopicError {
+	m.createPartitionsCalled = true
+	if m.MockCreatePartitionsFunc != nil {
+		return m.MockCreatePartitionsFunc(ctx, topicName, numPartitions)
+	}
+	errMsg := "mock CreatePartitions() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To CreatePartitions()
+func (m *MockAdminClient) CreatePartitionsCalled() bool {
+	return m.createPartitionsCalled
+}
+
+// Mock Kafka AdminClient ListTopics() Function - Calls Custom ListTopics() If Specified, Otherwise Returns No Topics
+func (m *MockAdminClient) ListTopics(ctx context.Context) (map[string]sarama.TopicDetail, error) {
+	m.listTopicsCalled = true
+	if m.MockListTopicsFunc != nil {
+		return m.MockListTopicsFunc(ctx)
+	}
+	return map[string]sarama.TopicDetail{}, nil
+}
+
+// Check On Calls To ListTopics()
+func (m *MockAdminClient) ListTopicsCalled() bool {
+	return m.listTopicsCalled
+}
+
+// Mock Kafka AdminClient DescribeTopic() Function - Calls Custom DescribeTopic() If Specified, Otherwise Returns Empty Metadata
+func (m *MockAdminClient) DescribeTopic(ctx context.Context, topicName string) (*sarama.TopicMetadata, error) {
+	m.describeTopicCalled = true
+	if m.MockDescribeTopicFunc != nil {
+		return m.MockDescribeTopicFunc(ctx, topicName)
+	}
+	return &sarama.TopicMetadata{Name: topicName}, nil
+}
+
+// Check On Calls To DescribeTopic()
+func (m *MockAdminClient) DescribeTopicCalled() bool {
+	return m.describeTopicCalled
+}
+
 // Mock Kafka AdminClient Close Function - NoOp
 func (m *MockAdminClient) Close() error {
 	m.closeCalled = true
@@ -26,4 +26,11 @@ const (
 
 	// GroupStoppedMessage is the message that will be in a subscriber's status when a group is stopped ("paused")
 	GroupStoppedMessage = "consumer group is stopped"
+
+	// ActiveConsumerGroupsGauge is the name of the gauge reporting the number of ConsumerGroups the dispatcher is running
+	ActiveConsumerGroupsGauge = "active_consumer_groups"
+
+	// Tag keys of the ActiveConsumerGroupsGauge
+	ChannelTagKey = "channel"
+	PodTagKey     = "pod"
 )
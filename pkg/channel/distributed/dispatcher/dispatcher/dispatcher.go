@@ -45,10 +45,12 @@ type DispatcherConfig struct {
 	Brokers         []string
 	Topic           string
 	ChannelKey      string
+	PodName         string
 	StatsReporter   metrics.StatsReporter
 	MetricsRegistry gometrics.Registry
 	SaramaConfig    *sarama.Config
 	SubscriberSpecs []eventingduck.SubscriberSpec
+	Concurrency     int // Maximum Number Of Messages Handled At Once Across All Subscriptions (Zero Is Unbounded)
 }
 
 // SubscriberWrapper Defines A Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup ID
@@ -78,6 +80,7 @@ type DispatcherImpl struct {
 	MetricsStopChan    chan struct{}
 	MetricsStoppedChan chan struct{}
 	consumerMgr        commonconsumer.KafkaConsumerGroupManager
+	semaphore          chan struct{} // Shared By The Handlers Of All Subscriptions (Nil If Unbounded)
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -98,6 +101,11 @@ func NewDispatcher(dispatcherConfig DispatcherConfig, controlServer controlproto
 		consumerMgr:        consumerGroupManager,
 	}
 
+	// Create The Semaphore Bounding The Number Of Messages Handled At Once (Zero Leaves It Unbounded)
+	if dispatcherConfig.Concurrency > 0 {
+		dispatcher.semaphore = make(chan struct{}, dispatcherConfig.Concurrency)
+	}
+
 	// Start Observing Metrics
 	dispatcher.ObserveMetrics(dispatcherconstants.MetricsInterval)
 
@@ -123,6 +131,12 @@ func (d *DispatcherImpl) Shutdown() {
 	d.consumerMgr.ClearNotifications()
 }
 
+// ShutdownOnDone Blocks Until The (Signal) Context Is Done And Then Shuts Down The Dispatcher
+func ShutdownOnDone(ctx context.Context, dispatcher Dispatcher) {
+	<-ctx.Done()
+	dispatcher.Shutdown()
+}
+
 // UpdateSubscriptions manages the Dispatcher's Subscriptions to align with new state
 func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap {
 
@@ -152,6 +166,7 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 
 			// Create/Start A New ConsumerGroup With Custom Handler
 			handler := NewHandler(logger, groupId, &subscriberSpec)
+			handler.Semaphore = d.semaphore
 			err := d.consumerMgr.StartConsumerGroup(groupId, []string{d.Topic}, d.Logger.Sugar(), handler)
 			if err != nil {
 
@@ -204,10 +219,24 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 		}
 	}
 
+	// Report The Number Of ConsumerGroups Remaining After The Additions/Removals
+	d.reportActiveConsumerGroups()
+
 	// Return Any Failed Subscriber Errors
 	return subscriptions
 }
 
+// reportActiveConsumerGroups reports the number of tracked ConsumerGroups, tagged with the channel and pod
+func (d *DispatcherImpl) reportActiveConsumerGroups() {
+	if d.StatsReporter != nil {
+		tags := map[string]string{
+			dispatcherconstants.ChannelTagKey: d.ChannelKey,
+			dispatcherconstants.PodTagKey:     d.PodName,
+		}
+		d.StatsReporter.ReportGauge(dispatcherconstants.ActiveConsumerGroupsGauge, float64(len(d.subscribers)), tags)
+	}
+}
+
 // closeConsumerGroup closes the ConsumerGroup associated with a single Subscriber
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 
@@ -247,6 +276,11 @@ func (d *DispatcherImpl) SecretChanged(ctx context.Context, secret *corev1.Secre
 		return
 	}
 
+	// A TLS-Only Secret Has No SASL Credentials, Which Is The Same As An Empty Username
+	if kafkaAuthCfg.SASL == nil {
+		kafkaAuthCfg.SASL = &client.KafkaSaslConfig{SaslType: sarama.SASLTypePlaintext}
+	}
+
 	// Don't Restart Dispatcher If All Auth Settings Identical
 	if kafkaAuthCfg.SASL.HasSameSettings(d.SaramaConfig) {
 		d.Logger.Info("No relevant changes in Secret; ignoring update")
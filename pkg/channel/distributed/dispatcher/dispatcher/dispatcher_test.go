@@ -32,6 +32,7 @@ import (
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	dispatcherconstants "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	commonclient "knative.dev/eventing-kafka/pkg/common/client"
 	clienttesting "knative.dev/eventing-kafka/pkg/common/client/testing"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
@@ -78,7 +79,39 @@ func TestNewDispatcher(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Perform The Test & Verify Results (Not Much To See Due To Interface)
-	createTestDispatcher(t, nil, baseSaramaConfig)
+	dispatcher := createTestDispatcher(t, nil, baseSaramaConfig, 0)
+	assert.Nil(t, dispatcher.(*DispatcherImpl).semaphore) // Unbounded By Default
+
+	// A Positive Concurrency Sizes The Semaphore
+	dispatcher = createTestDispatcher(t, nil, baseSaramaConfig, 10)
+	assert.Equal(t, 10, cap(dispatcher.(*DispatcherImpl).semaphore))
+}
+
+// Test The Dispatcher's Semaphore Is Sized By The Concurrency And Shared With The Subscription Handlers
+func TestDispatcherSemaphore(t *testing.T) {
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logtesting.TestLogger(t).Desugar(),
+			SaramaConfig: sarama.NewConfig(),
+			Concurrency:  5,
+		},
+		subscribers: make(map[types.UID]*SubscriberWrapper),
+		consumerMgr: mockManager,
+		semaphore:   make(chan struct{}, 5),
+	}
+
+	var startedHandler *Handler
+	mockManager.On("StartConsumerGroup", "kafka."+string(id123), mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { startedHandler = args.Get(3).(*Handler) }).
+		Return(nil)
+	mockManager.On("Errors", mock.Anything).Return(make(<-chan error))
+
+	dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: id123}})
+
+	assert.NotNil(t, startedHandler)
+	assert.Equal(t, dispatcher.semaphore, startedHandler.Semaphore)
+	assert.Equal(t, 5, cap(startedHandler.Semaphore))
 }
 
 // Test The Dispatcher's Shutdown() Functionality
@@ -146,6 +179,68 @@ func TestShutdown(t *testing.T) {
 	mockManager.AssertExpectations(t)
 }
 
+// Test The ShutdownOnDone() Functionality
+func TestShutdownOnDone(t *testing.T) {
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	consumerGroup1 := kafkatesting.NewMockConsumerGroup()
+	consumerGroup2 := kafkatesting.NewMockConsumerGroup()
+
+	subscriber1 := eventingduck.SubscriberSpec{UID: id123}
+	subscriber2 := eventingduck.SubscriberSpec{UID: id456}
+	groupId1 := fmt.Sprintf("kafka.%s", subscriber1.UID)
+	groupId2 := fmt.Sprintf("kafka.%s", subscriber2.UID)
+	mockManager.Groups[groupId1] = consumerGroup1
+	mockManager.Groups[groupId2] = consumerGroup2
+
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger: logtesting.TestLogger(t).Desugar(),
+		},
+		consumerMgr: mockManager,
+		subscribers: map[types.UID]*SubscriberWrapper{
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, groupId1),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, groupId2),
+		},
+	}
+
+	consumerGroup1.On("Close").Return(nil)
+	consumerGroup2.On("Close").Return(nil)
+	mockManager.On("ClearNotifications").Return()
+	mockManager.On("IsManaged", groupId1).Return(true)
+	mockManager.On("IsManaged", groupId2).Return(true)
+	mockManager.On("CloseConsumerGroup", groupId1).Return(nil)
+	mockManager.On("CloseConsumerGroup", groupId2).Return(nil)
+
+	// Stand In For The signals.NewContext() Which Is Cancelled On SIGTERM
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ShutdownOnDone(ctx, dispatcher)
+		close(done)
+	}()
+
+	// Nothing Should Be Closed Before The Signal Arrives
+	select {
+	case <-done:
+		t.Fatal("ShutdownOnDone() returned before the context was cancelled")
+	case <-time.After(100 * time.Millisecond):
+	}
+	mockManager.AssertNotCalled(t, "CloseConsumerGroup", groupId1)
+	mockManager.AssertNotCalled(t, "CloseConsumerGroup", groupId2)
+
+	// Simulate The Signal And Verify Every ConsumerGroup Is Closed
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ShutdownOnDone() did not return after the context was cancelled")
+	}
+	consumerGroup1.AssertExpectations(t)
+	consumerGroup2.AssertExpectations(t)
+	mockManager.AssertExpectations(t)
+	assert.Empty(t, dispatcher.subscribers)
+}
+
 // Test The UpdateSubscriptions() Functionality
 func TestUpdateSubscriptions(t *testing.T) {
 
@@ -419,6 +514,54 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test That Adding & Removing Subscriptions Updates The Active ConsumerGroups Gauge
+func TestUpdateSubscriptionsActiveConsumerGroupsGauge(t *testing.T) {
+
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+	config, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).Build(ctx)
+	assert.Nil(t, err)
+
+	// Create A DispatcherImpl With A Mock StatsReporter & ConsumerGroupManager
+	reporter := &statsReporterMock{}
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:        logger.Desugar(),
+			Brokers:       []string{configtesting.DefaultKafkaBroker},
+			ChannelKey:    "test-namespace/test-channel",
+			PodName:       "test-dispatcher-pod",
+			StatsReporter: reporter,
+			SaramaConfig:  config,
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{},
+		consumerMgr: mockManager,
+	}
+	errorSource := make(chan error)
+	defer close(errorSource)
+	for _, id := range []string{id123, id456} {
+		mockManager.On("StartConsumerGroup", "kafka."+id, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockManager.On("Errors", "kafka."+id).Return((<-chan error)(errorSource))
+		mockManager.On("IsManaged", "kafka."+id).Return(true)
+		mockManager.On("IsStopped", "kafka."+id).Return(false)
+		mockManager.On("CloseConsumerGroup", "kafka."+id).Return(nil)
+	}
+
+	// Add Two Subscriptions, Then Remove One, Then Remove The Other
+	dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}})
+	assert.Equal(t, float64(2), reporter.gauges[dispatcherconstants.ActiveConsumerGroupsGauge])
+	dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{{UID: uid456}})
+	assert.Equal(t, float64(1), reporter.gauges[dispatcherconstants.ActiveConsumerGroupsGauge])
+	dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{})
+	assert.Equal(t, float64(0), reporter.gauges[dispatcherconstants.ActiveConsumerGroupsGauge])
+
+	// Verify The Gauge Is Tagged With The Channel & Pod
+	assert.Equal(t, map[string]string{
+		dispatcherconstants.ChannelTagKey: "test-namespace/test-channel",
+		dispatcherconstants.PodTagKey:     "test-dispatcher-pod",
+	}, reporter.gaugeTags[dispatcherconstants.ActiveConsumerGroupsGauge])
+}
+
 // Test The Dispatcher's SecretChanged Functionality
 func TestSecretChanged(t *testing.T) {
 
@@ -477,6 +620,11 @@ func TestSecretChanged(t *testing.T) {
 			newSecret:           configtesting.NewKafkaSecret(configtesting.WithEmptyUsername),
 			expectEmptyUsername: true,
 		},
+		{
+			name:                "TLS-Only Change (Modifications)",
+			newSecret:           configtesting.NewKafkaSecret(configtesting.WithTlsOnly),
+			expectEmptyUsername: true,
+		},
 		{
 			name:              "SaslType Change (Modifications)",
 			newSecret:         configtesting.NewKafkaSecret(configtesting.WithModifiedSaslType),
@@ -504,7 +652,7 @@ func TestSecretChanged(t *testing.T) {
 
 			mockManager := consumertesting.NewMockConsumerGroupManager()
 			// Create A Test Dispatcher To Perform Tests Against
-			dispatcher := createTestDispatcher(t, brokers, baseSaramaConfig)
+			dispatcher := createTestDispatcher(t, brokers, baseSaramaConfig, 0)
 			impl := dispatcher.(*DispatcherImpl)
 			impl.subscribers = map[types.UID]*SubscriberWrapper{uid123: createSubscriberWrapper(uid123)}
 			if testCase.reconfigureErr {
@@ -542,7 +690,7 @@ func createSubscriberWrapper(uid types.UID) *SubscriberWrapper {
 }
 
 // Utility Function For Creating A Dispatcher With Specified Configuration
-func createTestDispatcher(t *testing.T, brokers []string, config *sarama.Config) Dispatcher {
+func createTestDispatcher(t *testing.T, brokers []string, config *sarama.Config, concurrency int) Dispatcher {
 
 	// Create A Test Logger
 	logger := logtesting.TestLogger(t).Desugar()
@@ -561,6 +709,7 @@ func createTestDispatcher(t *testing.T, brokers []string, config *sarama.Config)
 		MetricsRegistry: config.MetricRegistry,
 		SaramaConfig:    config,
 		SubscriberSpecs: subscriberSpecs,
+		Concurrency:     concurrency,
 	}
 
 	serverHandler := controltesting.GetMockServerHandler()
@@ -611,6 +760,8 @@ func TestConfigImpl_ObserveMetrics(t *testing.T) {
 // A mock for the StatsReporter that will provide feedback when the Report function is called
 type statsReporterMock struct {
 	reportCalled bool
+	gauges       map[string]float64
+	gaugeTags    map[string]map[string]string
 	mutex        sync.Mutex // Prevent race conditions between writing the value and assert.Eventually reading it
 }
 
@@ -626,6 +777,18 @@ func (s *statsReporterMock) Report(_ metrics.ReportingList) {
 	s.reportCalled = true
 }
 
+// ReportGauge records the latest value and tags reported for each gauge
+func (s *statsReporterMock) ReportGauge(name string, value float64, tags map[string]string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.gauges == nil {
+		s.gauges = make(map[string]float64)
+		s.gaugeTags = make(map[string]map[string]string)
+	}
+	s.gauges[name] = value
+	s.gaugeTags[name] = tags
+}
+
 // Shutdown is required to implement the StatsReporter interface
 func (s *statsReporterMock) Shutdown() {
 }
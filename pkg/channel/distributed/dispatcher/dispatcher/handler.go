@@ -49,6 +49,7 @@ type Handler struct {
 	replyURL          *url.URL
 	deadLetterURL     *url.URL
 	retryConfig       kncloudevents.RetryConfig
+	Semaphore         chan struct{} // Optional Semaphore Bounding Concurrent Handling (Nil Is Unbounded)
 }
 
 // NewHandler creates a new Handler instance.
@@ -129,6 +130,16 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 		return true, errors.New("received a message with unknown encoding - skipping") // Mark As Handled Since Retry Won't Fix Anything : )
 	}
 
+	// Wait For A Slot In The Dispatcher's Semaphore (If Bounded) Before Dispatching (Limits Concurrency, Adds No Workers)
+	if h.Semaphore != nil {
+		select {
+		case h.Semaphore <- struct{}{}:
+			defer func() { <-h.Semaphore }()
+		case <-ctx.Done():
+			return false, nil // Not Dispatched, So Don't Mark The Message (Will Be Redelivered)
+		}
+	}
+
 	// Start Tracing
 	ctx, span := tracing.StartTraceFromMessage(h.Logger.Sugar(), ctx, message, consumerMessage.Topic)
 	defer span.End()
@@ -81,6 +81,7 @@ type HandleTestCase struct {
 	deadLetterUri     *apis.URL
 	dispatchErr       error
 	retry             bool
+	semaphore         bool
 	expectMarkMessage bool
 }
 
@@ -131,6 +132,15 @@ func TestHandle(t *testing.T) {
 			retry:             false,
 			expectMarkMessage: true,
 		},
+		{
+			name:              "Bounded Semaphore",
+			destinationUri:    testSubscriberURI,
+			replyUri:          testReplyURI,
+			deadLetterUri:     testDeadLetterURI,
+			retry:             true,
+			semaphore:         true,
+			expectMarkMessage: true,
+		},
 		{
 			name:              "Context Canceled",
 			destinationUri:    testSubscriberURI,
@@ -161,6 +171,34 @@ func TestHandle(t *testing.T) {
 	}
 }
 
+// Test The Handler's Handle() Functionality When The Semaphore Is Exhausted
+func TestHandleSemaphoreExhausted(t *testing.T) {
+
+	// Create Mocks For Testing (No Dispatch Expected)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, nil, nil, nil, nil, nil)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create A Handler Whose Single Semaphore Slot Is Already In Use
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	handler.Semaphore = make(chan struct{}, 1)
+	handler.Semaphore <- struct{}{}
+
+	// Perform The Test With A Context That Is Canceled While Waiting For A Slot
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	result, err := handler.Handle(ctx, createConsumerMessage(t))
+
+	// Verify The Message Was Neither Dispatched Nor Marked
+	assert.Nil(t, err)
+	assert.False(t, result)
+	assert.Nil(t, mockMessageDispatcher.Message())
+	assert.Len(t, handler.Semaphore, 1)
+}
+
 func TestSetReady(t *testing.T) {
 	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
 	handler.SetReady(1, true)
@@ -217,6 +255,9 @@ func performHandleTest(t *testing.T, testCase HandleTestCase) {
 
 	// Create The Handler To Test
 	handler := createTestHandler(t, testCase.destinationUri, testCase.replyUri, &deliverySpec)
+	if testCase.semaphore {
+		handler.Semaphore = make(chan struct{}, 1)
+	}
 
 	// Perform The Test
 	consumerMessage := createConsumerMessage(t)
@@ -225,6 +266,9 @@ func performHandleTest(t *testing.T, testCase HandleTestCase) {
 	// Verify The Results
 	assert.Nil(t, err)
 	assert.Equal(t, testCase.expectMarkMessage, result)
+	if testCase.semaphore {
+		assert.Len(t, handler.Semaphore, 0) // The Semaphore Slot Was Released
+	}
 	assert.NotNil(t, mockMessageDispatcher.Message())
 	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
 }
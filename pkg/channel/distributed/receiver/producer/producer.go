@@ -184,6 +184,11 @@ func (p *Producer) SecretChanged(ctx context.Context, secret *corev1.Secret) *Pr
 		return nil
 	}
 
+	// A TLS-Only Secret Has No SASL Credentials, Which Is The Same As An Empty Username
+	if kafkaAuthCfg.SASL == nil {
+		kafkaAuthCfg.SASL = &client.KafkaSaslConfig{SaslType: sarama.SASLTypePlaintext}
+	}
+
 	// Don't Restart Producer If All Auth Settings Identical.
 	if kafkaAuthCfg.SASL.HasSameSettings(p.configuration) {
 		p.logger.Info("No relevant changes in Secret; ignoring update")
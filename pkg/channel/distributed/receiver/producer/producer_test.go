@@ -151,6 +151,12 @@ func TestSecretChanged(t *testing.T) {
 			expectNewProducer: true,
 			expectEmptyAuth:   true,
 		},
+		{
+			name:              "TLS-Only Change (New Producer)",
+			newSecret:         configtesting.NewKafkaSecret(configtesting.WithTlsOnly),
+			expectNewProducer: true,
+			expectEmptyAuth:   true,
+		},
 		{
 			name:              "SaslType Change (New Producer)",
 			newSecret:         configtesting.NewKafkaSecret(configtesting.WithModifiedSaslType),
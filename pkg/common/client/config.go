@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -47,6 +48,12 @@ type KafkaSaslConfig struct {
 	User     string
 	Password string
 	SaslType string
+
+	// Token is a static OAUTHBEARER access token, used when no TokenProvider is specified
+	Token string
+
+	// TokenProvider supplies the access tokens for the OAUTHBEARER SaslType
+	TokenProvider sarama.AccessTokenProvider
 }
 
 // HasSameSettings returns true if all of the SASL settings in the provided config are the same as in this struct
@@ -84,6 +91,18 @@ func HasSameBrokers(brokerString string, brokers []string) bool {
 	return true
 }
 
+// ComposeClientId joins the non-empty prefix, clientId and suffix with dashes, so that
+// operators can tell apart the clients of different clusters or tenants on the brokers
+func ComposeClientId(prefix string, clientId string, suffix string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{prefix, clientId, suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
 // ConfigBuilder builds the Sarama config using multiple options.
 // Precedence for the options is following:
 // - get existing config if provided, create a new one otherwise
@@ -118,6 +137,28 @@ type ConfigBuilder interface {
 	// (if provided) or in the YAML-string
 	WithClientId(clientId string) ConfigBuilder
 
+	// WithNetLimits makes the builder set the per-broker
+	// Net.MaxOpenRequests, Net.DialTimeout and Net.KeepAlive
+	// explicitly (zero values leave the existing settings untouched)
+	WithNetLimits(maxOpenRequests int, dialTimeout time.Duration, keepAlive time.Duration) ConfigBuilder
+
+	// WithRebalanceStrategy makes the builder set the consumer
+	// group's Consumer.Group.Rebalance.Strategy by name (one of
+	// RebalanceStrategyNames, an empty name leaves it untouched)
+	WithRebalanceStrategy(strategy string) ConfigBuilder
+
+	// WithGroupTimeouts makes the builder set the consumer
+	// group's Consumer.Group.Session.Timeout and
+	// Consumer.Group.Heartbeat.Interval explicitly (zero values
+	// leave the existing settings untouched), failing if the
+	// resulting heartbeat is not below a third of the session
+	WithGroupTimeouts(sessionTimeout time.Duration, heartbeatInterval time.Duration) ConfigBuilder
+
+	// WithMinimumVersion makes the builder fail if the
+	// resulting config has a Kafka version lower than
+	// the given one
+	WithMinimumVersion(version *sarama.KafkaVersion) ConfigBuilder
+
 	// Build builds the Sarama config with the given context.
 	// Context is used for getting the config at the moment.
 	Build(ctx context.Context) (*sarama.Config, error)
@@ -128,12 +169,46 @@ func NewConfigBuilder() ConfigBuilder {
 }
 
 type configBuilder struct {
-	existing *sarama.Config
-	defaults bool
-	version  *sarama.KafkaVersion
-	clientId string
-	yaml     string
-	auth     *KafkaAuthConfig
+	existing   *sarama.Config
+	defaults   bool
+	version    *sarama.KafkaVersion
+	minVersion *sarama.KafkaVersion
+	clientId   string
+	yaml       string
+	auth       *KafkaAuthConfig
+
+	maxOpenRequests int
+	dialTimeout     time.Duration
+	keepAlive       time.Duration
+
+	rebalanceStrategy string
+
+	sessionTimeout    time.Duration
+	heartbeatInterval time.Duration
+}
+
+// rebalanceStrategies maps the supported consumer group rebalance strategy names to the Sarama strategies
+var rebalanceStrategies = map[string]sarama.BalanceStrategy{
+	sarama.RangeBalanceStrategyName:      sarama.BalanceStrategyRange,
+	sarama.RoundRobinBalanceStrategyName: sarama.BalanceStrategyRoundRobin,
+	sarama.StickyBalanceStrategyName:     sarama.BalanceStrategySticky,
+}
+
+// RebalanceStrategyNames returns the sorted names of the supported consumer group rebalance strategies
+func RebalanceStrategyNames() []string {
+	names := make([]string, 0, len(rebalanceStrategies))
+	for name := range rebalanceStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsValidRebalanceStrategy returns whether the given name is empty (use the existing strategy)
+// or one of the supported consumer group rebalance strategies
+func IsValidRebalanceStrategy(strategy string) bool {
+	_, ok := rebalanceStrategies[strategy]
+	return ok || strategy == ""
 }
 
 func (b *configBuilder) WithExisting(existing *sarama.Config) ConfigBuilder {
@@ -156,6 +231,29 @@ func (b *configBuilder) WithClientId(clientId string) ConfigBuilder {
 	return b
 }
 
+func (b *configBuilder) WithNetLimits(maxOpenRequests int, dialTimeout time.Duration, keepAlive time.Duration) ConfigBuilder {
+	b.maxOpenRequests = maxOpenRequests
+	b.dialTimeout = dialTimeout
+	b.keepAlive = keepAlive
+	return b
+}
+
+func (b *configBuilder) WithRebalanceStrategy(strategy string) ConfigBuilder {
+	b.rebalanceStrategy = strategy
+	return b
+}
+
+func (b *configBuilder) WithGroupTimeouts(sessionTimeout time.Duration, heartbeatInterval time.Duration) ConfigBuilder {
+	b.sessionTimeout = sessionTimeout
+	b.heartbeatInterval = heartbeatInterval
+	return b
+}
+
+func (b *configBuilder) WithMinimumVersion(version *sarama.KafkaVersion) ConfigBuilder {
+	b.minVersion = version
+	return b
+}
+
 func (b *configBuilder) FromYaml(saramaSettingsYamlString string) ConfigBuilder {
 	b.yaml = saramaSettingsYamlString
 	return b
@@ -227,11 +325,16 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 			config.Net.TLS.Enable = true
 
 			// if we have TLS, we might want to use the certs for self-signed CERTs
-			if b.auth.TLS.Cacert != "" {
+			// and/or the client cert & key for mutual TLS authentication
+			if b.auth.TLS.Cacert != "" || (b.auth.TLS.Usercert != "" && b.auth.TLS.Userkey != "") {
 				tlsConfig, err := newTLSConfig(b.auth.TLS.Usercert, b.auth.TLS.Userkey, b.auth.TLS.Cacert)
 				if err != nil {
 					return nil, fmt.Errorf("Error creating TLS config: %w", err)
 				}
+				// keep any RootCAs from the YAML if no CA cert was provided
+				if tlsConfig.RootCAs == nil && config.Net.TLS.Config != nil {
+					tlsConfig.RootCAs = config.Net.TLS.Config.RootCAs
+				}
 				config.Net.TLS.Config = tlsConfig
 			}
 		}
@@ -252,6 +355,10 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 				config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &XDGSCRAMClient{HashGeneratorFcn: SHA512} }
 				config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 			}
+
+			if b.auth.SASL.SaslType == sarama.SASLTypeOAuth {
+				config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			}
 			config.Net.SASL.User = b.auth.SASL.User
 		}
 	}
@@ -263,12 +370,58 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 	if b.clientId != "" {
 		config.ClientID = b.clientId
 	}
+	if b.maxOpenRequests < 0 || b.dialTimeout < 0 || b.keepAlive < 0 {
+		return nil, fmt.Errorf("invalid net limits: maxOpenRequests=%d, dialTimeout=%s, keepAlive=%s must not be negative", b.maxOpenRequests, b.dialTimeout, b.keepAlive)
+	}
+	if b.maxOpenRequests > 0 {
+		config.Net.MaxOpenRequests = b.maxOpenRequests
+	}
+	if b.dialTimeout > 0 {
+		config.Net.DialTimeout = b.dialTimeout
+	}
+	if b.keepAlive > 0 {
+		config.Net.KeepAlive = b.keepAlive
+	}
+	if b.rebalanceStrategy != "" {
+		strategy, ok := rebalanceStrategies[b.rebalanceStrategy]
+		if !ok {
+			return nil, fmt.Errorf("invalid rebalance strategy %q, expected one of %v", b.rebalanceStrategy, RebalanceStrategyNames())
+		}
+		config.Consumer.Group.Rebalance.Strategy = strategy
+	}
+	if b.sessionTimeout < 0 || b.heartbeatInterval < 0 {
+		return nil, fmt.Errorf("invalid group timeouts: sessionTimeout=%s, heartbeatInterval=%s must not be negative", b.sessionTimeout, b.heartbeatInterval)
+	}
+	if b.sessionTimeout > 0 {
+		config.Consumer.Group.Session.Timeout = b.sessionTimeout
+	}
+	if b.heartbeatInterval > 0 {
+		config.Consumer.Group.Heartbeat.Interval = b.heartbeatInterval
+	}
+	if (b.sessionTimeout > 0 || b.heartbeatInterval > 0) &&
+		config.Consumer.Group.Heartbeat.Interval*3 >= config.Consumer.Group.Session.Timeout {
+		return nil, fmt.Errorf("invalid group timeouts: heartbeatInterval=%s must be lower than a third of sessionTimeout=%s", config.Consumer.Group.Heartbeat.Interval, config.Consumer.Group.Session.Timeout)
+	}
+
+	// verify the resulting version is supported
+	if b.minVersion != nil && !config.Version.IsAtLeast(*b.minVersion) {
+		return nil, fmt.Errorf("configured Kafka version %s is lower than the minimum supported version %s", config.Version, *b.minVersion)
+	}
 
 	logger := logging.FromContext(ctx)
 	logger.Infof("Built Sarama config: %+v", config)
 
 	if b.auth != nil && b.auth.SASL != nil {
 		config.Net.SASL.Password = b.auth.SASL.Password
+
+		// the token provider is applied after logging so that static tokens are never logged
+		if b.auth.SASL.SaslType == sarama.SASLTypeOAuth {
+			if b.auth.SASL.TokenProvider != nil {
+				config.Net.SASL.TokenProvider = b.auth.SASL.TokenProvider
+			} else {
+				config.Net.SASL.TokenProvider = NewStaticTokenProvider(b.auth.SASL.Token)
+			}
+		}
 	}
 
 	return config, nil
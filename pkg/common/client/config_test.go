@@ -316,6 +316,220 @@ func TestBuildSaramaConfig(t *testing.T) {
 	assert.Equal(t, sarama.V2_0_0_0, config.Version)
 }
 
+func TestComposeClientId(t *testing.T) {
+	testCases := map[string]struct {
+		prefix   string
+		clientId string
+		suffix   string
+		expected string
+	}{
+		"client id only":    {clientId: "kafka-ch-dispatcher", expected: "kafka-ch-dispatcher"},
+		"prefix":            {prefix: "cluster-a", clientId: "kafka-ch-dispatcher", expected: "cluster-a-kafka-ch-dispatcher"},
+		"suffix":            {clientId: "kafka-ch-dispatcher", suffix: "tenant-b", expected: "kafka-ch-dispatcher-tenant-b"},
+		"prefix and suffix": {prefix: "cluster-a", clientId: "kafka-ch-dispatcher", suffix: "tenant-b", expected: "cluster-a-kafka-ch-dispatcher-tenant-b"},
+		"no client id":      {prefix: "cluster-a", suffix: "tenant-b", expected: "cluster-a-tenant-b"},
+		"everything empty":  {expected: ""},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ComposeClientId(tc.prefix, tc.clientId, tc.suffix))
+		})
+	}
+}
+
+func TestBuildSaramaConfigWithMinimumVersion(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	testCases := []struct {
+		name        string
+		version     sarama.KafkaVersion
+		expectError bool
+	}{
+		{name: "Version Too Old", version: sarama.V0_10_2_0, expectError: true},
+		{name: "Minimum Version", version: sarama.V1_0_0_0, expectError: false},
+		{name: "Newer Version", version: sarama.V2_0_0_0, expectError: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithVersion(&tc.version).
+				WithMinimumVersion(&sarama.V1_0_0_0).
+				Build(ctx)
+			if tc.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.version, config.Version)
+			}
+		})
+	}
+}
+
+func TestBuildSaramaConfigWithNetLimits(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	defaults := sarama.NewConfig()
+
+	testCases := []struct {
+		name                    string
+		maxOpenRequests         int
+		dialTimeout             time.Duration
+		keepAlive               time.Duration
+		expectedMaxOpenRequests int
+		expectedDialTimeout     time.Duration
+		expectedKeepAlive       time.Duration
+		expectError             bool
+	}{
+		{
+			name:                    "No Limits",
+			expectedMaxOpenRequests: defaults.Net.MaxOpenRequests,
+			expectedDialTimeout:     defaults.Net.DialTimeout,
+			expectedKeepAlive:       defaults.Net.KeepAlive,
+		},
+		{
+			name:                    "All Limits",
+			maxOpenRequests:         1,
+			dialTimeout:             5 * time.Second,
+			keepAlive:               time.Minute,
+			expectedMaxOpenRequests: 1,
+			expectedDialTimeout:     5 * time.Second,
+			expectedKeepAlive:       time.Minute,
+		},
+		{
+			name:                    "MaxOpenRequests Only",
+			maxOpenRequests:         2,
+			expectedMaxOpenRequests: 2,
+			expectedDialTimeout:     defaults.Net.DialTimeout,
+			expectedKeepAlive:       defaults.Net.KeepAlive,
+		},
+		{name: "Negative MaxOpenRequests", maxOpenRequests: -1, expectError: true},
+		{name: "Negative DialTimeout", dialTimeout: -time.Second, expectError: true},
+		{name: "Negative KeepAlive", keepAlive: -time.Second, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithNetLimits(tc.maxOpenRequests, tc.dialTimeout, tc.keepAlive).
+				Build(ctx)
+			if tc.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedMaxOpenRequests, config.Net.MaxOpenRequests)
+				assert.Equal(t, tc.expectedDialTimeout, config.Net.DialTimeout)
+				assert.Equal(t, tc.expectedKeepAlive, config.Net.KeepAlive)
+				assert.Nil(t, config.Validate())
+			}
+		})
+	}
+}
+
+func TestBuildSaramaConfigWithRebalanceStrategy(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	testCases := []struct {
+		name             string
+		strategy         string
+		expectedStrategy sarama.BalanceStrategy
+		expectError      bool
+	}{
+		{name: "No Strategy", expectedStrategy: sarama.NewConfig().Consumer.Group.Rebalance.Strategy},
+		{name: "Range", strategy: "range", expectedStrategy: sarama.BalanceStrategyRange},
+		{name: "RoundRobin", strategy: "roundrobin", expectedStrategy: sarama.BalanceStrategyRoundRobin},
+		{name: "Sticky", strategy: "sticky", expectedStrategy: sarama.BalanceStrategySticky},
+		{name: "Unsupported Strategy", strategy: "cooperative-sticky", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithRebalanceStrategy(tc.strategy).
+				Build(ctx)
+			if tc.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedStrategy, config.Consumer.Group.Rebalance.Strategy)
+				assert.Nil(t, config.Validate())
+			}
+		})
+	}
+}
+
+func TestBuildSaramaConfigWithGroupTimeouts(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	defaults := sarama.NewConfig()
+
+	testCases := []struct {
+		name                      string
+		sessionTimeout            time.Duration
+		heartbeatInterval         time.Duration
+		expectedSessionTimeout    time.Duration
+		expectedHeartbeatInterval time.Duration
+		expectError               bool
+	}{
+		{
+			name:                      "No Timeouts",
+			expectedSessionTimeout:    defaults.Consumer.Group.Session.Timeout,
+			expectedHeartbeatInterval: defaults.Consumer.Group.Heartbeat.Interval,
+		},
+		{
+			name:                      "All Timeouts",
+			sessionTimeout:            30 * time.Second,
+			heartbeatInterval:         5 * time.Second,
+			expectedSessionTimeout:    30 * time.Second,
+			expectedHeartbeatInterval: 5 * time.Second,
+		},
+		{
+			name:                      "SessionTimeout Only",
+			sessionTimeout:            45 * time.Second,
+			expectedSessionTimeout:    45 * time.Second,
+			expectedHeartbeatInterval: defaults.Consumer.Group.Heartbeat.Interval,
+		},
+		{name: "Negative SessionTimeout", sessionTimeout: -time.Second, expectError: true},
+		{name: "Negative HeartbeatInterval", heartbeatInterval: -time.Second, expectError: true},
+		{name: "Heartbeat At A Third Of Session", sessionTimeout: 30 * time.Second, heartbeatInterval: 10 * time.Second, expectError: true},
+		{name: "SessionTimeout Too Short For Default Heartbeat", sessionTimeout: 8 * time.Second, expectError: true},
+		{name: "HeartbeatInterval Too Long For Default Session", heartbeatInterval: 4 * time.Second, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithGroupTimeouts(tc.sessionTimeout, tc.heartbeatInterval).
+				Build(ctx)
+			if tc.expectError {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedSessionTimeout, config.Consumer.Group.Session.Timeout)
+				assert.Equal(t, tc.expectedHeartbeatInterval, config.Consumer.Group.Heartbeat.Interval)
+				assert.Nil(t, config.Validate())
+			}
+		})
+	}
+}
+
+func TestRebalanceStrategyNames(t *testing.T) {
+	assert.Equal(t, []string{"range", "roundrobin", "sticky"}, RebalanceStrategyNames())
+	assert.True(t, IsValidRebalanceStrategy(""))
+	assert.True(t, IsValidRebalanceStrategy("sticky"))
+	assert.False(t, IsValidRebalanceStrategy("cooperative-sticky"))
+}
+
 func extractSaramaConfig(t *testing.T, saramaConfigField string) string {
 	saramaShell := &struct {
 		EnableLogging bool   `json:"enableLogging"`
@@ -417,6 +631,33 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 	assert.Equal(t, int16(1), config.Net.SASL.Version)
 }
 
+func TestBuildSaramaConfigWithMutualTLSAuth(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	cert, key := generateCert(t)
+	expectedCert, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	assert.Nil(t, err)
+
+	config, err := NewConfigBuilder().
+		WithDefaults().
+		WithAuth(&KafkaAuthConfig{
+			TLS: &KafkaTlsConfig{
+				Usercert: cert,
+				Userkey:  key,
+			},
+		}).
+		Build(ctx)
+	assert.Nil(t, err)
+
+	// Make sure the client certificate is loaded without requiring a CA cert
+	assert.True(t, config.Net.TLS.Enable)
+	assert.NotNil(t, config.Net.TLS.Config)
+	assert.Len(t, config.Net.TLS.Config.Certificates, 1)
+	assert.Equal(t, expectedCert.Certificate, config.Net.TLS.Config.Certificates[0].Certificate)
+	assert.Nil(t, config.Net.TLS.Config.RootCAs)
+	assert.False(t, config.Net.TLS.Config.InsecureSkipVerify)
+}
+
 func TestBuildSaramaConfigWithSASLAuth(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	ctx := logging.WithLogger(context.TODO(), logger)
@@ -532,6 +773,49 @@ func TestSaramaConfigEqual(t *testing.T) {
 	assert.True(t, ConfigEqual(config1, config2))
 }
 
+// Verify that the OAuth token provider is set up from the SASL settings
+func TestBuildSaramaConfigWithOAuth(t *testing.T) {
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+
+	customTokenProvider := NewStaticTokenProvider("CUSTOM-TOKEN")
+
+	testCases := []struct {
+		name          string
+		saslConfig    *KafkaSaslConfig
+		expectedToken string
+	}{
+		{
+			name:          "Static Token",
+			saslConfig:    &KafkaSaslConfig{SaslType: sarama.SASLTypeOAuth, Token: "STATIC-TOKEN"},
+			expectedToken: "STATIC-TOKEN",
+		},
+		{
+			name:          "Custom TokenProvider",
+			saslConfig:    &KafkaSaslConfig{SaslType: sarama.SASLTypeOAuth, TokenProvider: customTokenProvider},
+			expectedToken: "CUSTOM-TOKEN",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithAuth(&KafkaAuthConfig{SASL: tc.saslConfig}).
+				Build(ctx)
+			assert.Nil(t, err)
+
+			// Make sure the OAUTHBEARER mechanism and token provider are installed
+			assert.True(t, config.Net.SASL.Enable)
+			assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+			assert.NotNil(t, config.Net.SASL.TokenProvider)
+			token, err := config.Net.SASL.TokenProvider.Token()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedToken, token.Token)
+		})
+	}
+}
+
 func TestUpdateSaramaConfigWithKafkaAuthConfig(t *testing.T) {
 
 	cert, key := generateCert(t)
@@ -0,0 +1,37 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"github.com/Shopify/sarama"
+)
+
+// StaticTokenProvider is an OAUTHBEARER sarama.AccessTokenProvider which always returns the same token
+type StaticTokenProvider struct {
+	token string
+}
+
+var _ sarama.AccessTokenProvider = &StaticTokenProvider{}
+
+// NewStaticTokenProvider returns a StaticTokenProvider for the specified token
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: token}
+}
+
+// Token returns the static OAUTHBEARER access token
+func (p *StaticTokenProvider) Token() (*sarama.AccessToken, error) {
+	return &sarama.AccessToken{Token: p.token}, nil
+}
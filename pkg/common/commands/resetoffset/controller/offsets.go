@@ -47,12 +47,73 @@ type SaramaNewOffsetManagerFromClientFnType func(group string, client sarama.Cli
 // function used when reconciling offsets which facilitates stubbing in unit tests.
 var SaramaNewOffsetManagerFromClientFn SaramaNewOffsetManagerFromClientFnType = sarama.NewOffsetManagerFromClient
 
+// ConsumerGroupDescriber is the subset of the Sarama ClusterAdmin used to verify the state of a ConsumerGroup.
+type ConsumerGroupDescriber interface {
+	DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error)
+}
+
+// SaramaNewConsumerGroupDescriberFnType defines the signature of the function creating a ConsumerGroupDescriber.
+type SaramaNewConsumerGroupDescriberFnType func(client sarama.Client) (ConsumerGroupDescriber, error)
+
+// SaramaNewConsumerGroupDescriberFn is a reference to the function creating the ConsumerGroupDescriber
+// used when reconciling offsets which facilitates stubbing in unit tests.
+var SaramaNewConsumerGroupDescriberFn SaramaNewConsumerGroupDescriberFnType = NewConsumerGroupDescriber
+
+// NewConsumerGroupDescriber creates a Sarama ClusterAdmin sharing the specified Sarama Client.
+func NewConsumerGroupDescriber(client sarama.Client) (ConsumerGroupDescriber, error) {
+	return sarama.NewClusterAdminFromClient(client)
+}
+
+// ReconcileOffsetsTimeout is the maximum duration of the whole Offsets reconciliation, after which it is
+// aborted (with context.DeadlineExceeded) so that a hung broker can't block the reconciler indefinitely.
+var ReconcileOffsetsTimeout = 2 * time.Minute
+
+// CommitRetryAttempts is the number of attempts made at committing the repositioned Offsets.
+var CommitRetryAttempts = 3
+
+// CommitRetryBackoff is the delay before the first retry of the Offsets commit, doubled for each further retry.
+var CommitRetryBackoff = 500 * time.Millisecond
+
+// OffsetCommitError is a terminal error returned when the repositioned Offsets could not be
+// committed within the retry budget (CommitRetryAttempts).
+type OffsetCommitError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *OffsetCommitError) Error() string {
+	return fmt.Sprintf("failed to commit offsets after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *OffsetCommitError) Unwrap() error {
+	return e.Err
+}
+
+// ConsumerGroupActiveError is returned when attempting to reposition the Offsets of
+// a ConsumerGroup which still has active members.  The ConsumerGroup's consumers must
+// be stopped first, otherwise the commit is rejected by Kafka or races with them.
+type ConsumerGroupActiveError struct {
+	GroupId string
+	State   string
+}
+
+func (e *ConsumerGroupActiveError) Error() string {
+	return fmt.Sprintf("consumer group %s is active (state %q), its consumers must be stopped before resetting offsets", e.GroupId, e.State)
+}
+
 // reconcileOffsets updates the Offsets of all Partitions for the specified
 // Topic / ConsumerGroup to the Offset value corresponding to the specified
-// offsetTime (millis since epoch) and return OffsetMappings of the old/new
-// state.  An error will be returned and the Offsets will not be committed
-// if any problems occur.
-func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.RefInfo, offsetTime int64) ([]kafkav1alpha1.OffsetMapping, error) {
+// offsetTime (millis since epoch), or to the specified percentage of their
+// current lag if lagPercentage is not nil, and return OffsetMappings of the
+// old/new state.  An error will be returned and the Offsets will not be
+// committed if any problems occur.  The operation is bounded by the deadline
+// of the specified context (and the ReconcileOffsetsTimeout) and the Sarama
+// Client is closed in order to abort any pending Kafka requests on expiry.
+func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.RefInfo, offsetTime int64, lagPercentage *int32) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Bound The Whole Operation By The Context Deadline / ReconcileOffsetsTimeout
+	ctx, cancel := context.WithTimeout(ctx, ReconcileOffsetsTimeout)
+	defer cancel()
 
 	// Get The Logger From The Context & Enhance The With Parameters
 	logger := logging.FromContext(ctx).Desugar().With(
@@ -74,6 +135,45 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 		return nil, err
 	}
 
+	// Perform The Kafka Operations Asynchronously As The Sarama Client Doesn't Support Contexts
+	type offsetsResult struct {
+		offsetMappings []kafkav1alpha1.OffsetMapping
+		err            error
+	}
+	resultChan := make(chan offsetsResult, 1)
+	go func() {
+		offsetMappings, err := reconcileOffsetsWithClient(ctx, logger, saramaClient, refInfo, offsetTime, lagPercentage)
+		resultChan <- offsetsResult{offsetMappings: offsetMappings, err: err}
+	}()
+
+	// Wait For The Results Or Abort (Closing The Client To Release Any Pending Requests) Once The Deadline Expires
+	select {
+	case result := <-resultChan:
+		return result.offsetMappings, result.err
+	case <-ctx.Done():
+		logger.Error("Timed out reconciling Offsets - aborting", zap.Error(ctx.Err()))
+		safeCloseSaramaClient(logger, saramaClient)
+		<-resultChan // Wait For The Aborted Operations So That Nothing Is Committed After Returning
+		return nil, ctx.Err()
+	}
+}
+
+// reconcileOffsetsWithClient performs the actual Offsets reconciliation of reconcileOffsets()
+// using the specified Sarama Client.  Nothing is committed (or retried) once the context is done.
+func reconcileOffsetsWithClient(ctx context.Context,
+	logger *zap.Logger,
+	saramaClient sarama.Client,
+	refInfo *refmappers.RefInfo,
+	offsetTime int64,
+	lagPercentage *int32) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Verify The ConsumerGroup Has Been Stopped Before Repositioning Its Offsets
+	err := verifyConsumerGroupStopped(saramaClient, refInfo.GroupId)
+	if err != nil {
+		logger.Error("ConsumerGroup is not stopped - unable to update Offsets", zap.Error(err))
+		return nil, err
+	}
+
 	// Get The Partitions Of The Specified Kafka Topic
 	partitions, err := saramaClient.Partitions(refInfo.TopicName)
 	if err != nil {
@@ -82,11 +182,68 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 	}
 	logger.Debug("Found Topic Partitions", zap.Any("Partitions", partitions))
 
+	// Determine How The New Offsets Are Computed From The Specified Time / Lag Percentage
+	newOffset := timeOffsetFn(saramaClient, refInfo.TopicName, offsetTime)
+	offsetMetaData := formatOffsetMetaData(offsetTime)
+	if lagPercentage != nil {
+		newOffset = lagPercentageOffsetFn(saramaClient, refInfo.TopicName, *lagPercentage)
+		offsetMetaData = formatLagOffsetMetaData(*lagPercentage)
+	}
+
+	// Update & Commit The Offsets, Retrying The Commit With Backoff As Its Errors Are Usually Transient
+	var offsetMappings []kafkav1alpha1.OffsetMapping
+	backoff := CommitRetryBackoff
+	for attempt := 1; attempt <= CommitRetryAttempts; attempt++ {
+		if attempt > 1 {
+			logger.Warn("Retrying Offsets Commit", zap.Int("Attempt", attempt), zap.Duration("Backoff", backoff), zap.Error(err))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			backoff *= 2
+
+			// Reposition To The Offsets Of The First Attempt As Some Partitions May Have Been Committed Already
+			newOffset = mappedOffsetFn(offsetMappings)
+		}
+
+		var attemptOffsetMappings []kafkav1alpha1.OffsetMapping
+		var retryable bool
+		attemptOffsetMappings, retryable, err = updateAndCommitOffsets(ctx, logger, saramaClient, refInfo, partitions, newOffset, offsetMetaData)
+		if err == nil {
+			if offsetMappings == nil {
+				offsetMappings = attemptOffsetMappings
+			}
+			return offsetMappings, nil
+		} else if !retryable {
+			return nil, err
+		}
+		if offsetMappings == nil {
+			offsetMappings = attemptOffsetMappings
+		}
+	}
+
+	// Retry Budget Exhausted
+	logger.Error("Failed to commit Offsets within the retry budget", zap.Int("Attempts", CommitRetryAttempts), zap.Error(err))
+	return nil, &OffsetCommitError{Attempts: CommitRetryAttempts, Err: err}
+}
+
+// updateAndCommitOffsets repositions the Offsets of all the specified Partitions using new
+// OffsetManagers, commits them and returns the OffsetMappings of the old/new state.  The
+// returned bool is true if the error occurred while committing and the commit may be retried.
+func updateAndCommitOffsets(ctx context.Context,
+	logger *zap.Logger,
+	saramaClient sarama.Client,
+	refInfo *refmappers.RefInfo,
+	partitions []int32,
+	newOffset newOffsetFn,
+	offsetMetaData string) ([]kafkav1alpha1.OffsetMapping, bool, error) {
+
 	// Create An OffsetManager For The Specified ConsumerGroup
 	offsetManager, err := SaramaNewOffsetManagerFromClientFn(refInfo.GroupId, saramaClient)
 	if offsetManager == nil || err != nil {
 		logger.Error("Failed to create OffsetManager for ConsumerGroup", zap.Error(err))
-		return nil, err
+		return nil, false, err
 	}
 
 	// Create The Required PartitionOffsetManagers For The Specified Topic / Partitions
@@ -94,26 +251,64 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 	if err != nil {
 		logger.Error("Failed to create PartitionOffsetManagers for Topic Partitions", zap.Error(err))
 		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
-		return nil, err
+		return nil, false, err
 	}
 
-	// Update All Topic Partitions To The Specified Offset Time
-	offsetMappings, err := updateOffsets(logger, saramaClient, offsetManager, partitionOffsetManagers, refInfo.TopicName, partitions, offsetTime)
+	// Update All Topic Partitions To The New Offsets
+	offsetMappings, err := updateOffsets(ctx, logger, offsetManager, partitionOffsetManagers, partitions, newOffset, offsetMetaData)
 	if err != nil {
 		logger.Error("Failed to update Offsets for Topic Partitions", zap.Error(err))
 		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
-		return nil, err
+		return nil, false, err
 	}
 
-	// Close The Sarama Managers And Get Any Accumulated Errors
+	// Close The Sarama Managers And Get Any Accumulated (Commit) Errors
 	err = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
 	if err != nil {
 		logger.Error("PartitionOffsetManager Errors encountered", zap.Error(err))
-		return nil, err
+		return offsetMappings, true, err
 	}
 
 	// Return Success
-	return offsetMappings, nil
+	return offsetMappings, false, nil
+}
+
+// verifyConsumerGroupStopped returns a ConsumerGroupActiveError unless the specified
+// ConsumerGroup is in the "Empty" or "Dead" state (i.e. it has no active members).
+func verifyConsumerGroupStopped(saramaClient sarama.Client, groupId string) error {
+
+	// Create A ConsumerGroupDescriber From The Sarama Client (Not Closed As That Would Close The Client)
+	describer, err := SaramaNewConsumerGroupDescriberFn(saramaClient)
+	if err != nil {
+		return err
+	}
+
+	// Describe The ConsumerGroup & Verify Its State
+	groupDescriptions, err := describer.DescribeConsumerGroups([]string{groupId})
+	if err != nil {
+		return err
+	}
+	for _, groupDescription := range groupDescriptions {
+		if groupDescription.GroupId == groupId && groupDescription.State != "Empty" && groupDescription.State != "Dead" {
+			return &ConsumerGroupActiveError{GroupId: groupId, State: groupDescription.State}
+		}
+	}
+	return nil
+}
+
+// kafkaConfigInfo returns the Kafka brokers and relevant Sarama settings used when reconciling offsets.
+func (r *Reconciler) kafkaConfigInfo() *kafkav1alpha1.KafkaConfigInfo {
+	kafkaConfigInfo := &kafkav1alpha1.KafkaConfigInfo{
+		Brokers: append([]string(nil), r.kafkaBrokers...),
+	}
+	if r.saramaConfig != nil {
+		kafkaConfigInfo.Version = r.saramaConfig.Version.String()
+		kafkaConfigInfo.TLS = r.saramaConfig.Net.TLS.Enable
+		if r.saramaConfig.Net.SASL.Enable {
+			kafkaConfigInfo.SASLMechanism = string(r.saramaConfig.Net.SASL.Mechanism)
+		}
+	}
+	return kafkaConfigInfo
 }
 
 // updateOffsets attempts to update all of the specified Topic's Partitions
@@ -122,13 +317,14 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 // implementation, Errors directly related to Offset management are available
 // on the respective PartitionOffsetManager's Error channel.  Such errors are
 // not returned here as they should be drained after closing the Managers.
-func updateOffsets(logger *zap.Logger,
-	saramaClient sarama.Client,
+// The Commit() is skipped if the specified context is done.
+func updateOffsets(ctx context.Context,
+	logger *zap.Logger,
 	offsetManager sarama.OffsetManager,
 	partitionOffsetManagers PartitionOffsetManagers,
-	topicName string,
 	partitions []int32,
-	offsetTime int64) ([]kafkav1alpha1.OffsetMapping, error) {
+	newOffset newOffsetFn,
+	offsetMetaData string) ([]kafkav1alpha1.OffsetMapping, error) {
 
 	// The OffsetMappings To Be Returned For ResetOffset Status
 	offsetMappings := make([]kafkav1alpha1.OffsetMapping, len(partitions))
@@ -146,8 +342,8 @@ func updateOffsets(logger *zap.Logger,
 			return nil, fmt.Errorf("missing PartitionOffsetManager - unable to update Offset")
 		}
 
-		// Update The Individual Offset To Specified Time
-		offsetMapping, updateErr := updateOffset(logger, saramaClient, partitionOffsetManager, topicName, partition, offsetTime)
+		// Update The Individual Offset
+		offsetMapping, updateErr := updateOffset(logger, partitionOffsetManager, partition, newOffset, offsetMetaData)
 		if updateErr != nil {
 			logger.Error("Failed to update Offset - skipping Commit", zap.Error(updateErr))
 			return nil, updateErr
@@ -155,6 +351,12 @@ func updateOffsets(logger *zap.Logger,
 		offsetMappings[index] = *offsetMapping
 	}
 
+	// Don't Commit If The Operation Was Aborted In The Meantime
+	if err := ctx.Err(); err != nil {
+		logger.Error("Offsets reconciliation aborted - skipping Commit", zap.Error(err))
+		return nil, err
+	}
+
 	// All Partitions Updated Successfully - Commit The New Offsets!
 	logger.Info("All Offsets updated successfully - performing Commit")
 	offsetManager.Commit() // No Errors Returned - Will be in PartitionOffsetManager.Errors() Channel Post-Close!
@@ -167,16 +369,15 @@ func updateOffsets(logger *zap.Logger,
 // and returns an OffsetMapping representing the old/new state.  No Offset changes
 // are committed to allow for atomic commit/fail decision for all Offsets.
 func updateOffset(logger *zap.Logger,
-	saramaClient sarama.Client,
 	partitionOffsetManager sarama.PartitionOffsetManager,
-	topic string,
 	partition int32,
-	offsetTime int64) (*kafkav1alpha1.OffsetMapping, error) {
+	newOffset newOffsetFn,
+	offsetMetaData string) (*kafkav1alpha1.OffsetMapping, error) {
 
-	// Get The New Offset Of Partition For Specified Time
-	newOffset, err := saramaClient.GetOffset(topic, partition, offsetTime)
+	// Get The New Offset Of Partition
+	newPartitionOffset, err := newOffset(partitionOffsetManager, partition)
 	if err != nil {
-		logger.Error("Failed to get Partition Offset for Time", zap.Int64("Time", offsetTime), zap.Error(err))
+		logger.Error("Failed to get new Partition Offset", zap.Error(err))
 		return nil, err
 	}
 
@@ -184,24 +385,99 @@ func updateOffset(logger *zap.Logger,
 	currentOffset, _ := partitionOffsetManager.NextOffset()
 
 	// Update The Partition's Offset Forward/Back As Needed
-	offsetMetaData := formatOffsetMetaData(offsetTime)
-	if newOffset > currentOffset {
-		partitionOffsetManager.MarkOffset(newOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
-	} else if newOffset < currentOffset {
-		partitionOffsetManager.ResetOffset(newOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
+	if newPartitionOffset > currentOffset {
+		partitionOffsetManager.MarkOffset(newPartitionOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
+	} else if newPartitionOffset < currentOffset {
+		partitionOffsetManager.ResetOffset(newPartitionOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
 	}
 
 	// Create An OffsetMapping For The Partition
 	offsetMapping := &kafkav1alpha1.OffsetMapping{
 		Partition: partition,
 		OldOffset: currentOffset,
-		NewOffset: newOffset,
+		NewOffset: newPartitionOffset,
 	}
 
 	// Return The OffsetMapping Success
 	return offsetMapping, nil
 }
 
+// newOffsetFn returns the Offset to which the specified Partition is to be repositioned
+type newOffsetFn func(partitionOffsetManager sarama.PartitionOffsetManager, partition int32) (int64, error)
+
+// timeOffsetFn returns a newOffsetFn repositioning the Partitions to the Offsets of the specified time
+func timeOffsetFn(saramaClient sarama.Client, topic string, offsetTime int64) newOffsetFn {
+	return func(_ sarama.PartitionOffsetManager, partition int32) (int64, error) {
+		return saramaClient.GetOffset(topic, partition, offsetTime)
+	}
+}
+
+// lagPercentageOffsetFn returns a newOffsetFn repositioning the Partitions by the specified percentage of their lag
+func lagPercentageOffsetFn(saramaClient sarama.Client, topic string, lagPercentage int32) newOffsetFn {
+	return func(partitionOffsetManager sarama.PartitionOffsetManager, partition int32) (int64, error) {
+		return getLagPercentageOffset(saramaClient, partitionOffsetManager, topic, partition, lagPercentage)
+	}
+}
+
+// mappedOffsetFn returns a newOffsetFn repositioning the Partitions to the NewOffsets of the specified OffsetMappings
+func mappedOffsetFn(offsetMappings []kafkav1alpha1.OffsetMapping) newOffsetFn {
+	return func(_ sarama.PartitionOffsetManager, partition int32) (int64, error) {
+		for _, offsetMapping := range offsetMappings {
+			if offsetMapping.Partition == partition {
+				return offsetMapping.NewOffset, nil
+			}
+		}
+		return 0, fmt.Errorf("no OffsetMapping for Partition %d", partition)
+	}
+}
+
+// getLagPercentageOffset returns the Offset located the specified percentage of the current lag
+// (the distance between the committed and newest Offsets) before the committed Offset of the Partition.
+func getLagPercentageOffset(saramaClient sarama.Client,
+	partitionOffsetManager sarama.PartitionOffsetManager,
+	topic string,
+	partition int32,
+	lagPercentage int32) (int64, error) {
+
+	// Get The Newest & Oldest Offsets Of The Partition
+	newestOffset, err := saramaClient.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+	oldestOffset, err := saramaClient.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get The Committed Offset Of The Partition (Resolving The Sarama Initial Offsets If Nothing Was Committed)
+	committedOffset, _ := partitionOffsetManager.NextOffset()
+	if committedOffset == sarama.OffsetNewest {
+		committedOffset = newestOffset
+	} else if committedOffset < oldestOffset {
+		committedOffset = oldestOffset
+	}
+
+	return lagPercentageOffset(committedOffset, oldestOffset, newestOffset, lagPercentage), nil
+}
+
+// lagPercentageOffset returns committedOffset - lagPercentage% * (newestOffset - committedOffset), clamped to oldestOffset.
+func lagPercentageOffset(committedOffset int64, oldestOffset int64, newestOffset int64, lagPercentage int32) int64 {
+	lag := newestOffset - committedOffset
+	if lag < 0 {
+		lag = 0
+	}
+	newOffset := committedOffset - lag*int64(lagPercentage)/100
+	if newOffset < oldestOffset {
+		newOffset = oldestOffset
+	}
+	return newOffset
+}
+
+// formatLagOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified lag percentage.
+func formatLagOffsetMetaData(lagPercentage int32) string {
+	return fmt.Sprintf("resetoffset.lag.%d", lagPercentage)
+}
+
 // formatOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified time.
 func formatOffsetMetaData(time int64) string {
 	return fmt.Sprintf("resetoffset.%d", time)
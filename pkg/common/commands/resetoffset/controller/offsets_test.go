@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
@@ -61,11 +62,15 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 
 	offsetTime := int64(123456789)
 	metadata := formatOffsetMetaData(offsetTime)
+	lagPercentage := int32(50)
 
 	// Define The Test Cases
 	tests := []struct {
 		name                    string
 		client                  *controllertesting.MockClient
+		groupState              string
+		describeErr             error
+		lagPercentage           *int32
 		offsetManager           *controllertesting.MockOffsetManager
 		partitionOffsetManagers map[int32]*controllertesting.MockPartitionOffsetManager
 		expectedOffsetMappings  []kafkav1alpha1.OffsetMapping
@@ -134,6 +139,31 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 			expectedErr: nil,
 		},
 
+		{
+			name: "Successful Lag Percentage",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1}, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition1, sarama.OffsetNewest, newFutureOffset1, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition1, sarama.OffsetOldest, int64(0), nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			lagPercentage: &lagPercentage,
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockCommit(),
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockResetOffset(oldOffset1-25, formatLagOffsetMetaData(lagPercentage)),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
+				{Partition: partition1, OldOffset: oldOffset1, NewOffset: oldOffset1 - 25},
+			},
+			expectedErr: nil,
+		},
+
 		//
 		// Sarama Error Tests
 		//
@@ -155,6 +185,29 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 			expectedErr:            testErr,
 		},
 
+		//
+		// ConsumerGroup State Tests
+		//
+
+		{
+			name: "Active ConsumerGroup",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			groupState:             "Stable",
+			expectedOffsetMappings: nil,
+			expectedErr:            &ConsumerGroupActiveError{GroupId: groupId, State: "Stable"},
+		},
+		{
+			name: "DescribeConsumerGroups() Error",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			describeErr:            testErr,
+			expectedOffsetMappings: nil,
+			expectedErr:            testErr,
+		},
+
 		//
 		// Client Error Tests
 		//
@@ -184,6 +237,25 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 			expectedOffsetMappings: nil,
 			expectedErr:            testErr,
 		},
+		{
+			name: "Client.GetOffset() Partition Leader Change",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1}, nil),
+				controllertesting.WithClientMockLeaderChange(topicName, partition1, offsetTime, newPastOffset1),
+				controllertesting.WithClientMockClosed(true)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockCommit(),
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockResetOffset(newPastOffset1, metadata),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1}},
+			expectedErr:            nil,
+		},
 		{
 			name: "Client.Close() Error",
 			client: controllertesting.NewMockClient(
@@ -248,7 +320,7 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 		//
 
 		{
-			name: "PartitionsOffsetManager.Errors()",
+			name: "PartitionsOffsetManager.Errors() Until Retries Exhausted",
 			client: controllertesting.NewMockClient(
 				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
 				controllertesting.WithClientMockGetOffset(topicName, partition1, offsetTime, newPastOffset1, nil),
@@ -261,27 +333,48 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 				partition1: controllertesting.NewMockPartitionOffsetManager(
 					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
 					controllertesting.WithPartitionOffsetManagerMockResetOffset(newPastOffset1, metadata),
-					controllertesting.WithPartitionOffsetManagerMockErrors(&sarama.ConsumerError{
-						Topic:     topicName,
-						Partition: partition1,
-						Err:       testErr,
-					}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition1, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition1, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition1, Err: testErr}),
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 				partition2: controllertesting.NewMockPartitionOffsetManager(
 					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""),
 					controllertesting.WithPartitionOffsetManagerMockResetOffset(newPastOffset2, metadata),
-					controllertesting.WithPartitionOffsetManagerMockErrors(&sarama.ConsumerError{
-						Topic:     topicName,
-						Partition: partition2,
-						Err:       testErr,
-					}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition2, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition2, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition2, Err: testErr}),
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 			},
 			expectedOffsetMappings: nil,
-			expectedErr:            multierr.Combine(testErr, testErr),
+			expectedErr:            &OffsetCommitError{Attempts: 3, Err: multierr.Combine(testErr, testErr)},
+		},
+		{
+			name: "PartitionsOffsetManager.Errors() Twice Then Commit Succeeds",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1}, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition1, offsetTime, newPastOffset1, nil),
+				controllertesting.WithClientMockClosed(true)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockCommit(),
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockResetOffset(newPastOffset1, metadata),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition1, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(&sarama.ConsumerError{Topic: topicName, Partition: partition1, Err: testErr}),
+					controllertesting.WithPartitionOffsetManagerMockErrorsOnce(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1}},
+			expectedErr:            nil,
 		},
 	}
 
+	// Don't Wait Between Commit Retries
+	defer func(backoff time.Duration) { CommitRetryBackoff = backoff }(CommitRetryBackoff)
+	CommitRetryBackoff = time.Millisecond
+
 	// Execute The Test Cases
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -298,6 +391,14 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 			}
 			defer restoreSaramaNewClientFn()
 
+			// Stub The ConsumerGroupDescriber To Report The ConsumerGroup State ("Empty" Unless Specified)
+			groupState := test.groupState
+			if groupState == "" {
+				groupState = "Empty"
+			}
+			stubSaramaNewConsumerGroupDescriberFn(t, test.client, controllertesting.NewMockConsumerGroupDescriber(groupId, groupState, test.describeErr))
+			defer restoreSaramaNewConsumerGroupDescriberFn()
+
 			// Stub The Sarama NewOffsetManagerFromClient() Implementation To Return Mock Sarama OffsetManager
 			if test.offsetManager == nil {
 				stubSaramaNewOffsetManagerFromClientFn(t, groupId, test.client, nil, testErr)
@@ -326,7 +427,7 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 			}
 
 			// Perform The Test
-			offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, offsetTime)
+			offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, offsetTime, test.lagPercentage)
 
 			// Verify The Results
 			assert.Equal(t, test.expectedErr, err)
@@ -346,6 +447,183 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 	}
 }
 
+// Test That The Kafka Offset Reconciliation Is Aborted When The Context Deadline Expires
+func TestReconciler_ReconcileOffsetsDeadline(t *testing.T) {
+
+	// Test Data
+	topicName := "TestTopic"
+	groupId := "TestGroup"
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	refInfo := &refmappers.RefInfo{TopicName: topicName, GroupId: groupId}
+
+	// Create A Context With Test Logger & A Short Deadline
+	logger := logtesting.TestLogger(t)
+	ctx, cancel := context.WithTimeout(logging.WithLogger(context.Background(), logger), 100*time.Millisecond)
+	defer cancel()
+
+	// Stub The Sarama Client To Simulate A Never-Responding Broker
+	client := controllertesting.NewMockClient(controllertesting.WithClientMockUnresponsivePartitions(topicName))
+	stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+	defer restoreSaramaNewClientFn()
+	stubSaramaNewConsumerGroupDescriberFn(t, client, controllertesting.NewMockConsumerGroupDescriber(groupId, "Empty", nil))
+	defer restoreSaramaNewConsumerGroupDescriberFn()
+
+	// Create A Reconciler To Test
+	reconciler := &Reconciler{
+		kafkaBrokers: kafkaBrokers,
+		saramaConfig: saramaConfig,
+	}
+
+	// Perform The Test
+	startTime := time.Now()
+	offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, 123456789, nil)
+
+	// Verify The Results
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Nil(t, offsetMappings)
+	assert.Less(t, int64(time.Since(startTime)), int64(5*time.Second))
+	client.AssertCalled(t, "Close")
+}
+
+// Test That The Offsets Are Not Committed Once The Context Is Done
+func TestReconcileOffsetsWithClientContextDone(t *testing.T) {
+
+	// Test Data
+	topicName := controllertesting.TopicName
+	groupId := controllertesting.GroupId
+	refInfo := &refmappers.RefInfo{TopicName: topicName, GroupId: groupId}
+	partition := int32(0)
+	offsetTime := int64(123456789)
+	logger := logtesting.TestLogger(t)
+	ctx, cancel := context.WithCancel(logging.WithLogger(context.Background(), logger))
+	cancel()
+
+	// Stub The Sarama Client, ConsumerGroupDescriber & OffsetManager
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(topicName, []int32{partition}, nil),
+		controllertesting.WithClientMockGetOffset(topicName, partition, offsetTime, 50, nil))
+	stubSaramaNewConsumerGroupDescriberFn(t, client, controllertesting.NewMockConsumerGroupDescriber(groupId, "Empty", nil))
+	defer restoreSaramaNewConsumerGroupDescriberFn()
+	partitionOffsetManager := controllertesting.NewMockPartitionOffsetManager(
+		controllertesting.WithPartitionOffsetManagerMockNextOffset(100, ""),
+		controllertesting.WithPartitionOffsetManagerMockResetOffset(50, formatOffsetMetaData(offsetTime)),
+		controllertesting.WithPartitionOffsetManagerMockErrors(),
+		controllertesting.WithPartitionOffsetManagerMockAsyncClose())
+	offsetManager := controllertesting.NewMockOffsetManager(
+		controllertesting.WithOffsetManagerMockClose(nil),
+		controllertesting.WithOffsetManagerMockManagePartition(topicName, partition, partitionOffsetManager, nil))
+	stubSaramaNewOffsetManagerFromClientFn(t, groupId, client, offsetManager, nil)
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Perform The Test
+	offsetMappings, err := reconcileOffsetsWithClient(ctx, logger.Desugar(), client, refInfo, offsetTime, nil)
+
+	// Verify The Results
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, offsetMappings)
+	offsetManager.AssertNotCalled(t, "Commit")
+	offsetManager.AssertExpectations(t)
+}
+
+// Test That A Partition Leader Change Is Recovered By The Sarama Client's GetOffset() Used By timeOffsetFn
+func TestTimeOffsetFnLeaderChange(t *testing.T) {
+	topicName := controllertesting.TopicName
+	partition1 := int32(0)
+	offsetTime := int64(123456789)
+	newPastOffset1 := int64(50)
+
+	seedBroker := sarama.NewMockBroker(t, 1)
+	defer seedBroker.Close()
+	oldLeader := sarama.NewMockBroker(t, 2)
+	defer oldLeader.Close()
+	newLeader := sarama.NewMockBroker(t, 3)
+	defer newLeader.Close()
+
+	// The Partition Leadership Moves From The Old To The New Leader Once The Client Is Created
+	metadataResponse := func(leader *sarama.MockBroker) sarama.MockResponse {
+		return sarama.NewMockMetadataResponse(t).
+			SetBroker(oldLeader.Addr(), oldLeader.BrokerID()).
+			SetBroker(newLeader.Addr(), newLeader.BrokerID()).
+			SetLeader(topicName, partition1, leader.BrokerID())
+	}
+	seedBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockSequence(metadataResponse(oldLeader), metadataResponse(newLeader)),
+	})
+	oldLeader.SetHandlerByMap(map[string]sarama.MockResponse{
+		"OffsetRequest": sarama.NewMockWrapper(&sarama.OffsetResponse{
+			Version: 1,
+			Blocks: map[string]map[int32]*sarama.OffsetResponseBlock{
+				topicName: {partition1: {Err: sarama.ErrNotLeaderForPartition}},
+			},
+		}),
+	})
+	newLeader.SetHandlerByMap(map[string]sarama.MockResponse{
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).SetOffset(topicName, partition1, offsetTime, newPastOffset1),
+	})
+
+	config := sarama.NewConfig()
+	config.Metadata.Retry.Backoff = 0
+	saramaClient, err := sarama.NewClient([]string{seedBroker.Addr()}, config)
+	assert.Nil(t, err)
+	defer saramaClient.Close()
+
+	offset, err := timeOffsetFn(saramaClient, topicName, offsetTime)(nil, partition1)
+	assert.Nil(t, err)
+	assert.Equal(t, newPastOffset1, offset)
+
+	// Verify The Old Leader Was Asked First
+	offsetRequests := 0
+	for _, exchange := range oldLeader.History() {
+		if _, ok := exchange.Request.(*sarama.OffsetRequest); ok {
+			offsetRequests++
+		}
+	}
+	assert.Equal(t, 1, offsetRequests)
+}
+
+func TestReconciler_KafkaConfigInfo(t *testing.T) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.V2_0_0_0
+	saramaConfig.Net.TLS.Enable = true
+	saramaConfig.Net.SASL.Enable = true
+	saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+
+	reconciler := &Reconciler{
+		kafkaBrokers: []string{controllertesting.Brokers},
+		saramaConfig: saramaConfig,
+	}
+	assert.Equal(t, &kafkav1alpha1.KafkaConfigInfo{
+		Brokers:       []string{controllertesting.Brokers},
+		Version:       sarama.V2_0_0_0.String(),
+		TLS:           true,
+		SASLMechanism: sarama.SASLTypeSCRAMSHA512,
+	}, reconciler.kafkaConfigInfo())
+}
+
+func TestLagPercentageOffset(t *testing.T) {
+	tests := []struct {
+		name            string
+		committedOffset int64
+		oldestOffset    int64
+		newestOffset    int64
+		lagPercentage   int32
+		expectedOffset  int64
+	}{
+		{name: "0%", committedOffset: 100, oldestOffset: 0, newestOffset: 200, lagPercentage: 0, expectedOffset: 100},
+		{name: "50%", committedOffset: 100, oldestOffset: 0, newestOffset: 200, lagPercentage: 50, expectedOffset: 50},
+		{name: "100%", committedOffset: 150, oldestOffset: 0, newestOffset: 200, lagPercentage: 100, expectedOffset: 100},
+		{name: "Clamped To Oldest", committedOffset: 100, oldestOffset: 80, newestOffset: 200, lagPercentage: 50, expectedOffset: 80},
+		{name: "Clamped At Exact Oldest", committedOffset: 100, oldestOffset: 0, newestOffset: 200, lagPercentage: 100, expectedOffset: 0},
+		{name: "No Lag", committedOffset: 200, oldestOffset: 0, newestOffset: 200, lagPercentage: 100, expectedOffset: 200},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedOffset, lagPercentageOffset(test.committedOffset, test.oldestOffset, test.newestOffset, test.lagPercentage))
+		})
+	}
+}
+
 //
 // Stubbing Utilities
 //
@@ -379,3 +657,17 @@ func stubSaramaNewOffsetManagerFromClientFn(t *testing.T, expectedGroupId string
 func restoreSaramaNewOffsetManagerFromClientFn() {
 	SaramaNewOffsetManagerFromClientFn = sarama.NewOffsetManagerFromClient
 }
+
+// stubSaramaNewConsumerGroupDescriberFn replaces the ConsumerGroupDescriber creation function with a
+// test instance which verifies the Sarama Client and returns the specified ConsumerGroupDescriber.
+func stubSaramaNewConsumerGroupDescriberFn(t *testing.T, expectedClient sarama.Client, describer ConsumerGroupDescriber) {
+	SaramaNewConsumerGroupDescriberFn = func(client sarama.Client) (ConsumerGroupDescriber, error) {
+		assert.Equal(t, expectedClient, client)
+		return describer, nil
+	}
+}
+
+// restoreSaramaNewConsumerGroupDescriberFn restores the default ConsumerGroupDescriber creation function.
+func restoreSaramaNewConsumerGroupDescriberFn() {
+	SaramaNewConsumerGroupDescriberFn = NewConsumerGroupDescriber
+}
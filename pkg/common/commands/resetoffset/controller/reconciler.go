@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -28,6 +29,7 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	control "knative.dev/control-protocol/pkg"
 	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 
@@ -106,13 +108,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	// Only Stop ConsumerGroups & Update Offsets Once
 	if !resetOffset.Status.IsOffsetsUpdated() {
 
-		// Parse The Sarama Offset Time From ResetOffset Spec
-		offsetTime, err := resetOffset.Spec.ParseSaramaOffsetTime()
-		if err != nil {
-			logger.Error("Failed to parse Sarama Offset Time from ResetOffset Spec", zap.Error(err))
-			return err // Should never happen assuming Validation is in place
+		// Parse The Sarama Offset Time From ResetOffset Spec (Unless Repositioning By Lag Percentage)
+		var offsetTime int64
+		if resetOffset.Spec.IsOffsetLagPercentage() {
+			logger.Info("Repositioning Offsets by Lag Percentage", zap.Int32("LagPercentage", *resetOffset.Spec.Offset.LagPercentage))
+		} else {
+			offsetTime, err = resetOffset.Spec.ParseSaramaOffsetTime()
+			if err != nil {
+				logger.Error("Failed to parse Sarama Offset Time from ResetOffset Spec", zap.Error(err))
+				return err // Should never happen assuming Validation is in place
+			}
+			logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
 		}
-		logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
 
 		// Stop The ConsumerGroup In Associated Dispatchers
 		err = r.stopConsumerGroups(ctx, resetOffset, dataPlaneServices, refInfo)
@@ -125,8 +132,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 		resetOffset.Status.MarkConsumerGroupsStoppedTrue()
 
 		// Update The Sarama Offsets & Update ResetOffset CRD With OffsetMappings (Single Atomic Operation For All Offsets)
-		offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime)
-		if err != nil {
+		offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime, resetOffset.Spec.Offset.LagPercentage)
+		var offsetCommitErr *OffsetCommitError
+		if errors.As(err, &offsetCommitErr) {
+			// The Commit Retry Budget Is Exhausted - Restart The ConsumerGroups & Fail Without Requeue
+			logger.Error("Failed to commit Offsets of ConsumerGroup Partitions", zap.Error(err))
+			resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToCommitOffsets", "Failed to commit Offsets of ConsumerGroup Partitions: %v", err)
+			if startErr := r.startConsumerGroups(ctx, resetOffset, dataPlaneServices, refInfo); startErr != nil {
+				logger.Error("Failed to restart one or more ConsumerGroups", zap.Error(startErr))
+				resetOffset.Status.MarkConsumerGroupsStartedFailed("FailedToStartConsumerGroups", "Failed to restart one or more ConsumerGroups: %v", startErr)
+			}
+			return controller.NewPermanentError(fmt.Errorf("failed to commit Offsets of ConsumerGroup Partitions: %v", err))
+		} else if err != nil {
 			logger.Error("Failed to update Offsets of ConsumerGroup Partitions", zap.Error(err))
 			resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToUpdateOffsets", "Failed to update Offsets of ConsumerGroup Partitions: %v", err)
 			return fmt.Errorf("failed to update Offsets of ConsumerGroup Partitions: %v", err)
@@ -134,6 +151,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 		if offsetMappings != nil {
 			resetOffset.Status.SetPartitions(offsetMappings)
 		}
+		resetOffset.Status.SetKafkaConfigInfo(r.kafkaConfigInfo())
 		logger.Info("Successfully updated Offsets of all partitions")
 		resetOffset.Status.MarkOffsetsUpdatedTrue()
 	}
@@ -78,6 +78,11 @@ func TestReconcile(t *testing.T) {
 		{Partition: 0, OldOffset: oldOffset, NewOffset: newOffset},
 	}
 
+	kafkaConfigInfo := &kafkav1alpha1.KafkaConfigInfo{
+		Brokers: kafkaBrokers,
+		Version: saramaConfig.Version.String(),
+	}
+
 	podIp := "1.2.3.4"
 	pods := []*corev1.Pod{{Status: corev1.PodStatus{PodIP: podIp}}}
 	podIpPort := fmt.Sprintf("%s:%d", podIp, controlprotocol.ServerPort)
@@ -117,6 +122,7 @@ func TestReconcile(t *testing.T) {
 						controllertesting.WithStatusTopic(topicName),
 						controllertesting.WithStatusGroup(groupId),
 						controllertesting.WithStatusPartitions(offsetMappings),
+						controllertesting.WithStatusKafkaConfigInfo(kafkaConfigInfo),
 						controllertesting.WithStatusRefMapped(true),
 						controllertesting.WithStatusAcquireDataPlaneServices(true),
 						controllertesting.WithStatusConsumerGroupsStopped(true),
@@ -251,6 +257,7 @@ func TestReconcile(t *testing.T) {
 						controllertesting.WithStatusTopic(topicName),
 						controllertesting.WithStatusGroup(groupId),
 						controllertesting.WithStatusPartitions(offsetMappings),
+						controllertesting.WithStatusKafkaConfigInfo(kafkaConfigInfo),
 						controllertesting.WithStatusRefMapped(true),
 						controllertesting.WithStatusAcquireDataPlaneServices(true),
 						controllertesting.WithStatusConsumerGroupsStopped(true),
@@ -305,6 +312,7 @@ func TestReconcile(t *testing.T) {
 	// Restore Sarama Client / OffsetManager Stubs After Test Completion
 	defer restoreSaramaNewClientFn()
 	defer restoreSaramaNewOffsetManagerFromClientFn()
+	defer restoreSaramaNewConsumerGroupDescriberFn()
 
 	// Run The TableTest Using The ResetOffset Reconciler Provided By The Factory
 	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher, options map[string]interface{}) controller.Reconciler {
@@ -410,6 +418,7 @@ func TestReconcile(t *testing.T) {
 		stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, mockClient, saramaNewClientFnErr)
 		mockOffsetManager := newSuccessSaramaOffsetManager(topicName, partition, oldOffset, newOffset, metadata)
 		stubSaramaNewOffsetManagerFromClientFn(t, groupId, mockClient, mockOffsetManager, nil)
+		stubSaramaNewConsumerGroupDescriberFn(t, mockClient, controllertesting.NewMockConsumerGroupDescriber(groupId, "Empty", nil))
 
 		// Create The ResetOffset Reconciler Struct
 		r := &Reconciler{
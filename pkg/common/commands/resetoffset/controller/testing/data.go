@@ -123,6 +123,12 @@ func WithStatusPartitions(partitions []kafkav1alpha1.OffsetMapping) ResetOffsetO
 	}
 }
 
+func WithStatusKafkaConfigInfo(kafkaConfigInfo *kafkav1alpha1.KafkaConfigInfo) ResetOffsetOption {
+	return func(resetOffset *kafkav1alpha1.ResetOffset) {
+		resetOffset.Status.Kafka = kafkaConfigInfo
+	}
+}
+
 func WithStatusInitialized(resetOffset *kafkav1alpha1.ResetOffset) {
 	resetOffset.Status.InitializeConditions()
 }
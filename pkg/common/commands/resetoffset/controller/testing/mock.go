@@ -17,6 +17,8 @@ limitations under the License.
 package testing
 
 import (
+	"time"
+
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
@@ -156,6 +158,27 @@ func WithClientMockGetOffset(topic string, partition int32, offsetTime int64, of
 	}
 }
 
+// WithClientMockLeaderChange simulates a leadership change of the specified Partition as seen through the Sarama
+// Client, whose GetOffset() already refreshes the metadata and retries once against the new leader.  GetOffset()
+// therefore returns the new leader's Offset, and no RefreshMetadata() is mocked so that any explicit refresh fails the test.
+func WithClientMockLeaderChange(topic string, partition int32, offsetTime int64, offset int64) MockClientOption {
+	return func(mockSaramaClient *MockClient) {
+		mockSaramaClient.On("GetOffset", topic, partition, offsetTime).Return(offset, nil).Once()
+	}
+}
+
+// WithClientMockUnresponsivePartitions simulates a hung broker, whereby Partitions() never responds
+// until the Sarama Client is closed.
+func WithClientMockUnresponsivePartitions(topic string) MockClientOption {
+	return func(mockSaramaClient *MockClient) {
+		closedChan := make(chan time.Time)
+		mockSaramaClient.On("Partitions", topic).WaitUntil(closedChan).Return([]int32(nil), sarama.ErrClosedClient)
+		mockSaramaClient.On("Closed").Return(false).Once()
+		mockSaramaClient.On("Close").Run(func(mock.Arguments) { close(closedChan) }).Return(nil).Once()
+		mockSaramaClient.On("Closed").Return(true)
+	}
+}
+
 func WithClientMockClosed(closed bool) MockClientOption {
 	return func(mockSaramaClient *MockClient) {
 		mockSaramaClient.On("Closed").Return(closed)
@@ -168,6 +191,25 @@ func WithClientMockClose(err error) MockClientOption {
 	}
 }
 
+//
+// Mock ConsumerGroupDescriber
+//
+
+type MockConsumerGroupDescriber struct {
+	mock.Mock
+}
+
+func (d *MockConsumerGroupDescriber) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	args := d.Called(groups)
+	return args.Get(0).([]*sarama.GroupDescription), args.Error(1)
+}
+
+func NewMockConsumerGroupDescriber(groupId string, state string, err error) *MockConsumerGroupDescriber {
+	mockDescriber := &MockConsumerGroupDescriber{}
+	mockDescriber.On("DescribeConsumerGroups", []string{groupId}).Return([]*sarama.GroupDescription{{GroupId: groupId, State: state}}, err)
+	return mockDescriber
+}
+
 //
 // Mock Sarama OffsetManager
 //
@@ -292,19 +334,32 @@ func WithPartitionOffsetManagerMockResetOffset(offset int64, metadata string) Mo
 
 func WithPartitionOffsetManagerMockErrors(errors ...*sarama.ConsumerError) MockPartitionOffsetManagerOption {
 	return func(mockPartitionOffsetManager *MockPartitionOffsetManager) {
-		errChan := make(chan *sarama.ConsumerError)
-		if len(errors) > 0 {
-			go func() {
-				for _, err := range errors {
-					errChan <- err
-				}
-				close(errChan)
-			}()
-		} else {
+		mockPartitionOffsetManager.On("Errors").Return(newConsumerErrorChannel(errors...))
+	}
+}
+
+// WithPartitionOffsetManagerMockErrorsOnce mocks a single call to Errors(), allowing successive
+// calls (e.g. retries with new PartitionOffsetManagers) to return different errors.
+func WithPartitionOffsetManagerMockErrorsOnce(errors ...*sarama.ConsumerError) MockPartitionOffsetManagerOption {
+	return func(mockPartitionOffsetManager *MockPartitionOffsetManager) {
+		mockPartitionOffsetManager.On("Errors").Return(newConsumerErrorChannel(errors...)).Once()
+	}
+}
+
+// newConsumerErrorChannel returns a channel providing the specified errors before being closed
+func newConsumerErrorChannel(errors ...*sarama.ConsumerError) chan *sarama.ConsumerError {
+	errChan := make(chan *sarama.ConsumerError)
+	if len(errors) > 0 {
+		go func() {
+			for _, err := range errors {
+				errChan <- err
+			}
 			close(errChan)
-		}
-		mockPartitionOffsetManager.On("Errors").Return(errChan)
+		}()
+	} else {
+		close(errChan)
 	}
+	return errChan
 }
 
 func WithPartitionOffsetManagerMockClose(err error) MockPartitionOffsetManagerOption {
@@ -32,6 +32,7 @@ type EKKubernetesConfig struct {
 	MemoryLimit   resource.Quantity `json:"memoryLimit,omitempty"`
 	MemoryRequest resource.Quantity `json:"memoryRequest,omitempty"`
 	Replicas      int               `json:"replicas,omitempty"`
+	MaxReplicas   int               `json:"maxReplicas,omitempty"` // Distributed channel only (zero is unbounded)
 }
 
 // EKReceiverConfig has the base Kubernetes fields (Cpu, Memory, Replicas) only
@@ -39,9 +40,40 @@ type EKReceiverConfig struct {
 	EKKubernetesConfig
 }
 
-// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas) only
+// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas) and the dispatcher specific settings
+// (zero values keep the defaults)
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+
+	// DrainTimeoutMillis bounds the wait for in-flight messages when a channel is removed (Consolidated channel only)
+	DrainTimeoutMillis int64 `json:"drainTimeoutMillis,omitempty"`
+
+	// StartupJitterMillis bounds the random delay before the consumer groups are started (Consolidated channel only)
+	StartupJitterMillis int64 `json:"startupJitterMillis,omitempty"`
+
+	// MaxOpenRequests sets the Sarama Net.MaxOpenRequests per broker connection (Consolidated and Distributed channels)
+	MaxOpenRequests int `json:"maxOpenRequests,omitempty"`
+
+	// DialTimeoutMillis sets the Sarama Net.DialTimeout (Consolidated and Distributed channels)
+	DialTimeoutMillis int64 `json:"dialTimeoutMillis,omitempty"`
+
+	// KeepAliveMillis sets the Sarama Net.KeepAlive (Consolidated and Distributed channels)
+	KeepAliveMillis int64 `json:"keepAliveMillis,omitempty"`
+
+	// RebalanceStrategy sets the Sarama Consumer.Group.Rebalance.Strategy by name (Consolidated and Distributed channels)
+	RebalanceStrategy string `json:"rebalanceStrategy,omitempty"`
+
+	// SessionTimeoutMillis sets the Sarama Consumer.Group.Session.Timeout (Consolidated and Distributed channels)
+	SessionTimeoutMillis int64 `json:"sessionTimeoutMillis,omitempty"`
+
+	// HeartbeatIntervalMillis sets the Sarama Consumer.Group.Heartbeat.Interval (Consolidated and Distributed channels)
+	HeartbeatIntervalMillis int64 `json:"heartbeatIntervalMillis,omitempty"`
+
+	// Concurrency bounds the messages handled at once across all subscriptions (Distributed channel only, zero is unbounded)
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// ZoneSpread spreads the dispatcher replicas across the zones (Consolidated channel only)
+	ZoneSpread bool `json:"zoneSpread,omitempty"`
 }
 
 // EKKafkaTopicConfig contains some defaults that are only used if not provided by the channel spec
@@ -63,6 +95,8 @@ type EKKafkaConfig struct {
 	Brokers             string             `json:"brokers,omitempty"`
 	AuthSecretName      string             `json:"authSecretName,omitempty"`
 	AuthSecretNamespace string             `json:"authSecretNamespace,omitempty"`
+	ClientIdPrefix      string             `json:"clientIdPrefix,omitempty"`
+	ClientIdSuffix      string             `json:"clientIdSuffix,omitempty"`
 	Topic               EKKafkaTopicConfig `json:"topic,omitempty"`
 }
 
@@ -73,15 +107,19 @@ type EKSourceConfig struct {
 // EKChannelConfig contains items relevant to the eventing-kafka channels
 // NOTE:  Currently the consolidated channel type does not make use of most of these fields
 type EKChannelConfig struct {
-	Dispatcher EKDispatcherConfig `json:"dispatcher,omitempty"` // Consolidated and Distributed channels
-	Receiver   EKReceiverConfig   `json:"receiver,omitempty"`   // Distributed channel only
-	AdminType  string             `json:"adminType,omitempty"`  // Distributed channel only
+	Dispatcher           EKDispatcherConfig `json:"dispatcher,omitempty"`           // Consolidated and Distributed channels
+	Receiver             EKReceiverConfig   `json:"receiver,omitempty"`             // Distributed channel only
+	AdminType            string             `json:"adminType,omitempty"`            // Distributed channel only
+	DisableTopicDeletion bool               `json:"disableTopicDeletion,omitempty"` // Distributed channel only
 }
 
-// EKSaramaConfig holds the sarama.Config struct (populated separately), and the global Sarama debug logging flag
+// EKSaramaConfig holds the sarama.Config struct (populated separately), the global Sarama debug logging flag,
+// and the Sarama metric families (name prefixes) to report or suppress
 type EKSaramaConfig struct {
-	EnableLogging bool           `json:"enableLogging,omitempty"`
-	Config        *sarama.Config `json:"-"` // Sarama config string is converted to sarama.Config struct, stored here
+	EnableLogging  bool           `json:"enableLogging,omitempty"`
+	AllowedMetrics []string       `json:"allowedMetrics,omitempty"` // Distributed channel only
+	DeniedMetrics  []string       `json:"deniedMetrics,omitempty"`  // Distributed channel only
+	Config         *sarama.Config `json:"-"`                        // Sarama config string is converted to sarama.Config struct, stored here
 }
 
 // EventingKafkaConfig is the main struct that holds the Receiver, Dispatcher, and Kafka sub-items
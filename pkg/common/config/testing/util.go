@@ -22,6 +22,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/system"
 
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 )
@@ -98,6 +99,13 @@ func WithModifiedNamespace(secret *corev1.Secret) {
 	secret.Data[commonconstants.KafkaSecretKeyNamespace] = []byte(ModifiedSecretNamespace)
 }
 
+// WithTlsOnly Replaces The Secret Data With A TLS-Only (No SASL Credentials) Configuration
+func WithTlsOnly(secret *corev1.Secret) {
+	secret.Data = map[string][]byte{
+		commonconfig.TlsEnabled: []byte("true"),
+	}
+}
+
 // WithMissingConfig Removes the Data From The Secret
 func WithMissingConfig(secret *corev1.Secret) {
 	secret.Data = nil
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/Shopify/sarama"
+	"github.com/rickb777/date/period"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,8 +32,9 @@ const (
 func parseTls(secret *corev1.Secret, kafkaAuthConfig *client.KafkaAuthConfig) {
 
 	// self-signed CERTs we need CA CERT, USER CERT and KEY
-	if string(secret.Data[TlsCacert]) != "" {
-		// We have a self-signed TLS cert
+	// (client certificate auth against a proper CA only needs the USER CERT and KEY)
+	if string(secret.Data[TlsCacert]) != "" || string(secret.Data[TlsUsercert]) != "" {
+		// We have a self-signed TLS cert and/or a TLS client cert
 		tls := &client.KafkaTlsConfig{
 			Cacert:   string(secret.Data[TlsCacert]),
 			Usercert: string(secret.Data[TlsUsercert]),
@@ -86,13 +88,19 @@ func GetAuthConfigFromSecret(secret *corev1.Secret) *client.KafkaAuthConfig {
 	// Backwards-compatibility - Support old consolidated secret fields if present
 	// (TLS data is now in the configmap, e.g. sarama.Config.Net.TLS.Config.RootPEMs)
 	_, hasTlsCaCert := secret.Data[TlsCacert]
+	_, hasTlsUsercert := secret.Data[TlsUsercert]
 	_, hasTlsEnabled := secret.Data[TlsEnabled]
-	if hasTlsEnabled || hasTlsCaCert {
+	if hasTlsEnabled || hasTlsCaCert || hasTlsUsercert {
 		parseTls(secret, &authConfig)
 		username = string(secret.Data[SaslUser])
 		saslType = string(secret.Data[SaslType]) // old "saslType" is different than new "sasltype"
 	}
 
+	// A TLS-only secret (e.g. client certificate auth) has no SASL credentials, so leave SASL unset
+	if authConfig.TLS != nil && username == "" && len(secret.Data[constants.KafkaSecretKeyPassword]) == 0 {
+		return &authConfig
+	}
+
 	// If we don't convert the empty string to the "PLAIN" default, the client.HasSameSettings()
 	// function will assume that they should be treated as differences and needlessly reconfigure
 	if saslType == "" {
@@ -127,3 +135,20 @@ func ReplicationFactor(channel *kafkav1beta1.KafkaChannel, configuration *Eventi
 	}
 	return value
 }
+
+// RetentionMillis Gets The RetentionMillis - First From Channel Spec And Then From ConfigMap-Provided Settings
+func RetentionMillis(channel *kafkav1beta1.KafkaChannel, configuration *EventingKafkaConfig, logger *zap.SugaredLogger) int64 {
+	if channel.Spec.RetentionDuration != "" {
+		retentionDuration, err := period.Parse(channel.Spec.RetentionDuration)
+		if err == nil && retentionDuration.DurationApprox() > 0 {
+			return retentionDuration.DurationApprox().Milliseconds()
+		}
+		logger.Warn("Kafka Channel Spec 'RetentionDuration' Invalid - Using Default", zap.String("RetentionDuration", channel.Spec.RetentionDuration))
+	}
+	var value int64
+	if configuration != nil {
+		logger.Debug("Kafka Channel Spec 'RetentionDuration' Not Specified - Using Default", zap.Int64("Value", configuration.Kafka.Topic.DefaultRetentionMillis))
+		value = configuration.Kafka.Topic.DefaultRetentionMillis
+	}
+	return value
+}
@@ -119,9 +119,10 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 
 	// Define The TestCase Struct
 	type TestCase struct {
-		name      string
-		data      map[string][]byte
-		expectNil bool
+		name         string
+		data         map[string][]byte
+		expectNil    bool
+		expectNoSasl bool
 	}
 
 	// Asserts that, if there is a key in the data, that its mapped value is the same as the given value
@@ -170,6 +171,23 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 				SaslPassword: []byte("test-password"),
 			},
 		},
+		{
+			name: "Valid secret, TLS only",
+			data: map[string][]byte{
+				TlsCacert:   []byte("test-cacert"),
+				TlsUsercert: []byte("test-usercert"),
+				TlsUserkey:  []byte("test-userkey"),
+			},
+			expectNoSasl: true,
+		},
+		{
+			name: "Valid secret, TLS only without CA cert",
+			data: map[string][]byte{
+				TlsUsercert: []byte("test-usercert"),
+				TlsUserkey:  []byte("test-userkey"),
+			},
+			expectNoSasl: true,
+		},
 		{
 			name:      "Valid secret, backwards-compatibility, nil data",
 			data:      nil,
@@ -194,6 +212,13 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 			kafkaAuth := GetAuthConfigFromSecret(secret)
 			if testCase.expectNil {
 				assert.Nil(t, kafkaAuth)
+			} else if testCase.expectNoSasl {
+				assert.NotNil(t, kafkaAuth)
+				assert.Nil(t, kafkaAuth.SASL)
+				assert.NotNil(t, kafkaAuth.TLS)
+				assertKey(t, testCase.data, TlsCacert, kafkaAuth.TLS.Cacert)
+				assertKey(t, testCase.data, TlsUsercert, kafkaAuth.TLS.Usercert)
+				assertKey(t, testCase.data, TlsUserkey, kafkaAuth.TLS.Userkey)
 			} else {
 				assert.NotNil(t, kafkaAuth)
 				assert.NotNil(t, kafkaAuth.SASL)
@@ -257,3 +282,29 @@ func TestReplicationFactor(t *testing.T) {
 	actualReplicationFactor = ReplicationFactor(channel, configuration, logger)
 	assert.Equal(t, replicationFactor, actualReplicationFactor)
 }
+
+// Test The RetentionMillis Accessor
+func TestRetentionMillis(t *testing.T) {
+
+	// Test Logger
+	logger := logtesting.TestLogger(t)
+
+	// Test Data
+	defaultRetentionMillis := int64(604800000)
+	configuration := &EventingKafkaConfig{Kafka: EKKafkaConfig{Topic: EKKafkaTopicConfig{DefaultRetentionMillis: defaultRetentionMillis}}}
+
+	// Test The Default Failover Use Case
+	channel := &kafkav1beta1.KafkaChannel{}
+	actualRetentionMillis := RetentionMillis(channel, configuration, logger)
+	assert.Equal(t, defaultRetentionMillis, actualRetentionMillis)
+
+	// Test The Invalid RetentionDuration Failover Use Case
+	channel = &kafkav1beta1.KafkaChannel{Spec: kafkav1beta1.KafkaChannelSpec{RetentionDuration: "invalid"}}
+	actualRetentionMillis = RetentionMillis(channel, configuration, logger)
+	assert.Equal(t, defaultRetentionMillis, actualRetentionMillis)
+
+	// Test The Valid RetentionDuration Use Case
+	channel = &kafkav1beta1.KafkaChannel{Spec: kafkav1beta1.KafkaChannelSpec{RetentionDuration: "PT1H"}}
+	actualRetentionMillis = RetentionMillis(channel, configuration, logger)
+	assert.Equal(t, int64(3600000), actualRetentionMillis)
+}
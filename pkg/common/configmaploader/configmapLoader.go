@@ -3,6 +3,10 @@ package configmaploader
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type ConfigmapLoader func(path string) (map[string]string, error)
@@ -18,3 +22,26 @@ func FromContext(ctx context.Context) (ConfigmapLoader, error) {
 	}
 	return untyped.(func(data string) (map[string]string, error)), nil
 }
+
+// WaitFor loads the configmap at the given path, retrying every interval while it is empty or until the context
+// is done. This tolerates a configmap mounted as an optional volume that doesn't exist yet (the volume is then
+// empty until the configmap is created and the kubelet refreshes it). Any non-empty configmap is returned as is,
+// whatever its format, on the first load.
+func WaitFor(ctx context.Context, loader ConfigmapLoader, path string, interval time.Duration) (map[string]string, error) {
+	var data map[string]string
+	var loadErr error
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		data, loadErr = loader(path)
+		if loadErr != nil {
+			return false, nil
+		}
+		return len(data) > 0, nil
+	}, ctx.Done())
+	if err != nil {
+		if loadErr != nil {
+			return nil, fmt.Errorf("configmap at %s not loaded: %w", path, loadErr)
+		}
+		return nil, fmt.Errorf("configmap at %s is empty: %w", path, err)
+	}
+	return data, nil
+}
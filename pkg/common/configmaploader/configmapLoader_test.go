@@ -0,0 +1,66 @@
+package configmaploader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor(t *testing.T) {
+	const path = "/etc/config-kafka"
+	const key = "eventing-kafka"
+
+	// The configmap is absent (empty volume) on the first loads, then appears
+	loads := 0
+	loader := func(p string) (map[string]string, error) {
+		assert.Equal(t, path, p)
+		loads++
+		if loads < 3 {
+			return map[string]string{}, nil
+		}
+		return map[string]string{key: "value"}, nil
+	}
+
+	data, err := WaitFor(context.Background(), loader, path, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{key: "value"}, data)
+	assert.Equal(t, 3, loads)
+}
+
+func TestWaitForOldFormat(t *testing.T) {
+	// The old config-kafka format has no eventing-kafka key and must be returned without waiting
+	oldFormat := map[string]string{"bootstrapServers": "kafkabroker.kafka:9092"}
+	loads := 0
+	loader := func(string) (map[string]string, error) {
+		loads++
+		return oldFormat, nil
+	}
+
+	data, err := WaitFor(context.Background(), loader, "/etc/config-kafka", time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, oldFormat, data)
+	assert.Equal(t, 1, loads)
+}
+
+func TestWaitForCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Empty configmap
+	data, err := WaitFor(ctx, func(string) (map[string]string, error) {
+		return map[string]string{}, nil
+	}, "/etc/config-kafka", time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Nil(t, data)
+
+	// Load error
+	loadErr := errors.New("load error")
+	data, err = WaitFor(ctx, func(string) (map[string]string, error) {
+		return nil, loadErr
+	}, "/etc/config-kafka", time.Millisecond)
+	assert.True(t, errors.Is(err, loadErr))
+	assert.Nil(t, data)
+}
@@ -64,4 +64,7 @@ const (
 
 	// KafkaTopicConfigRetentionMs is the key in the Sarama TopicDetail ConfigEntries map for retention time (in ms)
 	KafkaTopicConfigRetentionMs = "retention.ms"
+
+	// KafkaTopicConfigMinInSyncReplicas is the key in the Sarama TopicDetail ConfigEntries map for the minimum in-sync replicas
+	KafkaTopicConfigMinInSyncReplicas = "min.insync.replicas"
 )
@@ -62,6 +62,22 @@ func WithTimeout(timeout time.Duration) SaramaConsumerHandlerOption {
 	}
 }
 
+// WithManualCommit makes the handler commit the offset of each message synchronously, once the message
+// has been handled and marked, instead of relying on the periodic auto-commit of the marked offsets.
+func WithManualCommit() SaramaConsumerHandlerOption {
+	return func(handler *SaramaConsumerHandler) {
+		handler.manualCommit = true
+	}
+}
+
+// WithAtMostOnce makes the handler mark and commit the offset of each message before handling it, so that
+// a message is never handled again even if its handling fails or the consumer stops during it.
+func WithAtMostOnce() SaramaConsumerHandlerOption {
+	return func(handler *SaramaConsumerHandler) {
+		handler.atMostOnce = true
+	}
+}
+
 // ConsumerHandler implements sarama.ConsumerGroupHandler and provides some glue code to simplify message handling
 // You must implement KafkaConsumerHandler and create a new SaramaConsumerHandler with it
 type SaramaConsumerHandler struct {
@@ -73,6 +89,12 @@ type SaramaConsumerHandler struct {
 
 	lifecycleListener SaramaConsumerLifecycleListener
 
+	// Commit the offsets synchronously after handling each message
+	manualCommit bool
+
+	// Commit the offsets before handling each message
+	atMostOnce bool
+
 	logger *zap.SugaredLogger
 
 	// Errors channel
@@ -150,6 +172,12 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 			break
 		}
 
+		// Commit the message before handling it when delivering at most once
+		if consumer.atMostOnce {
+			session.MarkMessage(message, "")
+			session.Commit()
+		}
+
 		// We need to control when to cancel Handle calls so give it a downstream context
 		hctx, cancel := context.WithCancel(context.Background())
 
@@ -185,8 +213,11 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 			}
 		}
 
-		if mustMark {
+		if mustMark && !consumer.atMostOnce {
 			session.MarkMessage(message, "") // Mark kafka message as processed
+			if consumer.manualCommit {
+				session.Commit() // Commit the marked offset before handling the next message
+			}
 			if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
 				consumer.logger.Debugw("Message marked", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
 			}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -39,11 +41,12 @@ var mockMessage = sarama.ConsumerMessage{
 }
 
 type mockConsumerGroupSession struct {
-	marked bool
+	marked    bool
+	committed int32
 }
 
 func (m *mockConsumerGroupSession) Commit() {
-	panic("implement me")
+	atomic.AddInt32(&m.committed, 1)
 }
 
 func (m *mockConsumerGroupSession) Claims() map[string][]int32 {
@@ -168,3 +171,71 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+type ackingMessageHandler struct {
+	mockMessageHandler
+	ack chan struct{}
+}
+
+func (m ackingMessageHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) (bool, error) {
+	<-m.ack
+	return true, nil
+}
+
+func TestManualCommit(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []SaramaConsumerHandlerOption
+		wantCommitted int32
+		wantEarly     int32
+	}{
+		{
+			name:          "auto commit",
+			wantCommitted: 0,
+		},
+		{
+			name:          "manual commit",
+			options:       []SaramaConsumerHandlerOption{WithManualCommit()},
+			wantCommitted: 1,
+		},
+		{
+			name:          "at most once",
+			options:       []SaramaConsumerHandlerOption{WithAtMostOnce()},
+			wantCommitted: 1,
+			wantEarly:     1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errorCh := make(chan error, 1)
+			handler := ackingMessageHandler{ack: make(chan struct{})}
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, errorCh, test.options...)
+
+			session := mockConsumerGroupSession{}
+			claim := mockConsumerGroupClaim{msg: &mockMessage}
+
+			done := make(chan struct{})
+			go func() {
+				_ = cgh.ConsumeClaim(&session, claim)
+				close(done)
+			}()
+
+			// The offset must not be committed until the subscriber acks the message, unless delivering at most once
+			time.Sleep(50 * time.Millisecond)
+			if committed := atomic.LoadInt32(&session.committed); committed != test.wantEarly {
+				t.Errorf("Offset committed %d times before the message was acked, want %d", committed, test.wantEarly)
+			}
+
+			close(handler.ack)
+			<-done
+
+			if committed := atomic.LoadInt32(&session.committed); committed != test.wantCommitted {
+				t.Errorf("Offset committed %d times, want %d", committed, test.wantCommitted)
+			}
+			if !session.marked {
+				t.Errorf("Session was not marked")
+			}
+			close(errorCh)
+		})
+	}
+}
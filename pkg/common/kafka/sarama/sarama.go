@@ -18,10 +18,12 @@ package sarama
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/ghodss/yaml"
@@ -86,8 +88,10 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 
 	// Merge The ConfigMap Settings Into The Provided Config
 	saramaShell := &struct {
-		EnableLogging bool   `json:"enableLogging"`
-		Config        string `json:"config"`
+		EnableLogging  bool     `json:"enableLogging"`
+		AllowedMetrics []string `json:"allowedMetrics"`
+		DeniedMetrics  []string `json:"deniedMetrics"`
+		Config         string   `json:"config"`
 	}{}
 	var saramaConfigString string
 
@@ -104,6 +108,8 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 			ekConfig.Sarama.EnableLogging = false
 		} else {
 			ekConfig.Sarama.EnableLogging = saramaShell.EnableLogging
+			ekConfig.Sarama.AllowedMetrics = saramaShell.AllowedMetrics
+			ekConfig.Sarama.DeniedMetrics = saramaShell.DeniedMetrics
 			saramaConfigString = saramaShell.Config
 		}
 	}
@@ -113,7 +119,7 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 		WithDefaults().
 		FromYaml(saramaConfigString).
 		WithAuth(ekConfig.Auth).
-		WithClientId(clientId).
+		WithClientId(client.ComposeClientId(ekConfig.Kafka.ClientIdPrefix, clientId, ekConfig.Kafka.ClientIdSuffix)).
 		Build(ctx)
 
 	return ekConfig, err
@@ -227,6 +233,58 @@ func loadEventingKafkaSettings(configMap map[string]string) (*commonconfig.Event
 	return eventingKafkaConfig, nil
 }
 
+// ApplyDispatcherSettings Applies The Dispatcher's Per-Broker Connection Limits, Rebalance Strategy And Group Timeouts (If Specified) To The Sarama Config
+func ApplyDispatcherSettings(ctx context.Context, ekConfig *commonconfig.EventingKafkaConfig) error {
+	dispatcherConfig := ekConfig.Channel.Dispatcher
+	saramaConfig, err := client.NewConfigBuilder().
+		WithExisting(ekConfig.Sarama.Config).
+		WithNetLimits(dispatcherConfig.MaxOpenRequests,
+			time.Duration(dispatcherConfig.DialTimeoutMillis)*time.Millisecond,
+			time.Duration(dispatcherConfig.KeepAliveMillis)*time.Millisecond).
+		WithRebalanceStrategy(dispatcherConfig.RebalanceStrategy).
+		WithGroupTimeouts(time.Duration(dispatcherConfig.SessionTimeoutMillis)*time.Millisecond,
+			time.Duration(dispatcherConfig.HeartbeatIntervalMillis)*time.Millisecond).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	ekConfig.Sarama.Config = saramaConfig
+	return nil
+}
+
+// topicErrorReasons Maps The Kafka Errors Returned When Creating / Describing A Topic To Condition Reasons
+var topicErrorReasons = map[sarama.KError]string{
+	sarama.ErrInvalidTopic:               "InvalidTopic",
+	sarama.ErrInvalidPartitions:          "InvalidPartitions",
+	sarama.ErrInvalidReplicationFactor:   "InvalidReplicationFactor",
+	sarama.ErrInvalidReplicaAssignment:   "InvalidReplicaAssignment",
+	sarama.ErrInvalidConfig:              "InvalidTopicConfig",
+	sarama.ErrPolicyViolation:            "PolicyViolation",
+	sarama.ErrTopicAuthorizationFailed:   "TopicAuthorizationFailed",
+	sarama.ErrClusterAuthorizationFailed: "ClusterAuthorizationFailed",
+	sarama.ErrUnknownTopicOrPartition:    "UnknownTopic",
+	sarama.ErrNotController:              "NotController",
+	sarama.ErrRequestTimedOut:            "RequestTimedOut",
+}
+
+// TopicErrorReason Returns A Condition Reason Naming The Kafka Error (Sarama TopicError Or KError) Behind
+// A Failed Topic Operation, Or The Specified Default Reason For Any Other Error
+func TopicErrorReason(err error, defaultReason string) string {
+	var topicError *sarama.TopicError
+	var kError sarama.KError
+	switch {
+	case errors.As(err, &topicError):
+		kError = topicError.Err
+	case errors.As(err, &kError):
+	default:
+		return defaultReason
+	}
+	if reason, ok := topicErrorReasons[kError]; ok {
+		return reason
+	}
+	return defaultReason
+}
+
 // AuthFromSarama creates a KafkaAuthConfig using the SASL settings from
 // a given Sarama config, or nil if there is no SASL user in that config
 func AuthFromSarama(config *sarama.Config) *client.KafkaAuthConfig {
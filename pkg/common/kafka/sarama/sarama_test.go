@@ -19,6 +19,8 @@ package sarama
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -202,6 +204,7 @@ func TestLoadDefaultSaramaSettings(t *testing.T) {
 			assert.Nil(t, err)
 			verifySaramaSettings(t, settings)
 			verifyEventingKafkaSettings(t, settings)
+			assert.Equal(t, 0, settings.Channel.Dispatcher.Concurrency) // Unbounded Unless Specified
 		})
 	}
 }
@@ -522,11 +525,15 @@ func TestLoadSettings(t *testing.T) {
 
 	// Define The TestCase Struct
 	type TestCase struct {
-		name           string
-		config         map[string]string
-		authConfig     *client.KafkaAuthConfig
-		expectErr      bool
-		expectDefaults bool
+		name                 string
+		config               map[string]string
+		authConfig           *client.KafkaAuthConfig
+		clientId             string
+		expectErr            bool
+		expectDefaults       bool
+		expectClientId       string
+		expectAllowedMetrics []string
+		expectDeniedMetrics  []string
 	}
 
 	// Create The TestCases
@@ -559,6 +566,26 @@ func TestLoadSettings(t *testing.T) {
 			authConfig:     &client.KafkaAuthConfig{SASL: &client.KafkaSaslConfig{User: ""}},
 			expectDefaults: true, // The empty auth will remove the user/password from the OldSaramaConfig
 		},
+		{
+			name: "ClientId Prefix And Suffix",
+			config: map[string]string{
+				constants.VersionConfigKey:               constants.CurrentConfigVersion,
+				constants.SaramaSettingsConfigKey:        commontesting.OldSaramaConfig,
+				constants.EventingKafkaSettingsConfigKey: "kafka:\n  clientIdPrefix: cluster-a\n  clientIdSuffix: tenant-b\n",
+			},
+			clientId:       "kafka-ch-dispatcher",
+			expectClientId: "cluster-a-kafka-ch-dispatcher-tenant-b",
+		},
+		{
+			name: "Metric Families",
+			config: map[string]string{
+				constants.VersionConfigKey:        constants.CurrentConfigVersion,
+				constants.SaramaSettingsConfigKey: "allowedMetrics:\n  - request-\ndeniedMetrics:\n  - requests-in-flight-for-broker-\n",
+			},
+			expectDefaults:       true,
+			expectAllowedMetrics: []string{"request-"},
+			expectDeniedMetrics:  []string{"requests-in-flight-for-broker-"},
+		},
 		{
 			name:           "Empty Config (Defaults Only)",
 			config:         map[string]string{constants.SaramaSettingsConfigKey: ""},
@@ -576,7 +603,7 @@ func TestLoadSettings(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 
 			// Perform The Test (note that the eventingKafkaConfig is tested separately in TestLoadEventingKafkaSettings)
-			settings, err := LoadSettings(context.TODO(), "", testCase.config, mockGetAuth(testCase.authConfig))
+			settings, err := LoadSettings(context.TODO(), testCase.clientId, testCase.config, mockGetAuth(testCase.authConfig))
 
 			// Verify Expected State
 			if testCase.expectErr {
@@ -592,6 +619,11 @@ func TestLoadSettings(t *testing.T) {
 					assert.Equal(t, commontesting.OldUsername, settings.Sarama.Config.Net.SASL.User)
 					assert.Equal(t, commontesting.OldPassword, settings.Sarama.Config.Net.SASL.Password)
 				}
+				if testCase.expectClientId != "" {
+					assert.Equal(t, testCase.expectClientId, settings.Sarama.Config.ClientID)
+				}
+				assert.Equal(t, testCase.expectAllowedMetrics, settings.Sarama.AllowedMetrics)
+				assert.Equal(t, testCase.expectDeniedMetrics, settings.Sarama.DeniedMetrics)
 			}
 		})
 	}
@@ -650,6 +682,51 @@ func TestAuthFromSarama(t *testing.T) {
 	}
 }
 
+func TestApplyDispatcherSettings(t *testing.T) {
+	ctx := context.TODO()
+
+	// Valid Limits Reach The Sarama Config (Without Disturbing Other Settings)
+	ekConfig := &commonconfig.EventingKafkaConfig{Sarama: commonconfig.EKSaramaConfig{Config: sarama.NewConfig()}}
+	ekConfig.Sarama.Config.ClientID = "test-client-id"
+	ekConfig.Channel.Dispatcher.MaxOpenRequests = 3
+	ekConfig.Channel.Dispatcher.DialTimeoutMillis = 2500
+	ekConfig.Channel.Dispatcher.KeepAliveMillis = 45000
+	ekConfig.Channel.Dispatcher.RebalanceStrategy = sarama.StickyBalanceStrategyName
+	ekConfig.Channel.Dispatcher.SessionTimeoutMillis = 60000
+	ekConfig.Channel.Dispatcher.HeartbeatIntervalMillis = 15000
+	assert.Nil(t, ApplyDispatcherSettings(ctx, ekConfig))
+	assert.Equal(t, 3, ekConfig.Sarama.Config.Net.MaxOpenRequests)
+	assert.Equal(t, 2500*time.Millisecond, ekConfig.Sarama.Config.Net.DialTimeout)
+	assert.Equal(t, 45*time.Second, ekConfig.Sarama.Config.Net.KeepAlive)
+	assert.Equal(t, sarama.BalanceStrategySticky, ekConfig.Sarama.Config.Consumer.Group.Rebalance.Strategy)
+	assert.Equal(t, time.Minute, ekConfig.Sarama.Config.Consumer.Group.Session.Timeout)
+	assert.Equal(t, 15*time.Second, ekConfig.Sarama.Config.Consumer.Group.Heartbeat.Interval)
+	assert.Equal(t, "test-client-id", ekConfig.Sarama.Config.ClientID)
+
+	// Heartbeats Not Below A Third Of The Session Timeout Are Rejected
+	ekConfig.Channel.Dispatcher.HeartbeatIntervalMillis = 20000
+	assert.NotNil(t, ApplyDispatcherSettings(ctx, ekConfig))
+	ekConfig.Channel.Dispatcher.HeartbeatIntervalMillis = 15000
+
+	// Invalid Rebalance Strategies Are Rejected
+	ekConfig.Channel.Dispatcher.RebalanceStrategy = "cooperative-sticky"
+	assert.NotNil(t, ApplyDispatcherSettings(ctx, ekConfig))
+
+	// Invalid Limits Are Rejected
+	ekConfig.Channel.Dispatcher.RebalanceStrategy = ""
+	ekConfig.Channel.Dispatcher.MaxOpenRequests = -1
+	assert.NotNil(t, ApplyDispatcherSettings(ctx, ekConfig))
+}
+
+func TestTopicErrorReason(t *testing.T) {
+	errMsg := "replication factor larger than available brokers"
+	assert.Equal(t, "InvalidReplicationFactor", TopicErrorReason(&sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor, ErrMsg: &errMsg}, "TopicFailed"))
+	assert.Equal(t, "PolicyViolation", TopicErrorReason(fmt.Errorf("wrapped: %w", &sarama.TopicError{Err: sarama.ErrPolicyViolation}), "TopicFailed"))
+	assert.Equal(t, "TopicAuthorizationFailed", TopicErrorReason(sarama.ErrTopicAuthorizationFailed, "TopicFailed"))
+	assert.Equal(t, "TopicFailed", TopicErrorReason(&sarama.TopicError{Err: sarama.ErrBrokerNotAvailable}, "TopicFailed"))
+	assert.Equal(t, "TopicFailed", TopicErrorReason(errors.New("test error"), "TopicFailed"))
+}
+
 func TestStringifyHeaders(t *testing.T) {
 
 	// Define The TestCase Struct
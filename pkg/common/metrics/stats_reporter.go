@@ -19,6 +19,7 @@ package metrics
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +33,7 @@ import (
 // StatsReporter defines the interface for sending ingress metrics.
 type StatsReporter interface {
 	Report(ReportingList)
+	ReportGauge(name string, value float64, tags map[string]string)
 	Shutdown()
 }
 
@@ -71,11 +73,32 @@ var regexDescriptions = []struct {
 	{regexp.MustCompile(`all brokers-for-broker-`), `broker `},
 }
 
+// regexSpecificPartition extracts the partition from the name of a partition-scoped Sarama metric, such as
+// "consumer-fetch-rate-for-topic-my-topic-for-partition-3".  The partition is reported as a tag rather than
+// as part of the metric name, so that the metrics of all partitions share a single name and description.
+var regexSpecificPartition = regexp.MustCompile(`^(.+)-for-partition-(\d+)$`)
+
+// regexSpecificTopicOrBroker splits the name of a topic or broker scoped Sarama metric, such as
+// "record-send-rate-for-topic-my-topic", into its prefix and the topic or broker.  The topic or broker is
+// sanitized like a tag value, since topic names may contain characters (such as dots) that metrics backends reject.
+var regexSpecificTopicOrBroker = regexp.MustCompile(`^(.+-for-(?:topic|broker)-)(.+)$`)
+
+// partitionTagKey is the tag (label) key used for the partition of a partition-scoped Sarama metric
+const partitionTagKey = "partition"
+
+// maxTagValueLength is the longest tag value that is reported, matching the OpenCensus tag value limit
+const maxTagValueLength = 255
+
+// disallowedTagValueChars matches the characters that are replaced in reported tag values, such as the
+// dots and slashes of topic names, which some metrics backends reject
+var disallowedTagValueChars = regexp.MustCompile(`[^a-zA-Z0-9_:-]`)
+
 // The saramaMetricInfo struct holds information related to a particular Sarama metric, used when creating TimeSeries
 type saramaMetricInfo struct {
 	Name        string
 	Description string
 	Unit        metricdata.Unit
+	Partition   string // Empty unless the metric is partition-scoped
 }
 
 // Since regular expressions are somewhat costly and the metrics are repetitive, this cache will hold a simple
@@ -88,17 +111,45 @@ type ReportingList = map[string]ReportingItem
 
 // Define StatsReporter Structure, which implements the OpenCensus Producer interface
 type Reporter struct {
-	logger  *zap.Logger
-	metrics map[string]*metricdata.Metric
-	once    sync.Once // Used to add a particular metric producer to the OpenCensus global manager only one time
+	logger       *zap.Logger
+	metrics      map[string]*metricdata.Metric
+	metricsMutex sync.Mutex // Protects the metrics map, which is written by the reporting functions and read by Read()
+	once         sync.Once  // Used to add a particular metric producer to the OpenCensus global manager only one time
+
+	// allowedFamilies and deniedFamilies are Sarama metric name prefixes (such as "requests-in-flight") used to
+	// suppress unwanted metric families; an empty allowedFamilies list allows every family that isn't denied
+	allowedFamilies []string
+	deniedFamilies  []string
+}
+
+// StatsReporterOption customizes the Reporter created by NewStatsReporter
+type StatsReporterOption func(*Reporter)
+
+// WithAllowedMetricFamilies only reports the Sarama metrics whose name starts with one of the given prefixes
+func WithAllowedMetricFamilies(prefixes ...string) StatsReporterOption {
+	return func(r *Reporter) {
+		r.allowedFamilies = append(r.allowedFamilies, prefixes...)
+	}
+}
+
+// WithDeniedMetricFamilies never reports the Sarama metrics whose name starts with one of the given prefixes
+// (such as "requests-in-flight-for-broker-" to drop the per-broker variant of that metric)
+func WithDeniedMetricFamilies(prefixes ...string) StatsReporterOption {
+	return func(r *Reporter) {
+		r.deniedFamilies = append(r.deniedFamilies, prefixes...)
+	}
 }
 
 // StatsReporter Constructor
-func NewStatsReporter(log *zap.Logger) StatsReporter {
-	return &Reporter{
+func NewStatsReporter(log *zap.Logger, options ...StatsReporterOption) StatsReporter {
+	reporter := &Reporter{
 		logger:  log,
 		metrics: make(map[string]*metricdata.Metric),
 	}
+	for _, option := range options {
+		option(reporter)
+	}
+	return reporter
 }
 
 //
@@ -107,9 +158,10 @@ func NewStatsReporter(log *zap.Logger) StatsReporter {
 func (r *Reporter) Report(list ReportingList) {
 
 	// Add this Reporter as an OpenCensus Producer, if it has not been done already
-	r.once.Do(func() {
-		metricproducer.GlobalManager().AddProducer(r)
-	})
+	r.addProducer()
+
+	r.metricsMutex.Lock()
+	defer r.metricsMutex.Unlock()
 
 	// Validate The Metrics
 	// Loop Over The Observed Metrics
@@ -118,18 +170,141 @@ func (r *Reporter) Report(list ReportingList) {
 	}
 }
 
+// ReportGauge reports a single point-in-time value for the named gauge, for components that have individual
+// values to export rather than a Sarama registry.  The tags become the labels of the gauge, each distinct set of
+// tag values being its own TimeSeries of the gauge, and reporting the same name and tags again replaces the previous
+// value.  All the TimeSeries of a gauge share the same tag keys, so reporting the name with different tag keys
+// replaces all of its previous values.  Tag values are sanitized (see sanitizeTagValue).  Example /metrics output
+// for the name "active_consumer_groups" reported with the tags {"channel": "channel-a"} and {"channel": "channel-b"}:
+//
+//   # HELP eventing_kafka_active_consumer_groups active_consumer_groups
+//   # TYPE eventing_kafka_active_consumer_groups gauge
+//   eventing_kafka_active_consumer_groups{channel="channel-a"} 3
+//   eventing_kafka_active_consumer_groups{channel="channel-b"} 1
+//
+func (r *Reporter) ReportGauge(name string, value float64, tags map[string]string) {
+
+	// Add this Reporter as an OpenCensus Producer, if it has not been done already
+	r.addProducer()
+
+	// Sort the tag keys so that the labels of the gauge are consistent from one report to the next
+	tagKeys := make([]string, 0, len(tags))
+	for key := range tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	labelKeys := make([]metricdata.LabelKey, len(tagKeys))
+	labelValues := make([]metricdata.LabelValue, len(tagKeys))
+	for i, key := range tagKeys {
+		labelKeys[i] = metricdata.LabelKey{Key: key}
+		labelValues[i] = metricdata.NewLabelValue(sanitizeTagValue(tags[key]))
+	}
+
+	timeNow := time.Now()
+	info := getMetricInfo(name)
+
+	r.metricsMutex.Lock()
+	defer r.metricsMutex.Unlock()
+
+	// A new Metric is created rather than modifying the existing one, which may still be in use by an exporter
+	metric := &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name:        info.Name,
+			Description: info.Description,
+			Unit:        info.Unit,
+			Type:        metricdata.TypeGaugeFloat64,
+			LabelKeys:   labelKeys,
+		},
+		Resource: &resource.Resource{Type: name},
+	}
+
+	// Keep the TimeSeries of the other tag values, unless the tag keys have changed
+	if existing, ok := r.metrics[name]; ok && equalLabelKeys(existing.Descriptor.LabelKeys, labelKeys) {
+		for _, timeSeries := range existing.TimeSeries {
+			if !equalLabelValues(timeSeries.LabelValues, labelValues) {
+				metric.TimeSeries = append(metric.TimeSeries, timeSeries)
+			}
+		}
+	}
+	metric.TimeSeries = append(metric.TimeSeries, &metricdata.TimeSeries{
+		LabelValues: labelValues,
+		Points:      []metricdata.Point{metricdata.NewFloat64Point(timeNow, value)},
+		StartTime:   timeNow,
+	})
+	r.metrics[name] = metric
+}
+
+// equalLabelKeys returns true if both lists contain the same label keys in the same order
+func equalLabelKeys(a []metricdata.LabelKey, b []metricdata.LabelKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalLabelValues returns true if both lists contain the same label values in the same order
+func equalLabelValues(a []metricdata.LabelValue, b []metricdata.LabelValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// addProducer adds this Reporter to the OpenCensus global manager, if it has not been done already
+func (r *Reporter) addProducer() {
+	r.once.Do(func() {
+		metricproducer.GlobalManager().AddProducer(r)
+	})
+}
+
 // Remove this producer from the global manager's list so that it will no longer call Read()
 func (r *Reporter) Shutdown() {
 	metricproducer.GlobalManager().DeleteProducer(r)
 }
 
-// Read implements the OpenCensus Producer interface
+// Read implements the OpenCensus Producer interface.  The metrics sharing a name, such as the partitions of a
+// partition-scoped Sarama metric, are returned as a single Metric with the TimeSeries of all of them, since the
+// exporters expect exactly one Metric (descriptor) per name.
 func (r *Reporter) Read() []*metricdata.Metric {
-	metricsArray := make([]*metricdata.Metric, len(r.metrics))
-	index := 0
-	for name := range r.metrics {
-		metricsArray[index] = r.metrics[name]
-		index++
+	r.metricsMutex.Lock()
+	defer r.metricsMutex.Unlock()
+
+	// Sort the keys so that the TimeSeries of the merged metrics are in a consistent order
+	keys := make([]string, 0, len(r.metrics))
+	for key := range r.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metricsArray := make([]*metricdata.Metric, 0, len(keys))
+	metricsByName := make(map[string]*metricdata.Metric, len(keys))
+	for _, key := range keys {
+		metric := r.metrics[key]
+		merged, ok := metricsByName[metric.Descriptor.Name]
+		if !ok {
+			// Copy the metric so that merging TimeSeries into it doesn't modify the stored one
+			merged = &metricdata.Metric{
+				Descriptor: metric.Descriptor,
+				TimeSeries: append([]*metricdata.TimeSeries(nil), metric.TimeSeries...),
+				Resource:   metric.Resource,
+			}
+			metricsByName[metric.Descriptor.Name] = merged
+			metricsArray = append(metricsArray, merged)
+		} else if equalLabelKeys(merged.Descriptor.LabelKeys, metric.Descriptor.LabelKeys) {
+			merged.TimeSeries = append(merged.TimeSeries, metric.TimeSeries...)
+		} else {
+			r.logger.Warn("Skipping metric with the same name but different labels than another one", zap.String("Metric", key))
+		}
 	}
 	return metricsArray
 }
@@ -139,6 +314,11 @@ func (r *Reporter) Read() []*metricdata.Metric {
 // requires a collection of TimeSeries values so that they appear in the exporter properly as "one name with different
 // tags for the percentile values".
 func (r *Reporter) recordMetric(metricKey string, item ReportingItem) {
+	// Skip the metric families that have been suppressed
+	if !r.isFamilyEnabled(getMetricInfo(metricKey)) {
+		return
+	}
+
 	timeNow := time.Now()
 
 	if isPercentileMetric(item) {
@@ -175,16 +355,19 @@ func (r *Reporter) recordMetric(metricKey string, item ReportingItem) {
 		//
 		for subKey, value := range item {
 			info := getMetricSubInfo(metricKey, subKey)
-			r.metrics[info.Name] = &metricdata.Metric{
+			labelKeys, labelValues := partitionLabels(info)
+			r.metrics[fmt.Sprintf("%s.%s", metricKey, subKey)] = &metricdata.Metric{
 				Descriptor: metricdata.Descriptor{
 					Name:        info.Name,
 					Description: info.Description,
 					Unit:        info.Unit,
 					Type:        metricdata.TypeGaugeFloat64,
+					LabelKeys:   labelKeys,
 				},
 				TimeSeries: []*metricdata.TimeSeries{{
-					Points:    []metricdata.Point{r.newPoint(timeNow, value)},
-					StartTime: timeNow,
+					LabelValues: labelValues,
+					Points:      []metricdata.Point{r.newPoint(timeNow, value)},
+					StartTime:   timeNow,
 				}},
 				Resource: &resource.Resource{Type: info.Name},
 			}
@@ -206,6 +389,7 @@ func (r *Reporter) recordMetric(metricKey string, item ReportingItem) {
 func (r *Reporter) recordPercentileMetric(metricTime time.Time, metricKey string, item ReportingItem) {
 
 	info := getMetricInfo(metricKey)
+	partitionKeys, partitionValues := partitionLabels(info)
 
 	// Create a TimeSeries for each percentile item in the ReportingItem provided
 	timeSeries := make([]*metricdata.TimeSeries, 0, 10)
@@ -217,7 +401,7 @@ func (r *Reporter) recordPercentileMetric(metricTime time.Time, metricKey string
 		// Count isn't the same unit as anything else, so don't put it in this timeseries
 		if key != "count" {
 			timeSeries = append(timeSeries, &metricdata.TimeSeries{
-				LabelValues: []metricdata.LabelValue{{Value: label, Present: true}},
+				LabelValues: append([]metricdata.LabelValue{{Value: label, Present: true}}, partitionValues...),
 				Points:      []metricdata.Point{r.newPoint(metricTime, value)},
 			})
 		}
@@ -231,7 +415,7 @@ func (r *Reporter) recordPercentileMetric(metricTime time.Time, metricKey string
 			Description: info.Description,
 			Unit:        info.Unit,
 			Type:        metricdata.TypeGaugeFloat64, // Because some fields like "mean" are always floats
-			LabelKeys:   []metricdata.LabelKey{{Key: "percentile"}},
+			LabelKeys:   append([]metricdata.LabelKey{{Key: "percentile"}}, partitionKeys...),
 		},
 		TimeSeries: timeSeries,
 		Resource:   &resource.Resource{Type: metricKey},
@@ -239,23 +423,53 @@ func (r *Reporter) recordPercentileMetric(metricTime time.Time, metricKey string
 
 	// Put the count, if present, in its own metric, as it is not the same type as the other values
 	if countValue, ok := item["count"]; ok {
-		countName := metricKey + "_count"
-		r.metrics[countName] = &metricdata.Metric{
+		countName := info.Name + "_count"
+		r.metrics[metricKey+"_count"] = &metricdata.Metric{
 			Descriptor: metricdata.Descriptor{
 				Name:        countName,
 				Description: info.Description + " (count)",
 				Unit:        metricdata.UnitDimensionless,
 				Type:        metricdata.TypeGaugeInt64, // a count is always an int
+				LabelKeys:   partitionKeys,
 			},
 			TimeSeries: []*metricdata.TimeSeries{{
-				Points:    []metricdata.Point{r.newPoint(metricTime, countValue)},
-				StartTime: metricTime,
+				LabelValues: partitionValues,
+				Points:      []metricdata.Point{r.newPoint(metricTime, countValue)},
+				StartTime:   metricTime,
 			}},
 			Resource: &resource.Resource{Type: countName},
 		}
 	}
 }
 
+// partitionLabels returns the "partition" label key and value for a partition-scoped metric, or nil slices
+// for any other metric
+func partitionLabels(info saramaMetricInfo) ([]metricdata.LabelKey, []metricdata.LabelValue) {
+	if info.Partition == "" {
+		return nil, nil
+	}
+	return []metricdata.LabelKey{{Key: partitionTagKey}}, []metricdata.LabelValue{metricdata.NewLabelValue(info.Partition)}
+}
+
+// isFamilyEnabled returns whether the metric described by the info is allowed (or not denied) by the
+// family prefixes this Reporter was configured with
+func (r *Reporter) isFamilyEnabled(info saramaMetricInfo) bool {
+	for _, prefix := range r.deniedFamilies {
+		if strings.HasPrefix(info.Name, prefix) {
+			return false
+		}
+	}
+	if len(r.allowedFamilies) == 0 {
+		return true
+	}
+	for _, prefix := range r.allowedFamilies {
+		if strings.HasPrefix(info.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // newPoint creates a Point structure using the specific type of the value provided.
 // Note that currently all of the mechanisms for generating a Point do exactly the same
 // thing, and that Point.Value is an interface{} internally, so the only real benefit of
@@ -294,6 +508,17 @@ func isPercentileMetric(item ReportingItem) bool {
 	return false
 }
 
+// sanitizeTagValue replaces the characters of a tag value (such as a topic or broker name, including those of
+// topic or broker scoped metric names) which metrics
+// backends may reject with underscores, and caps its length.  The same input always produces the same output.
+func sanitizeTagValue(value string) string {
+	sanitized := disallowedTagValueChars.ReplaceAllString(value, "_")
+	if len(sanitized) > maxTagValueLength {
+		sanitized = sanitized[:maxTagValueLength]
+	}
+	return sanitized
+}
+
 // getMetricUnit returns the proper unit for a given Sarama metric name
 func getMetricUnit(metricKey string) metricdata.Unit {
 	if strings.Contains(metricKey, "-in-ms") {
@@ -307,15 +532,30 @@ func getMetricInfo(metricKey string) saramaMetricInfo {
 	if cachedReplacement, ok := replacementCache[metricKey]; ok {
 		return cachedReplacement
 	}
-	newString := metricKey
+	// Partition-scoped metrics are named (and described) without the partition, which becomes a tag instead
+	name := metricKey
+	partition := ""
+	if match := regexSpecificPartition.FindStringSubmatch(metricKey); match != nil {
+		name = match[1]
+		partition = match[2]
+	}
+
+	newString := name
 	for _, replacement := range regexDescriptions {
 		newString = replacement.Search.ReplaceAllString(newString, replacement.Replace)
 	}
 
+	// The description keeps the original topic or broker, as only the name and tags are subject to restrictions
+	sanitizedName := name
+	if match := regexSpecificTopicOrBroker.FindStringSubmatch(name); match != nil {
+		sanitizedName = match[1] + sanitizeTagValue(match[2])
+	}
+
 	info := saramaMetricInfo{
-		Name:        metricKey,
+		Name:        sanitizedName,
 		Description: newString,
-		Unit:        getMetricUnit(metricKey),
+		Unit:        getMetricUnit(name),
+		Partition:   partition,
 	}
 	replacementCache[metricKey] = info
 	return info
@@ -328,9 +568,8 @@ func getMetricSubInfo(main string, sub string) saramaMetricInfo {
 	}
 	// Run through the list of known replacements that should be made (multiple replacements may happen)
 	info := getMetricInfo(main)
-	info.Name = fmt.Sprintf("%s.%s", main, sub)
+	info.Name = fmt.Sprintf("%s.%s", info.Name, sub)
 	info.Description += ": " + getSubDescription(sub)
-	info.Unit = getMetricUnit(main)
 	replacementCache[main+sub] = info
 	return info
 }
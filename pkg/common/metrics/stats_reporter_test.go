@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +68,179 @@ func TestMetricsServer_Report(t *testing.T) {
 	assert.Equal(t, msgCount, messageValue)
 }
 
+// Test The Reporter's ReportGauge() Functionality
+func TestReporterReportGauge(t *testing.T) {
+	reporter := createTestReporter(t)
+	defer reporter.Shutdown()
+
+	// Report A Custom Gauge With Tags
+	gaugeName := "test_custom_gauge"
+	reporter.ReportGauge(gaugeName, 3, map[string]string{"zone": "zone-a", "channel": "test-channel"})
+
+	// Verify The Gauge Was Created With Sorted Labels And The Reported Value
+	savedMetric := reporter.metrics[gaugeName]
+	require.NotNil(t, savedMetric)
+	assert.Equal(t, gaugeName, savedMetric.Descriptor.Name)
+	assert.Equal(t, metricdata.TypeGaugeFloat64, savedMetric.Descriptor.Type)
+	assert.Equal(t, []metricdata.LabelKey{{Key: "channel"}, {Key: "zone"}}, savedMetric.Descriptor.LabelKeys)
+	require.Equal(t, 1, len(savedMetric.TimeSeries))
+	assert.Equal(t, []metricdata.LabelValue{metricdata.NewLabelValue("test-channel"), metricdata.NewLabelValue("zone-a")}, savedMetric.TimeSeries[0].LabelValues)
+	require.Equal(t, 1, len(savedMetric.TimeSeries[0].Points))
+	assert.Equal(t, float64(3), savedMetric.TimeSeries[0].Points[0].Value)
+
+	// Other Tag Values Are Reported As Additional TimeSeries Of The Same Gauge
+	reporter.ReportGauge(gaugeName, 4, map[string]string{"zone": "zone-b", "channel": "test-channel"})
+	metricsArray := reporter.Read()
+	require.Equal(t, 1, len(metricsArray))
+	require.Equal(t, 2, len(metricsArray[0].TimeSeries))
+	assert.Equal(t, float64(3), metricsArray[0].TimeSeries[0].Points[0].Value)
+	assert.Equal(t, []metricdata.LabelValue{metricdata.NewLabelValue("test-channel"), metricdata.NewLabelValue("zone-b")}, metricsArray[0].TimeSeries[1].LabelValues)
+	assert.Equal(t, float64(4), metricsArray[0].TimeSeries[1].Points[0].Value)
+
+	// Reporting The Same Tag Values Again Replaces Their Previous Value
+	reporter.ReportGauge(gaugeName, 6, map[string]string{"zone": "zone-a", "channel": "test-channel"})
+	metricsArray = reporter.Read()
+	require.Equal(t, 1, len(metricsArray))
+	require.Equal(t, 2, len(metricsArray[0].TimeSeries))
+	assert.Equal(t, float64(4), metricsArray[0].TimeSeries[0].Points[0].Value)
+	assert.Equal(t, float64(6), metricsArray[0].TimeSeries[1].Points[0].Value)
+
+	// Reporting Different Tag Keys Replaces All The Previous Values
+	reporter.ReportGauge(gaugeName, 5, nil)
+	metricsArray = reporter.Read()
+	require.Equal(t, 1, len(metricsArray))
+	require.Equal(t, 1, len(metricsArray[0].TimeSeries))
+	assert.Empty(t, metricsArray[0].Descriptor.LabelKeys)
+	assert.Equal(t, float64(5), metricsArray[0].TimeSeries[0].Points[0].Value)
+}
+
+// Test That Topic And Broker Tag Values Are Sanitized Consistently
+func TestReporterReportGaugeSanitizesTags(t *testing.T) {
+	reporter := createTestReporter(t)
+	defer reporter.Shutdown()
+
+	tags := map[string]string{"topic": "knative-messaging-kafka.my-namespace.my-channel", "broker": "kafka-0.kafka/broker:9092"}
+	reporter.ReportGauge("test_sanitized_gauge", 1, tags)
+	reporter.ReportGauge("test_sanitized_gauge_2", 1, tags)
+
+	expected := []metricdata.LabelValue{
+		metricdata.NewLabelValue("kafka-0_kafka_broker:9092"),
+		metricdata.NewLabelValue("knative-messaging-kafka_my-namespace_my-channel"),
+	}
+	assert.Equal(t, expected, reporter.metrics["test_sanitized_gauge"].TimeSeries[0].LabelValues)
+	assert.Equal(t, expected, reporter.metrics["test_sanitized_gauge_2"].TimeSeries[0].LabelValues)
+}
+
+// Test That The Topic Or Broker Of A Scoped Sarama Metric Name Is Sanitized Consistently
+func TestGetMetricInfoSanitizesTopicAndBroker(t *testing.T) {
+	tests := []struct {
+		name            string
+		wantName        string
+		wantDescription string
+	}{
+		{
+			name:            "record-send-rate-for-topic-knative-messaging-kafka.my-namespace.my-channel",
+			wantName:        "record-send-rate-for-topic-knative-messaging-kafka_my-namespace_my-channel",
+			wantDescription: "Records/second sent to topic \"knative-messaging-kafka.my-namespace.my-channel\"",
+		},
+		{
+			name:            "batch-size-for-topic-a/b c-for-partition-2",
+			wantName:        "batch-size-for-topic-a_b_c",
+			wantDescription: "Distribution of the number of bytes sent per partition per request for topic \"a/b c\"",
+		},
+		{
+			name:            "request-rate-for-broker-kafka-0.kafka",
+			wantName:        "request-rate-for-broker-kafka-0_kafka",
+			wantDescription: "Requests/second sent to broker kafka-0.kafka",
+		},
+		{
+			name:            "record-send-rate-for-topic-test-topic",
+			wantName:        "record-send-rate-for-topic-test-topic",
+			wantDescription: "Records/second sent to topic \"test-topic\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := getMetricInfo(tt.name)
+			assert.Equal(t, tt.wantName, info.Name)
+			assert.Equal(t, tt.wantDescription, info.Description)
+			assert.Equal(t, info, getMetricInfo(tt.name)) // Cached
+			assert.Equal(t, tt.wantName, sanitizeTagValue(info.Name))
+		})
+	}
+}
+
+func TestSanitizeTagValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "Empty", value: "", want: ""},
+		{name: "Allowed Characters", value: "my-topic_1:9092", want: "my-topic_1:9092"},
+		{name: "Dots", value: "knative-messaging-kafka.ns.name", want: "knative-messaging-kafka_ns_name"},
+		{name: "Slashes And Spaces", value: "a/b c", want: "a_b_c"},
+		{name: "Too Long", value: strings.Repeat("a.", maxTagValueLength), want: strings.Repeat("a_", maxTagValueLength)[:maxTagValueLength]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitizeTagValue(tt.value))
+			assert.Equal(t, tt.want, sanitizeTagValue(tt.want)) // Sanitizing is idempotent
+		})
+	}
+}
+
+// Test That Denied And Non-Allowed Metric Families Are Not Reported
+func TestReporterMetricFamilies(t *testing.T) {
+	metrics := createTestMetrics("test-topic", 100)
+
+	tests := []struct {
+		name      string
+		options   []StatsReporterOption
+		reported  []string
+		notReport []string
+	}{
+		{
+			name:     "No Filtering",
+			reported: []string{"requests-in-flight-for-broker-0.count", "request-rate.count", "batch-size"},
+		},
+		{
+			name:      "Deny Per-Broker In-Flight Requests",
+			options:   []StatsReporterOption{WithDeniedMetricFamilies("requests-in-flight-for-broker-")},
+			reported:  []string{"requests-in-flight.count", "request-rate.count", "batch-size"},
+			notReport: []string{"requests-in-flight-for-broker-0.count"},
+		},
+		{
+			name:      "Allow Request Families Only",
+			options:   []StatsReporterOption{WithAllowedMetricFamilies("request-rate", "request-size")},
+			reported:  []string{"request-rate.count", "request-rate-for-broker-0.count", "request-size"},
+			notReport: []string{"batch-size", "response-rate.count", "requests-in-flight.count"},
+		},
+		{
+			name: "Deny Takes Precedence Over Allow",
+			options: []StatsReporterOption{
+				WithAllowedMetricFamilies("request-rate"),
+				WithDeniedMetricFamilies("request-rate-for-broker-"),
+			},
+			reported:  []string{"request-rate.count"},
+			notReport: []string{"request-rate-for-broker-0.count", "batch-size"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewStatsReporter(logtesting.TestLogger(t).Desugar(), tt.options...).(*Reporter)
+			defer reporter.Shutdown()
+			reporter.Report(metrics)
+			for _, name := range tt.reported {
+				assert.Contains(t, reporter.metrics, name)
+			}
+			for _, name := range tt.notReport {
+				assert.NotContains(t, reporter.metrics, name)
+			}
+		})
+	}
+}
+
 func TestGetMetricSubInfo(t *testing.T) {
 	const subMetric = "test-sub-metric"
 
@@ -144,6 +318,7 @@ func TestGetMetricInfo(t *testing.T) {
 		{name: "records-per-request", want: "Distribution of the number of records sent per request for all topics"},
 		{name: "compression-ratio", want: "Distribution of the compression ratio times 100 of record batches for all topics"},
 		{name: "consumer-batch-size", want: "Distribution of the number of messages in a batch"},
+		{name: "consumer-batch-size-for-partition-3", want: "Distribution of the number of messages in a batch"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -386,6 +561,82 @@ func TestReporterRecordMetric(t *testing.T) {
 	}
 }
 
+func TestReporterRecordPartitionMetric(t *testing.T) {
+	reporter := createTestReporter(t)
+	defer reporter.Shutdown()
+
+	// A partition-scoped meter is reported under the topic-level name, with a partition tag
+	meterKey := "consumer-fetch-rate-for-topic-test-topic-for-partition-3"
+	reporter.recordMetric(meterKey, ReportingItem{"count": 7, "1m.rate": 1.5})
+	savedMetric, ok := reporter.metrics[meterKey+".count"]
+	require.True(t, ok)
+	assert.Equal(t, "consumer-fetch-rate-for-topic-test-topic.count", savedMetric.Descriptor.Name)
+	assert.Equal(t, []metricdata.LabelKey{{Key: partitionTagKey}}, savedMetric.Descriptor.LabelKeys)
+	require.Equal(t, 1, len(savedMetric.TimeSeries))
+	assert.Equal(t, []metricdata.LabelValue{metricdata.NewLabelValue("3")}, savedMetric.TimeSeries[0].LabelValues)
+
+	// A partition-scoped histogram has both the percentile and partition tags (and the count has the partition tag)
+	histogramKey := "consumer-batch-size-for-topic-test-topic-for-partition-12"
+	reporter.recordMetric(histogramKey, ReportingItem{"75%": 4, "99%": 9, "count": 3})
+	savedMetric, ok = reporter.metrics[histogramKey]
+	require.True(t, ok)
+	assert.Equal(t, "consumer-batch-size-for-topic-test-topic", savedMetric.Descriptor.Name)
+	assert.Equal(t, []metricdata.LabelKey{{Key: "percentile"}, {Key: partitionTagKey}}, savedMetric.Descriptor.LabelKeys)
+	require.Equal(t, 2, len(savedMetric.TimeSeries))
+	for _, series := range savedMetric.TimeSeries {
+		require.Equal(t, 2, len(series.LabelValues))
+		assert.Equal(t, metricdata.NewLabelValue("12"), series.LabelValues[1])
+	}
+	countMetric, ok := reporter.metrics[histogramKey+"_count"]
+	require.True(t, ok)
+	assert.Equal(t, "consumer-batch-size-for-topic-test-topic_count", countMetric.Descriptor.Name)
+	assert.Equal(t, []metricdata.LabelValue{metricdata.NewLabelValue("12")}, countMetric.TimeSeries[0].LabelValues)
+
+	// Metrics that aren't partition-scoped have no partition tag
+	reporter.recordMetric("request-rate-for-broker-0", ReportingItem{"count": 1})
+	savedMetric, ok = reporter.metrics["request-rate-for-broker-0.count"]
+	require.True(t, ok)
+	assert.Empty(t, savedMetric.Descriptor.LabelKeys)
+	assert.Empty(t, savedMetric.TimeSeries[0].LabelValues)
+}
+
+// Test That The Partitions Of A Partition-Scoped Metric Are Read As One Metric With A TimeSeries Per Partition
+func TestReporterReadPartitionMetrics(t *testing.T) {
+	reporter := createTestReporter(t)
+	defer reporter.Shutdown()
+
+	reporter.Report(ReportingList{
+		"consumer-fetch-rate-for-topic-test-topic-for-partition-0":  {"count": 7},
+		"consumer-fetch-rate-for-topic-test-topic-for-partition-1":  {"count": 8},
+		"consumer-batch-size-for-topic-test-topic-for-partition-0":  {"75%": 4, "count": 3},
+		"consumer-batch-size-for-topic-test-topic-for-partition-1":  {"75%": 5, "count": 2},
+		"consumer-batch-size-for-topic-test-topic-for-partition-10": {"75%": 6, "count": 1},
+	})
+
+	metricsByName := make(map[string]*metricdata.Metric)
+	for _, metric := range reporter.Read() {
+		require.NotContains(t, metricsByName, metric.Descriptor.Name, "duplicate metric %s", metric.Descriptor.Name)
+		metricsByName[metric.Descriptor.Name] = metric
+	}
+	require.Equal(t, 3, len(metricsByName))
+
+	partitionsOf := func(name string) []string {
+		metric, ok := metricsByName[name]
+		require.True(t, ok, "missing metric %s", name)
+		var partitions []string
+		for _, series := range metric.TimeSeries {
+			partitions = append(partitions, series.LabelValues[len(series.LabelValues)-1].Value)
+		}
+		return partitions
+	}
+	assert.ElementsMatch(t, []string{"0", "1"}, partitionsOf("consumer-fetch-rate-for-topic-test-topic.count"))
+	assert.ElementsMatch(t, []string{"0", "1", "10"}, partitionsOf("consumer-batch-size-for-topic-test-topic"))
+	assert.ElementsMatch(t, []string{"0", "1", "10"}, partitionsOf("consumer-batch-size-for-topic-test-topic_count"))
+
+	// The stored metrics are not modified by the merge
+	require.Equal(t, 1, len(reporter.metrics["consumer-fetch-rate-for-topic-test-topic-for-partition-0.count"].TimeSeries))
+}
+
 func TestReporterRead(t *testing.T) {
 	reporter := createTestReporter(t)
 	defer reporter.Shutdown()
@@ -437,6 +688,8 @@ func createTestMetrics(topic string, count int64) ReportingList {
 	testMetrics["response-rate-for-broker-0"] = ReportingItem{"15m.rate": 0.7922622031773328, "1m.rate": 0.6918979178602331, "5m.rate": 0.777023951053527, "count": 5, "mean.rate": 0.3744806601376294}
 	testMetrics["response-size"] = ReportingItem{"75%": 503.25, "95%": 1797, "99%": 1797, "99.9%": 1797, "count": 6, "max": 1797, "mean": 359.5, "median": 72, "min": 72, "stddev": 642.8695435311895}
 	testMetrics["response-size-for-broker-0"] = ReportingItem{"75%": 72, "95%": 72, "99%": 72, "99.9%": 72, "count": 5, "max": 72, "mean": 72, "median": 72, "min": 72, "stddev": 0}
+	testMetrics["requests-in-flight"] = ReportingItem{"count": 1}
+	testMetrics["requests-in-flight-for-broker-0"] = ReportingItem{"count": 1}
 	testMetrics["int32-test-metric"] = ReportingItem{"test-header": int32(1)}
 	testMetrics["float32-test-metric"] = ReportingItem{"test-header": float32(1.2345)}
 	testMetrics["nan-test-metric"] = ReportingItem{"test-header": "not-a-number"}
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"go.opencensus.io/stats/view"
+)
+
+// RegisterViews registers the given OpenCensus views, skipping any view whose name is already registered.
+// Unlike view.Register, registering the same views from multiple components in one process is not an error.
+func RegisterViews(views ...*view.View) error {
+	unregistered := make([]*view.View, 0, len(views))
+	for _, v := range views {
+		if view.Find(viewName(v)) == nil {
+			unregistered = append(unregistered, v)
+		}
+	}
+	if len(unregistered) == 0 {
+		return nil
+	}
+	return view.Register(unregistered...)
+}
+
+// viewName returns the name the view is registered under, which defaults to the measure name
+func viewName(v *view.View) string {
+	if v.Name == "" && v.Measure != nil {
+		return v.Measure.Name()
+	}
+	return v.Name
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+// Test That Registering The Same View Twice Is Not An Error
+func TestRegisterViews(t *testing.T) {
+	measure := stats.Int64("test_register_views_count", "Test measure", stats.UnitDimensionless)
+	newView := func() *view.View {
+		return &view.View{
+			Description: measure.Description(),
+			Measure:     measure,
+			Aggregation: view.Count(),
+		}
+	}
+	defer func() { view.Unregister(view.Find(measure.Name())) }()
+
+	assert.Nil(t, RegisterViews(newView()))
+	assert.NotNil(t, view.Find(measure.Name()))
+
+	// A Second Registration Under The Same Name (Even With A Different Aggregation) Succeeds
+	duplicate := newView()
+	duplicate.Aggregation = view.LastValue()
+	assert.NotNil(t, view.Register(duplicate))
+	assert.Nil(t, RegisterViews(duplicate))
+	assert.Nil(t, RegisterViews(newView()))
+}
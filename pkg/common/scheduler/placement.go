@@ -50,3 +50,22 @@ func GetPodCount(placements []duckv1alpha1.Placement) int {
 	}
 	return set.Len()
 }
+
+// PlacementsChanged returns true when the placements differ from the current ones,
+// regardless of their order
+func PlacementsChanged(current []duckv1alpha1.Placement, placements []duckv1alpha1.Placement) bool {
+	if len(current) != len(placements) {
+		return true
+	}
+	counts := make(map[duckv1alpha1.Placement]int, len(current))
+	for _, p := range current {
+		counts[p]++
+	}
+	for _, p := range placements {
+		if counts[p] == 0 {
+			return true
+		}
+		counts[p]--
+	}
+	return false
+}
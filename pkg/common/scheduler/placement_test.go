@@ -154,3 +154,58 @@ func TestPodCount(t *testing.T) {
 		})
 	}
 }
+
+func TestPlacementsChanged(t *testing.T) {
+	testCases := []struct {
+		name       string
+		current    []duckv1alpha1.Placement
+		placements []duckv1alpha1.Placement
+		expected   bool
+	}{
+		{
+			name:       "nil and empty placements",
+			current:    nil,
+			placements: []duckv1alpha1.Placement{},
+			expected:   false,
+		},
+		{
+			name:       "identical placements",
+			current:    []duckv1alpha1.Placement{{PodName: "p1", ZoneName: "z1", VReplicas: 2}, {PodName: "p2", ZoneName: "z2", VReplicas: 6}},
+			placements: []duckv1alpha1.Placement{{PodName: "p1", ZoneName: "z1", VReplicas: 2}, {PodName: "p2", ZoneName: "z2", VReplicas: 6}},
+			expected:   false,
+		},
+		{
+			name:       "reordered placements",
+			current:    []duckv1alpha1.Placement{{PodName: "p1", ZoneName: "z1", VReplicas: 2}, {PodName: "p2", ZoneName: "z2", VReplicas: 6}},
+			placements: []duckv1alpha1.Placement{{PodName: "p2", ZoneName: "z2", VReplicas: 6}, {PodName: "p1", ZoneName: "z1", VReplicas: 2}},
+			expected:   false,
+		},
+		{
+			name:       "different vreplicas",
+			current:    []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}, {PodName: "p2", VReplicas: 6}},
+			placements: []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}, {PodName: "p2", VReplicas: 5}},
+			expected:   true,
+		},
+		{
+			name:       "added placement",
+			current:    []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}},
+			placements: []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}, {PodName: "p2", VReplicas: 1}},
+			expected:   true,
+		},
+		{
+			name:       "duplicated placement",
+			current:    []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}, {PodName: "p2", VReplicas: 1}},
+			placements: []duckv1alpha1.Placement{{PodName: "p1", VReplicas: 2}, {PodName: "p1", VReplicas: 2}},
+			expected:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := PlacementsChanged(tc.current, tc.placements)
+			if got != tc.expected {
+				t.Errorf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
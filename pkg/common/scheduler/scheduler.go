@@ -17,7 +17,9 @@ limitations under the License.
 package scheduler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/types"
 
@@ -28,6 +30,24 @@ var (
 	ErrNotEnoughReplicas = errors.New("scheduling failed (not enough pod replicas)")
 )
 
+// NotEnoughReplicasError is returned when only some of the vreplicas of a VPod
+// could be placed. It matches ErrNotEnoughReplicas with errors.Is.
+type NotEnoughReplicasError struct {
+	// Placed is the number of vreplicas placed so far
+	Placed int32
+
+	// Pending is the number of vreplicas waiting for capacity
+	Pending int32
+}
+
+func (e *NotEnoughReplicasError) Error() string {
+	return fmt.Sprintf("%s: %d vreplicas placed, %d pending", ErrNotEnoughReplicas.Error(), e.Placed, e.Pending)
+}
+
+func (e *NotEnoughReplicasError) Is(target error) bool {
+	return target == ErrNotEnoughReplicas
+}
+
 // VPodLister is the function signature for returning a list of VPods
 type VPodLister func() ([]VPod, error)
 
@@ -42,6 +62,41 @@ type Scheduler interface {
 	Schedule(vpod VPod) ([]duckv1alpha1.Placement, error)
 }
 
+// Warmer is implemented by schedulers able to build their state ahead of the
+// first call to Schedule.
+type Warmer interface {
+	// Warm builds the scheduler state so that the first Schedule call does not
+	// pay the full cost of doing so. It should be called once the informers backing
+	// the scheduler have synced, and returns any error encountered along the way.
+	Warm(ctx context.Context) error
+}
+
+// Checker is implemented by schedulers able to verify that they can reach the
+// resources they place vreplicas on, for instance to back a readiness probe.
+type Checker interface {
+	// Check returns an error if the scheduler is not ready to schedule vpods.
+	Check() error
+}
+
+// Planner is implemented by schedulers able to compute placements without applying them.
+type Planner interface {
+	// PlanSchedule returns the placements and the number of pending vreplicas
+	// Schedule would produce for vpod, without reserving them or triggering
+	// any scaling.
+	PlanSchedule(vpod VPod) ([]duckv1alpha1.Placement, int32, error)
+}
+
+// Drainer is implemented by schedulers able to move all vreplicas off a given pod,
+// for instance to drain a pod ahead of node maintenance.
+type Drainer interface {
+	// Evict marks the pod as unavailable and evicts its vreplicas so that they
+	// get rescheduled on other pods.
+	Evict(podName string) error
+
+	// Restore makes an evicted pod available again for new vreplicas.
+	Restore(podName string)
+}
+
 // VPod represents virtual replicas placed into real Kubernetes pods
 // The scheduler is responsible for placing VPods
 type VPod interface {
@@ -55,3 +110,21 @@ type VPod interface {
 	// Do not mutate!
 	GetPlacements() []duckv1alpha1.Placement
 }
+
+// WeightedVPod is implemented by VPods whose vreplicas consume more than one unit
+// of pod capacity each, for instance sources handling large messages.
+type WeightedVPod interface {
+	VPod
+
+	// GetVReplicaWeight returns the capacity consumed by each virtual replica.
+	GetVReplicaWeight() int32
+}
+
+// GetVReplicaWeight returns the capacity consumed by each virtual replica of vpod,
+// defaulting to 1 for VPods not implementing WeightedVPod.
+func GetVReplicaWeight(vpod VPod) int32 {
+	if wvpod, ok := vpod.(WeightedVPod); ok && wvpod.GetVReplicaWeight() > 1 {
+		return wvpod.GetVReplicaWeight()
+	}
+	return 1
+}
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/utils/clock"
 
 	"knative.dev/eventing-kafka/pkg/common/scheduler"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -49,11 +50,18 @@ type autoscaler struct {
 	trigger           chan int32
 	evictor           scheduler.Evictor
 
+	// isLeader reports whether this replica is the leader and is
+	// therefore allowed to scale the statefulset.
+	isLeader func() bool
+
 	// capacity is the total number of virtual replicas available per pod.
 	capacity int32
 
 	// refreshPeriod is how often the autoscaler tries to scale down the statefulset
 	refreshPeriod time.Duration
+
+	// clock measures the refresh period.
+	clock clock.Clock
 }
 
 func NewAutoscaler(ctx context.Context,
@@ -62,7 +70,16 @@ func NewAutoscaler(ctx context.Context,
 	stateAccessor stateAccessor,
 	evictor scheduler.Evictor,
 	refreshPeriod time.Duration,
-	capacity int32) Autoscaler {
+	capacity int32,
+	isLeader func() bool,
+	clk clock.Clock) Autoscaler {
+
+	if isLeader == nil {
+		isLeader = func() bool { return true }
+	}
+	if clk == nil {
+		clk = clock.RealClock{}
+	}
 
 	return &autoscaler{
 		logger:            logging.FromContext(ctx),
@@ -74,6 +91,8 @@ func NewAutoscaler(ctx context.Context,
 		trigger:           make(chan int32, 1),
 		capacity:          capacity,
 		refreshPeriod:     refreshPeriod,
+		isLeader:          isLeader,
+		clock:             clk,
 	}
 }
 
@@ -84,12 +103,20 @@ func (a *autoscaler) Start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(a.refreshPeriod):
+		case <-a.clock.After(a.refreshPeriod):
 			attemptScaleDown = true
 		case pending = <-a.trigger:
 			attemptScaleDown = false
 		}
 
+		// Only the leader is allowed to scale the statefulset, otherwise replicas
+		// might fight over the replica count.
+		if !a.isLeader() {
+			a.logger.Debug("not the leader, skipping autoscaling")
+			pending = int32(0)
+			continue
+		}
+
 		// Retry a few times, just so that we don't have to wait for the next beat when
 		// a transient error occurs
 		wait.Poll(500*time.Millisecond, 5*time.Second, func() (bool, error) {
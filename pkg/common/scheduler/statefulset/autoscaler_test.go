@@ -23,7 +23,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	gtesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	listers "knative.dev/eventing/pkg/reconciler/testing/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client/fake"
@@ -215,7 +217,7 @@ func TestAutoscaler(t *testing.T) {
 
 			vpodClient := tscheduler.NewVPodClient()
 			ls := listers.NewListers(nil)
-			stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, ls.GetNodeLister())
+			stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
 
 			sfsClient := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs)
 			_, err := sfsClient.Create(ctx, makeStatefulset(testNs, sfsName, tc.replicas), metav1.CreateOptions{})
@@ -227,7 +229,7 @@ func TestAutoscaler(t *testing.T) {
 				return nil
 			}
 
-			autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, 10*time.Second, int32(10)).(*autoscaler)
+			autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, 10*time.Second, int32(10), nil, nil).(*autoscaler)
 
 			for _, vpod := range tc.vpods {
 				vpodClient.Append(vpod)
@@ -263,7 +265,7 @@ func TestAutoscalerScaleDownToZero(t *testing.T) {
 
 	vpodClient := tscheduler.NewVPodClient()
 	ls := listers.NewListers(nil)
-	stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister())
+	stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
 
 	sfsClient := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs)
 	_, err := sfsClient.Create(ctx, makeStatefulset(testNs, sfsName, 10), metav1.CreateOptions{})
@@ -275,7 +277,7 @@ func TestAutoscalerScaleDownToZero(t *testing.T) {
 		return nil
 	}
 
-	autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, 2*time.Second, int32(10)).(*autoscaler)
+	autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, 2*time.Second, int32(10), nil, nil).(*autoscaler)
 
 	done := make(chan bool)
 	go func() {
@@ -307,6 +309,148 @@ func TestAutoscalerScaleDownToZero(t *testing.T) {
 	}
 }
 
+func TestAutoscalerRefreshPeriodFakeClock(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+	defer cancel()
+
+	updated := make(chan bool, 1)
+	kubeclient.Get(ctx).PrependReactor("update", "statefulsets", func(action gtesting.Action) (handled bool, ret runtime.Object, err error) {
+		if action.GetSubresource() == "scale" {
+			select {
+			case updated <- true:
+			default:
+			}
+		}
+		return false, nil, nil
+	})
+
+	vpodClient := tscheduler.NewVPodClient()
+	vpodClient.Create(vpodNamespace, vpodName, 1, []duckv1alpha1.Placement{{PodName: "statefulset-name-1", VReplicas: 1}})
+	ls := listers.NewListers(nil)
+	stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+
+	sfsClient := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs)
+	_, err := sfsClient.Create(ctx, makeStatefulset(testNs, sfsName, 10), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	noopEvictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		return nil
+	}
+
+	refreshPeriod := time.Minute
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, refreshPeriod, int32(10), nil, fakeClock).(*autoscaler)
+
+	done := make(chan bool)
+	go func() {
+		autoscaler.Start(ctx)
+		done <- true
+	}()
+
+	// Wait for the autoscaler to wait on the refresh period
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) { return fakeClock.HasWaiters(), nil }); err != nil {
+		t.Fatal("timeout waiting for the autoscaler to start", err)
+	}
+
+	// No scale down before the refresh period elapses
+	fakeClock.Step(refreshPeriod - time.Second)
+	select {
+	case <-updated:
+		t.Fatal("unexpected scale subresource update before the refresh period elapsed")
+	case <-time.After(time.Second):
+	}
+
+	// Scale down to the last ordinal once the refresh period elapses
+	fakeClock.Step(time.Second)
+	select {
+	case <-updated:
+	case <-time.After(4 * time.Second):
+		t.Fatal("timeout waiting for scale subresource to be updated")
+	}
+
+	scale, err := sfsClient.GetScale(ctx, sfsName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if scale.Spec.Replicas != 2 {
+		t.Errorf("unexpected number of replicas, got %d, want 2", scale.Spec.Replicas)
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout waiting for autoscaler to stop")
+	}
+}
+
+func TestAutoscalerNotLeader(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+
+	updated := make(chan bool, 1)
+	kubeclient.Get(ctx).PrependReactor("update", "statefulsets", func(action gtesting.Action) (handled bool, ret runtime.Object, err error) {
+		if action.GetSubresource() == "scale" {
+			select {
+			case updated <- true:
+			default:
+			}
+		}
+		return false, nil, nil
+	})
+
+	vpodClient := tscheduler.NewVPodClient()
+	ls := listers.NewListers(nil)
+	stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+
+	sfsClient := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs)
+	_, err := sfsClient.Create(ctx, makeStatefulset(testNs, sfsName, 10), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	noopEvictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		return nil
+	}
+	notLeader := func() bool {
+		return false
+	}
+
+	autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, noopEvictor, 1*time.Second, int32(10), notLeader, nil).(*autoscaler)
+
+	done := make(chan bool)
+	go func() {
+		autoscaler.Start(ctx)
+		done <- true
+	}()
+
+	autoscaler.Autoscale(int32(100))
+
+	select {
+	case <-updated:
+		t.Fatal("unexpected scale subresource update from non-leader autoscaler")
+	case <-time.After(3 * time.Second):
+	}
+
+	sfs, err := sfsClient.Get(ctx, sfsName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if *sfs.Spec.Replicas != 10 {
+		t.Errorf("unexpected number of replicas, got %d, want 10", *sfs.Spec.Replicas)
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout waiting for autoscaler to stop")
+	}
+}
+
 func TestCompactor(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -399,7 +543,7 @@ func TestCompactor(t *testing.T) {
 
 			vpodClient := tscheduler.NewVPodClient()
 			ls := listers.NewListers(nil)
-			stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, ls.GetNodeLister())
+			stateAccessor := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
 
 			evictions := make(map[types.NamespacedName]duckv1alpha1.Placement)
 			recordEviction := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
@@ -407,7 +551,7 @@ func TestCompactor(t *testing.T) {
 				return nil
 			}
 
-			autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, recordEviction, 10*time.Second, int32(10)).(*autoscaler)
+			autoscaler := NewAutoscaler(ctx, testNs, sfsName, vpodClient.List, stateAccessor, recordEviction, 10*time.Second, int32(10), nil, nil).(*autoscaler)
 
 			for _, vpod := range tc.vpods {
 				vpodClient.Append(vpod)
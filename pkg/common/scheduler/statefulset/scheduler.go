@@ -19,6 +19,7 @@ package statefulset
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"sync"
@@ -26,8 +27,10 @@ import (
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clientappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/integer"
@@ -39,6 +42,7 @@ import (
 
 	duckv1alpha1 "knative.dev/eventing-kafka/pkg/apis/duck/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/common/scheduler"
+	nodeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/node"
 	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 )
 
@@ -49,30 +53,107 @@ const (
 	MAXFILLUP SchedulerPolicyType = "MAXFILLUP"
 	// EVENSPREAD policy type spreads replicas uniformly across failure-domains such as regions, zones, nodes, etc
 	EVENSPREAD = "EVENSPREAD"
+	// EVENSPREAD_BYREGION policy type spreads replicas uniformly across regions rather than zones
+	EVENSPREAD_BYREGION = "EVENSPREAD_BYREGION"
+	// PREFERREDEVENSPREAD policy type spreads replicas like EVENSPREAD, but packs the replicas that cannot be
+	// placed without exceeding the spread onto any pod with free capacity rather than leaving them pending
+	PREFERREDEVENSPREAD = "PREFERREDEVENSPREAD"
 )
 
+// schedulerPolicies holds every policy type understood by the scheduler
+var schedulerPolicies = []SchedulerPolicyType{MAXFILLUP, EVENSPREAD, EVENSPREAD_BYREGION, PREFERREDEVENSPREAD}
+
+// ListSchedulerPolicies returns the sorted names of the available scheduler policy types.
+func ListSchedulerPolicies() []string {
+	names := make([]string, 0, len(schedulerPolicies))
+	for _, policy := range schedulerPolicies {
+		names = append(names, string(policy))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidatePolicy returns an error listing the available policy types if the policy is not one of them.
+func ValidatePolicy(policy SchedulerPolicyType) error {
+	for _, p := range schedulerPolicies {
+		if p == policy {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown scheduler policy %q, available policies: %v", policy, ListSchedulerPolicies())
+}
+
+// isEvenSpread returns whether the policy type spreads replicas across zones (or regions), either strictly or as a preference
+func (p SchedulerPolicyType) isEvenSpread() bool {
+	return p == EVENSPREAD || p == EVENSPREAD_BYREGION || p == PREFERREDEVENSPREAD
+}
+
 const (
+	// ZoneLabel is the default node label holding the zone of the node
 	ZoneLabel = "topology.kubernetes.io/zone"
+
+	// RegionLabel is the default node label holding the region of the node
+	RegionLabel = "topology.kubernetes.io/region"
+
+	// minRefreshPeriod is the smallest autoscaler refresh period accepted by NewScheduler.
+	minRefreshPeriod = 1 * time.Second
 )
 
 // NewScheduler creates a new scheduler with pod autoscaling enabled.
+// The zone (region) of the nodes is read from the zoneLabel (regionLabel) node label,
+// ZoneLabel (RegionLabel) when empty.
 func NewScheduler(ctx context.Context,
 	namespace, name string,
 	lister scheduler.VPodLister,
 	refreshPeriod time.Duration,
 	capacity int32,
 	schedulerPolicy SchedulerPolicyType,
+	excludeUnschedulableNodes bool,
+	stickyPlacements bool,
+	zoneLabel, regionLabel string,
 	nodeLister corev1listers.NodeLister,
-	evictor scheduler.Evictor) scheduler.Scheduler {
+	evictor scheduler.Evictor,
+	isLeader func() bool) scheduler.Scheduler {
+
+	if refreshPeriod < minRefreshPeriod {
+		logging.FromContext(ctx).Warnw("autoscaler refresh period is too small, using minimum instead",
+			zap.Duration("refreshPeriod", refreshPeriod),
+			zap.Duration("minRefreshPeriod", minRefreshPeriod))
+		refreshPeriod = minRefreshPeriod
+	}
 
-	stateAccessor := newStateBuilder(ctx, lister, capacity, schedulerPolicy, nodeLister)
-	autoscaler := NewAutoscaler(ctx, namespace, name, lister, stateAccessor, evictor, refreshPeriod, capacity)
+	if zoneLabel == "" {
+		zoneLabel = ZoneLabel
+	}
+	if regionLabel == "" {
+		regionLabel = RegionLabel
+	}
+
+	stateAccessor := newStateBuilder(ctx, lister, capacity, schedulerPolicy, nodeLister, excludeUnschedulableNodes, zoneLabel, regionLabel)
+
+	// Recompute the node to zone mapping whenever nodes, their zones, regions or their schedulability change
+	if sb, ok := stateAccessor.(*stateBuilder); ok && schedulerPolicy.isEvenSpread() {
+		nodeinformer.Get(ctx).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { sb.invalidateNodeCache() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldNode, oldOk := oldObj.(*corev1.Node)
+				newNode, newOk := newObj.(*corev1.Node)
+				if !oldOk || !newOk || oldNode.GetLabels()[zoneLabel] != newNode.GetLabels()[zoneLabel] ||
+					oldNode.GetLabels()[regionLabel] != newNode.GetLabels()[regionLabel] ||
+					oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable {
+					sb.invalidateNodeCache()
+				}
+			},
+			DeleteFunc: func(obj interface{}) { sb.invalidateNodeCache() },
+		})
+	}
+	autoscaler := NewAutoscaler(ctx, namespace, name, lister, stateAccessor, evictor, refreshPeriod, capacity, isLeader, nil)
 	podInformer := podinformer.Get(ctx)
 	podLister := podInformer.Lister().Pods(namespace)
 
 	go autoscaler.Start(ctx)
 
-	return NewStatefulSetScheduler(ctx, namespace, name, lister, stateAccessor, autoscaler, podLister)
+	return NewStatefulSetScheduler(ctx, namespace, name, lister, stateAccessor, autoscaler, podLister, evictor, stickyPlacements, isLeader)
 }
 
 // StatefulSetScheduler is a scheduler placing VPod into statefulset-managed set of pods
@@ -80,16 +161,23 @@ type StatefulSetScheduler struct {
 	logger            *zap.SugaredLogger
 	statefulSetName   string
 	statefulSetClient clientappsv1.StatefulSetInterface
+	statefulSetLister appsv1listers.StatefulSetNamespaceLister
+	statefulSetSynced cache.InformerSynced
 	podLister         corev1listers.PodNamespaceLister
 	vpodLister        scheduler.VPodLister
 	lock              sync.Locker
 	stateAccessor     stateAccessor
 	autoscaler        Autoscaler
+	evictor           scheduler.Evictor
+
+	// stickyPlacements, when true, makes scale downs take the vreplicas from as few pods as possible
+	// so that the consumers on the other pods aren't rebalanced.
+	stickyPlacements bool
 
 	// replicas is the (cached) number of statefulset replicas.
 	replicas int32
 
-	// pending tracks the number of virtual replicas that haven't been scheduled yet
+	// pending tracks the capacity needed by the virtual replicas that haven't been scheduled yet
 	// because there wasn't enough free capacity.
 	// The autoscaler uses
 	pending map[types.NamespacedName]int32
@@ -97,13 +185,28 @@ type StatefulSetScheduler struct {
 	// reserved tracks vreplicas that have been placed (ie. scheduled) but haven't been
 	// committed yet (ie. not appearing in vpodLister)
 	reserved map[types.NamespacedName]map[string]int32
+
+	// evicted tracks the ordinals of the pods drained with Evict. No new vreplicas
+	// are placed on these pods until they are restored.
+	evicted map[int32]bool
+
+	// isLeader reports whether this replica is the leader and is
+	// therefore allowed to evict the vreplicas of removed pods.
+	isLeader func() bool
 }
 
 func NewStatefulSetScheduler(ctx context.Context,
 	namespace, name string,
 	lister scheduler.VPodLister,
 	stateAccessor stateAccessor,
-	autoscaler Autoscaler, podlister corev1listers.PodNamespaceLister) scheduler.Scheduler {
+	autoscaler Autoscaler, podlister corev1listers.PodNamespaceLister,
+	evictor scheduler.Evictor,
+	stickyPlacements bool,
+	isLeader func() bool) scheduler.Scheduler {
+
+	if isLeader == nil {
+		isLeader = func() bool { return true }
+	}
 
 	scheduler := &StatefulSetScheduler{
 		logger:            logging.FromContext(ctx),
@@ -115,11 +218,17 @@ func NewStatefulSetScheduler(ctx context.Context,
 		lock:              new(sync.Mutex),
 		stateAccessor:     stateAccessor,
 		reserved:          make(map[types.NamespacedName]map[string]int32),
+		evicted:           make(map[int32]bool),
 		autoscaler:        autoscaler,
+		evictor:           evictor,
+		stickyPlacements:  stickyPlacements,
+		isLeader:          isLeader,
 	}
 
 	// Monitor our statefulset
 	statefulsetInformer := statefulsetinformer.Get(ctx)
+	scheduler.statefulSetLister = statefulsetInformer.Lister().StatefulSets(namespace)
+	scheduler.statefulSetSynced = statefulsetInformer.Informer().HasSynced
 	statefulsetInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterWithNameAndNamespace(namespace, name),
 		Handler:    controller.HandleAll(scheduler.updateStatefulset),
@@ -128,18 +237,49 @@ func NewStatefulSetScheduler(ctx context.Context,
 	return scheduler
 }
 
+// Warm builds the scheduler state once, priming the state accessor caches (such as
+// the node to zone mapping) so that the first Schedule call is fast and startup
+// errors are surfaced early.
+func (s *StatefulSetScheduler) Warm(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, err := s.stateAccessor.State(s.reserved)
+	if err != nil {
+		s.logger.Warnw("failed to warm the scheduler state", zap.Error(err))
+		return err
+	}
+
+	s.logger.Infow("scheduler state warmed", zap.Int32("lastOrdinal", state.lastOrdinal), zap.Int32("numZones", state.numZones))
+	return nil
+}
+
+// Check returns an error if the statefulset informer has not synced yet or if the
+// statefulset the vreplicas are placed on does not exist.
+func (s *StatefulSetScheduler) Check() error {
+	if !s.statefulSetSynced() {
+		return errors.New("statefulset informer has not synced")
+	}
+	if _, err := s.statefulSetLister.Get(s.statefulSetName); err != nil {
+		return fmt.Errorf("failed to get statefulset %s: %w", s.statefulSetName, err)
+	}
+	return nil
+}
+
 func (s *StatefulSetScheduler) Schedule(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	placements, err := s.scheduleVPod(vpod)
+	placements, err := s.scheduleVPod(vpod, false)
 	if placements == nil {
 		return placements, err
 	}
 
-	sort.SliceStable(placements, func(i int, j int) bool {
-		return ordinalFromPodName(placements[i].PodName) < ordinalFromPodName(placements[j].PodName)
-	})
+	sortPlacements(placements)
 
 	// Reserve new placements until they are committed to the vpod.
 	s.reservePlacements(vpod, placements)
@@ -147,13 +287,41 @@ func (s *StatefulSetScheduler) Schedule(vpod scheduler.VPod) ([]duckv1alpha1.Pla
 	return placements, err
 }
 
-func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
+// PlanSchedule returns the placements and the number of pending vreplicas Schedule would
+// produce for vpod, leaving the reserved and pending vreplicas untouched and without
+// triggering the autoscaler.
+func (s *StatefulSetScheduler) PlanSchedule(vpod scheduler.VPod) ([]duckv1alpha1.Placement, int32, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	placements, err := s.scheduleVPod(vpod, true)
+	var notEnough *scheduler.NotEnoughReplicasError
+	if errors.As(err, &notEnough) {
+		sortPlacements(placements)
+		return placements, notEnough.Pending, nil
+	}
+	if placements != nil {
+		sortPlacements(placements)
+	}
+	return placements, 0, err
+}
+
+// scheduleVPod computes the new placements of vpod. When dryRun is true, the
+// scheduler reserved and pending vreplicas are left untouched and the autoscaler
+// isn't triggered.
+func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod, dryRun bool) ([]duckv1alpha1.Placement, error) {
 	logger := s.logger.With("key", vpod.GetKey())
 	logger.Info("scheduling")
 
+	// Building the state updates the reserved vreplicas, work on a copy when planning
+	reserved := s.reserved
+	if dryRun {
+		reserved = copyReserved(s.reserved)
+	}
+
 	// Get the current placements state
 	// Quite an expensive operation but safe and simple.
-	state, err := s.stateAccessor.State(s.reserved)
+	state, err := s.stateAccessor.State(reserved)
 	if err != nil {
 		logger.Info("error while refreshing scheduler state (will retry)", zap.Error(err))
 		return nil, err
@@ -162,6 +330,9 @@ func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod) ([]duckv1alpha1
 	placements := vpod.GetPlacements()
 	var spreadVal, left int32
 
+	// Capacity consumed by each vreplica of the vpod
+	weight := scheduler.GetVReplicaWeight(vpod)
+
 	// The scheduler when policy type is
 	// Policy: MAXFILLUP (SchedulerPolicyType == MAXFILLUP)
 	// - allocates as many vreplicas as possible to the same pod(s)
@@ -170,12 +341,19 @@ func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod) ([]duckv1alpha1
 	// - divides up vreplicas equally between the zones and
 	// - allocates as many vreplicas as possible to existing pods while not going over the equal spread value
 	// - allocates remaining vreplicas to new pods created in new zones still satisfying equal spread
+	// Policy: EVENSPREAD_BYREGION (SchedulerPolicyType == EVENSPREAD_BYREGION)
+	// - same as EVENSPREAD, dividing up vreplicas equally between the regions instead of the zones
+	// Policy: PREFERREDEVENSPREAD (SchedulerPolicyType == PREFERREDEVENSPREAD)
+	// - same as EVENSPREAD, then
+	// - allocates the vreplicas left over by the equal spread to any pod with free capacity
 
 	// Exact number of vreplicas => do nothing
 	tr := scheduler.GetTotalVReplicas(placements)
 	if tr == vpod.GetVReplicas() {
 		logger.Info("scheduling succeeded (already scheduled)")
-		delete(s.pending, vpod.GetKey())
+		if !dryRun {
+			delete(s.pending, vpod.GetKey())
+		}
 
 		// Fully placed. Nothing to do
 		return placements, nil
@@ -184,11 +362,11 @@ func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod) ([]duckv1alpha1
 	// Need less => scale down
 	if tr > vpod.GetVReplicas() {
 		logger.Infow("scaling down", zap.Int32("vreplicas", tr), zap.Int32("new vreplicas", vpod.GetVReplicas()))
-		if state.schedulerPolicy == EVENSPREAD {
+		if state.schedulerPolicy.isEvenSpread() {
 			//spreadVal is the minimum number of replicas to be left behind in each zone for high availability
-			spreadVal = int32(math.Floor(float64(vpod.GetVReplicas()) / float64(state.numZones)))
+			spreadVal = int32(math.Floor(float64(vpod.GetVReplicas()) / float64(state.numSpreadDomains())))
 			logger.Infow("number of replicas per zone", zap.Int32("spreadVal", spreadVal))
-			placements = s.removeReplicasEvenSpread(tr-vpod.GetVReplicas(), placements, spreadVal)
+			placements = s.removeReplicasEvenSpread(state, tr-vpod.GetVReplicas(), placements, spreadVal)
 		} else {
 			placements = s.removeReplicas(tr-vpod.GetVReplicas(), placements)
 		}
@@ -200,35 +378,108 @@ func (s *StatefulSetScheduler) scheduleVPod(vpod scheduler.VPod) ([]duckv1alpha1
 
 	// Need more => scale up
 	logger.Infow("scaling up", zap.Int32("vreplicas", tr), zap.Int32("new vreplicas", vpod.GetVReplicas()))
-	if state.schedulerPolicy == EVENSPREAD {
+	s.logCandidates(logger, state, placements)
+	if state.schedulerPolicy.isEvenSpread() {
 		//spreadVal is the maximum number of replicas to be placed in each zone for high availability
-		spreadVal = int32(math.Ceil(float64(vpod.GetVReplicas()) / float64(state.numZones)))
+		spreadVal = int32(math.Ceil(float64(vpod.GetVReplicas()) / float64(state.numSpreadDomains())))
 		logger.Infow("number of replicas per zone", zap.Int32("spreadVal", spreadVal))
-		placements, left = s.addReplicasEvenSpread(state, vpod.GetVReplicas()-tr, placements, spreadVal)
+		placements, left = s.addReplicasEvenSpread(state, vpod.GetVReplicas()-tr, placements, spreadVal, weight)
+		if left > 0 && state.schedulerPolicy == PREFERREDEVENSPREAD {
+			// Availability over spread: pack the remaining vreplicas wherever there is capacity
+			logger.Infow("even spread not satisfiable, packing remaining vreplicas", zap.Int32("left", left))
+			placements, left = s.packReplicas(state, left, placements, weight)
+		}
 	} else {
-		placements, left = s.addReplicas(state, vpod.GetVReplicas()-tr, placements)
+		placements, left = s.addReplicas(state, vpod.GetVReplicas()-tr, placements, weight)
 	}
 
 	if left > 0 {
 		// Give time for the autoscaler to do its job
 		logger.Info("scheduling failed (not enough pod replicas)", zap.Any("placement", placements), zap.Int32("left", left))
 
-		s.pending[vpod.GetKey()] = left
+		if !dryRun {
+			s.pending[vpod.GetKey()] = left * weight
 
-		// Trigger the autoscaler
-		if s.autoscaler != nil {
-			s.autoscaler.Autoscale(s.pendingVReplicas())
+			// Trigger the autoscaler
+			if s.autoscaler != nil {
+				s.autoscaler.Autoscale(s.pendingVReplicas())
+			}
 		}
 
-		return placements, scheduler.ErrNotEnoughReplicas
+		return placements, &scheduler.NotEnoughReplicasError{Placed: vpod.GetVReplicas() - left, Pending: left}
 	}
 
 	logger.Infow("scheduling successful", zap.Any("placement", placements))
-	delete(s.pending, vpod.GetKey())
+	if !dryRun {
+		delete(s.pending, vpod.GetKey())
+	}
 	return placements, nil
 }
 
+// podCandidate describes what the placement of vreplicas on a pod is decided from
+type podCandidate struct {
+	PodName       string `json:"podName"`
+	ZoneName      string `json:"zoneName,omitempty"`
+	Free          int32  `json:"free"`
+	VReplicas     int32  `json:"vreplicas"`
+	Unschedulable bool   `json:"unschedulable,omitempty"`
+	NotReady      bool   `json:"notReady,omitempty"`
+	Evicted       bool   `json:"evicted,omitempty"`
+}
+
+// logCandidates logs, at debug level, the free capacity, current vreplicas and availability
+// of every pod the vpod may be placed on. This is a no-op when debug logging is disabled.
+func (s *StatefulSetScheduler) logCandidates(logger *zap.SugaredLogger, state *state, placements []duckv1alpha1.Placement) {
+	ce := logger.Desugar().Check(zap.DebugLevel, "placement candidates")
+	if ce == nil {
+		return
+	}
+
+	candidates := make([]podCandidate, 0, s.replicas)
+	for ordinal := int32(0); ordinal < s.replicas; ordinal++ {
+		podName := podNameFromOrdinal(s.statefulSetName, ordinal)
+		candidate := podCandidate{
+			PodName:       podName,
+			Free:          state.Free(ordinal),
+			Unschedulable: s.isOnUnschedulableNode(state, podName),
+			NotReady:      s.isPodNotReady(podName),
+			Evicted:       s.evicted[ordinal],
+		}
+		if placement := scheduler.GetPlacementForPod(placements, podName); placement != nil {
+			candidate.VReplicas = placement.VReplicas
+		}
+		if state.schedulerPolicy.isEvenSpread() {
+			candidate.ZoneName, _ = s.getZoneNameFromPod(state, podName)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	ce.Write(zap.String("policy", string(state.schedulerPolicy)), zap.Any("candidates", candidates))
+}
+
+// sortPlacements sorts the placements by pod ordinal
+func sortPlacements(placements []duckv1alpha1.Placement) {
+	sort.SliceStable(placements, func(i int, j int) bool {
+		return ordinalFromPodName(placements[i].PodName) < ordinalFromPodName(placements[j].PodName)
+	})
+}
+
+// copyReserved returns a deep copy of the reserved vreplicas
+func copyReserved(reserved map[types.NamespacedName]map[string]int32) map[types.NamespacedName]map[string]int32 {
+	c := make(map[types.NamespacedName]map[string]int32, len(reserved))
+	for key, ps := range reserved {
+		c[key] = make(map[string]int32, len(ps))
+		for podName, vreplicas := range ps {
+			c[key][podName] = vreplicas
+		}
+	}
+	return c
+}
+
 func (s *StatefulSetScheduler) removeReplicas(diff int32, placements []duckv1alpha1.Placement) []duckv1alpha1.Placement {
+	if s.stickyPlacements {
+		placements = stickyRemovalOrder(diff, placements)
+	}
 	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
 	for i := len(placements) - 1; i > -1; i-- {
 		if diff >= placements[i].VReplicas {
@@ -245,11 +496,13 @@ func (s *StatefulSetScheduler) removeReplicas(diff int32, placements []duckv1alp
 	return newPlacements
 }
 
-func (s *StatefulSetScheduler) removeReplicasEvenSpread(diff int32, placements []duckv1alpha1.Placement, evenSpread int32) []duckv1alpha1.Placement {
+func (s *StatefulSetScheduler) removeReplicasEvenSpread(state *state, diff int32, placements []duckv1alpha1.Placement, evenSpread int32) []duckv1alpha1.Placement {
 	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
 	logger := s.logger.Named("remove replicas")
 
-	placementsByZone := getPlacementsByZoneKey(placements)
+	placementsByOrdinal := getPlacementsByOrdinal(placements)
+	domainPlacements := s.withSpreadDomains(state, placements)
+	placementsByZone := getPlacementsByZoneKey(domainPlacements)
 	zoneNames := make([]string, 0, len(placementsByZone))
 	for zoneName := range placementsByZone {
 		zoneNames = append(zoneNames, zoneName)
@@ -257,13 +510,16 @@ func (s *StatefulSetScheduler) removeReplicasEvenSpread(diff int32, placements [
 	sort.Strings(zoneNames) //for ordered accessing of map
 
 	for i := 0; i < len(zoneNames); i++ { //iterate through each zone
-		totalInZone := getTotalVReplicasInZone(placements, zoneNames[i])
+		totalInZone := getTotalVReplicasInZone(domainPlacements, zoneNames[i])
 		logger.Info(zap.String("zoneName", zoneNames[i]), zap.Int32("totalInZone", totalInZone))
 
 		placementOrdinals := placementsByZone[zoneNames[i]]
+		if s.stickyPlacements && diff > 0 && totalInZone > evenSpread {
+			placementOrdinals = stickyRemovalOrdinals(integer.Int32Min(diff, totalInZone-evenSpread), placementOrdinals, placementsByOrdinal)
+		}
 		for j := len(placementOrdinals) - 1; j >= 0; j-- { //iterating through all existing pods belonging to a single zone from larger cardinal to smaller
 			ordinal := placementOrdinals[j]
-			placement := s.getPlacementFromPodOrdinal(placements, ordinal)
+			placement := placementsByOrdinal[ordinal]
 
 			if diff > 0 && totalInZone >= evenSpread {
 				deallocation := integer.Int32Min(diff, integer.Int32Min(placement.VReplicas, totalInZone-evenSpread))
@@ -300,7 +556,47 @@ func (s *StatefulSetScheduler) removeReplicasEvenSpread(diff int32, placements [
 	return newPlacements
 }
 
-func (s *StatefulSetScheduler) addReplicas(state *state, diff int32, placements []duckv1alpha1.Placement) ([]duckv1alpha1.Placement, int32) {
+// stickyRemovalOrder returns the placements reordered so that the last placement able to give up diff
+// vreplicas on its own, if any, is removed from first (ie. moved to the end), leaving the others untouched.
+// A placement keeping some vreplicas is preferred over one that would be removed entirely.
+func stickyRemovalOrder(diff int32, placements []duckv1alpha1.Placement) []duckv1alpha1.Placement {
+	candidate := -1
+	for i := len(placements) - 1; i >= 0; i-- {
+		if placements[i].VReplicas > diff {
+			candidate = i
+			break
+		}
+		if placements[i].VReplicas == diff && candidate == -1 {
+			candidate = i
+		}
+	}
+	if candidate == -1 || candidate == len(placements)-1 {
+		return placements
+	}
+
+	ordered := make([]duckv1alpha1.Placement, 0, len(placements))
+	ordered = append(ordered, placements[:candidate]...)
+	ordered = append(ordered, placements[candidate+1:]...)
+	return append(ordered, placements[candidate])
+}
+
+// stickyRemovalOrdinals is stickyRemovalOrder for the ordinals of the placements in a zone.
+func stickyRemovalOrdinals(diff int32, ordinals []int32, placementsByOrdinal map[int32]duckv1alpha1.Placement) []int32 {
+	placements := make([]duckv1alpha1.Placement, 0, len(ordinals))
+	for _, ordinal := range ordinals {
+		placements = append(placements, placementsByOrdinal[ordinal])
+	}
+	placements = stickyRemovalOrder(diff, placements)
+
+	ordered := make([]int32, 0, len(placements))
+	for _, placement := range placements {
+		ordered = append(ordered, ordinalFromPodName(placement.PodName))
+	}
+	return ordered
+}
+
+// addReplicas places diff vreplicas consuming weight capacity each, filling the pods with existing placements first.
+func (s *StatefulSetScheduler) addReplicas(state *state, diff int32, placements []duckv1alpha1.Placement, weight int32) ([]duckv1alpha1.Placement, int32) {
 	// Pod affinity algorithm: prefer adding replicas to existing pods before considering other replicas
 	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
 
@@ -311,15 +607,15 @@ func (s *StatefulSetScheduler) addReplicas(state *state, diff int32, placements
 
 		// Is there space in PodName?
 		f := state.Free(ordinal)
-		if diff >= 0 && f > 0 {
-			allocation := integer.Int32Min(f, diff)
+		if diff >= 0 && f >= weight && !s.isPodUnavailable(podName) {
+			allocation := integer.Int32Min(f/weight, diff)
 			newPlacements = append(newPlacements, duckv1alpha1.Placement{
 				PodName:   podName,
 				VReplicas: placements[i].VReplicas + allocation,
 			})
 
 			diff -= allocation
-			state.SetFree(ordinal, f-allocation)
+			state.SetFree(ordinal, f-allocation*weight)
 		} else {
 			newPlacements = append(newPlacements, placements[i])
 		}
@@ -329,15 +625,16 @@ func (s *StatefulSetScheduler) addReplicas(state *state, diff int32, placements
 		// Needs to allocate replicas to additional pods
 		for ordinal := int32(0); ordinal < s.replicas; ordinal++ {
 			f := state.Free(ordinal)
-			if f > 0 {
-				allocation := integer.Int32Min(f, diff)
+			podName := podNameFromOrdinal(s.statefulSetName, ordinal)
+			if f >= weight && !s.isPodUnavailable(podName) {
+				allocation := integer.Int32Min(f/weight, diff)
 				newPlacements = append(newPlacements, duckv1alpha1.Placement{
-					PodName:   podNameFromOrdinal(s.statefulSetName, ordinal),
+					PodName:   podName,
 					VReplicas: allocation,
 				})
 
 				diff -= allocation
-				state.SetFree(ordinal, f-allocation)
+				state.SetFree(ordinal, f-allocation*weight)
 			}
 
 			if diff == 0 {
@@ -349,13 +646,15 @@ func (s *StatefulSetScheduler) addReplicas(state *state, diff int32, placements
 	return newPlacements, diff
 }
 
-func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, placements []duckv1alpha1.Placement, evenSpread int32) ([]duckv1alpha1.Placement, int32) {
+func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, placements []duckv1alpha1.Placement, evenSpread int32, weight int32) ([]duckv1alpha1.Placement, int32) {
 	// Pod affinity MAXFILLUP algorithm prefer adding replicas to existing pods to fill them up before adding to new pods
 	// Pod affinity EVENSPREAD algorithm spread replicas across pods in different regions for HA
 	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
 	logger := s.logger.Named("add replicas")
 
-	placementsByZone := getPlacementsByZoneKey(placements)
+	placementsByOrdinal := getPlacementsByOrdinal(placements)
+	domainPlacements := s.withSpreadDomains(state, placements)
+	placementsByZone := getPlacementsByZoneKey(domainPlacements)
 	zoneNames := make([]string, 0, len(placementsByZone))
 	for zoneName := range placementsByZone {
 		zoneNames = append(zoneNames, zoneName)
@@ -363,18 +662,18 @@ func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, p
 	sort.Strings(zoneNames) //for ordered accessing of map
 
 	for i := 0; i < len(zoneNames); i++ { //iterate through each zone
-		totalInZone := getTotalVReplicasInZone(placements, zoneNames[i])
+		totalInZone := getTotalVReplicasInZone(domainPlacements, zoneNames[i])
 		logger.Info(zap.String("zoneName", zoneNames[i]), zap.Int32("totalInZone", totalInZone))
 
 		placementOrdinals := placementsByZone[zoneNames[i]]
 		for j := 0; j < len(placementOrdinals); j++ { //iterating through all existing pods belonging to a single zone
 			ordinal := placementOrdinals[j]
-			placement := s.getPlacementFromPodOrdinal(placements, ordinal)
+			placement := placementsByOrdinal[ordinal]
 
 			// Is there space in Pod?
 			f := state.Free(ordinal)
-			if diff >= 0 && f > 0 && totalInZone < evenSpread {
-				allocation := integer.Int32Min(diff, integer.Int32Min(f, (evenSpread-totalInZone)))
+			if diff >= 0 && f >= weight && totalInZone < evenSpread && !s.isOnUnschedulableNode(state, placement.PodName) && !s.isPodUnavailable(placement.PodName) {
+				allocation := integer.Int32Min(diff, integer.Int32Min(f/weight, (evenSpread-totalInZone)))
 				logger.Info(zap.Int32("diff", diff), zap.Int32("allocation", allocation))
 
 				newPlacements = append(newPlacements, duckv1alpha1.Placement{
@@ -384,7 +683,7 @@ func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, p
 				})
 
 				diff -= allocation
-				state.SetFree(ordinal, f-allocation)
+				state.SetFree(ordinal, f-allocation*weight)
 				totalInZone += allocation
 			} else {
 				newPlacements = append(newPlacements, placement)
@@ -393,23 +692,39 @@ func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, p
 	}
 
 	if diff > 0 {
+		// Track the vreplicas per zone incrementally rather than summing up the new placements for every pod
+		totalsByZone := getTotalVReplicasByZone(s.withSpreadDomains(state, newPlacements))
 		for ordinal := int32(0); ordinal < s.replicas; ordinal++ {
 			f := state.Free(ordinal)
-			if f > 0 { //here it is possible to hit pods that are in existing placements
+			if f >= weight { //here it is possible to hit pods that are in existing placements
 				podName := podNameFromOrdinal(s.statefulSetName, ordinal)
+				if s.isOnUnschedulableNode(state, podName) {
+					continue //since the pod's node is cordoned and only counted for the zone math
+				}
+				if s.isPodUnavailable(podName) {
+					continue //since the pod can't consume until it becomes ready
+				}
+
 				zoneName, err := s.getZoneNameFromPod(state, podName)
 				if err != nil {
 					logger.Errorw("Error getting zone info from pod", zap.Error(err))
 					continue //TODO: not continue?
 				}
+				domainName := zoneName
+				if state.schedulerPolicy == EVENSPREAD_BYREGION {
+					if domainName, err = s.getRegionNameFromPod(state, podName); err != nil {
+						logger.Errorw("Error getting region info from pod", zap.Error(err))
+						continue
+					}
+				}
 
-				totalInZone := getTotalVReplicasInZone(newPlacements, zoneName)
+				totalInZone := totalsByZone[domainName]
 				if totalInZone >= evenSpread {
 					continue //since current zone that pod belongs to is already at max spread
 				}
 				logger.Info("Need to schedule on a new pod", zap.Int32("ordinal", ordinal), zap.Int32("free", f), zap.String("zoneName", zoneName), zap.Int32("totalInZone", totalInZone))
 
-				allocation := integer.Int32Min(diff, integer.Int32Min(f, (evenSpread-totalInZone)))
+				allocation := integer.Int32Min(diff, integer.Int32Min(f/weight, (evenSpread-totalInZone)))
 				logger.Info(zap.Int32("diff", diff), zap.Int32("allocation", allocation))
 
 				newPlacements = append(newPlacements, duckv1alpha1.Placement{
@@ -419,8 +734,9 @@ func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, p
 				})
 
 				diff -= allocation
-				state.SetFree(ordinal, f-allocation)
-				placementsByZone[zoneName] = append(placementsByZone[zoneName], ordinal)
+				state.SetFree(ordinal, f-allocation*weight)
+				totalsByZone[domainName] += allocation
+				placementsByZone[domainName] = append(placementsByZone[domainName], ordinal)
 			}
 
 			if diff == 0 {
@@ -431,6 +747,61 @@ func (s *StatefulSetScheduler) addReplicasEvenSpread(state *state, diff int32, p
 	return newPlacements, diff
 }
 
+// packReplicas places diff vreplicas on any pod with free capacity regardless of the even spread,
+// filling the pods with existing placements first. Pods on unschedulable nodes are skipped.
+func (s *StatefulSetScheduler) packReplicas(state *state, diff int32, placements []duckv1alpha1.Placement, weight int32) ([]duckv1alpha1.Placement, int32) {
+	logger := s.logger.Named("pack replicas")
+	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
+	placed := make(map[int32]bool, len(placements))
+
+	// Add to existing
+	for _, placement := range placements {
+		ordinal := ordinalFromPodName(placement.PodName)
+		placed[ordinal] = true
+
+		f := state.Free(ordinal)
+		if diff > 0 && f >= weight && !s.isOnUnschedulableNode(state, placement.PodName) && !s.isPodUnavailable(placement.PodName) {
+			allocation := integer.Int32Min(f/weight, diff)
+			placement.VReplicas += allocation
+
+			diff -= allocation
+			state.SetFree(ordinal, f-allocation*weight)
+		}
+		newPlacements = append(newPlacements, placement)
+	}
+
+	// Needs to allocate replicas to additional pods
+	for ordinal := int32(0); ordinal < s.replicas && diff > 0; ordinal++ {
+		f := state.Free(ordinal)
+		if f < weight || placed[ordinal] {
+			continue
+		}
+
+		podName := podNameFromOrdinal(s.statefulSetName, ordinal)
+		if s.isOnUnschedulableNode(state, podName) || s.isPodUnavailable(podName) {
+			continue
+		}
+
+		zoneName, err := s.getZoneNameFromPod(state, podName)
+		if err != nil {
+			logger.Errorw("Error getting zone info from pod", zap.Error(err))
+			continue
+		}
+
+		allocation := integer.Int32Min(f/weight, diff)
+		newPlacements = append(newPlacements, duckv1alpha1.Placement{
+			PodName:   podName,
+			ZoneName:  zoneName,
+			VReplicas: allocation,
+		})
+
+		diff -= allocation
+		state.SetFree(ordinal, f-allocation*weight)
+	}
+
+	return newPlacements, diff
+}
+
 func (s *StatefulSetScheduler) getZoneNameFromPod(state *state, podName string) (zoneName string, err error) {
 	pod, err := s.podLister.Get(podName)
 	if err != nil {
@@ -444,6 +815,75 @@ func (s *StatefulSetScheduler) getZoneNameFromPod(state *state, podName string)
 	return zoneName, nil
 }
 
+func (s *StatefulSetScheduler) getRegionNameFromPod(state *state, podName string) (regionName string, err error) {
+	pod, err := s.podLister.Get(podName)
+	if err != nil {
+		return regionName, err
+	}
+
+	regionName, ok := state.nodeToRegionMap[pod.Spec.NodeName]
+	if !ok {
+		return regionName, errors.New("Could not find region")
+	}
+	return regionName, nil
+}
+
+// withSpreadDomains returns the placements as seen by the even spread math, that is with their
+// zone name replaced by their region name for the EVENSPREAD_BYREGION policy. The placements
+// of pods with an unknown region are grouped under the empty name.
+func (s *StatefulSetScheduler) withSpreadDomains(state *state, placements []duckv1alpha1.Placement) []duckv1alpha1.Placement {
+	if state.schedulerPolicy != EVENSPREAD_BYREGION {
+		return placements
+	}
+
+	domainPlacements := make([]duckv1alpha1.Placement, len(placements))
+	for i, placement := range placements {
+		domainPlacements[i] = placement
+		domainPlacements[i].ZoneName, _ = s.getRegionNameFromPod(state, placement.PodName)
+	}
+	return domainPlacements
+}
+
+// isOnUnschedulableNode returns true when the pod runs on an unschedulable node
+// that is still counted in the zone info
+func (s *StatefulSetScheduler) isOnUnschedulableNode(state *state, podName string) bool {
+	if len(state.unschedulableNodes) == 0 {
+		return false
+	}
+
+	pod, err := s.podLister.Get(podName)
+	if err != nil {
+		return false
+	}
+	return state.unschedulableNodes[pod.Spec.NodeName]
+}
+
+// isPodUnavailable returns true when the pod has been evicted or isn't ready,
+// in which case no new vreplicas should be placed on it.
+func (s *StatefulSetScheduler) isPodUnavailable(podName string) bool {
+	return s.evicted[ordinalFromPodName(podName)] || s.isPodNotReady(podName)
+}
+
+// isPodNotReady returns true when the pod exists but is pending, has failed or its Ready
+// condition isn't true (eg. crash looping), in which case it can't consume and shouldn't
+// receive new vreplicas. A pod that doesn't exist yet (eg. the statefulset is scaling up)
+// or that doesn't report a phase nor a Ready condition isn't considered not ready.
+func (s *StatefulSetScheduler) isPodNotReady(podName string) bool {
+	pod, err := s.podLister.Get(podName)
+	if err != nil {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status != corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func getPlacementsByZoneKey(placements []duckv1alpha1.Placement) map[string][]int32 {
 	placementsByZone := make(map[string][]int32)
 	for i := 0; i < len(placements); i++ {
@@ -463,13 +903,24 @@ func getTotalVReplicasInZone(placements []duckv1alpha1.Placement, zoneName strin
 	return totalReplicasInZone
 }
 
-func (s *StatefulSetScheduler) getPlacementFromPodOrdinal(placements []duckv1alpha1.Placement, ordinal int32) (placement duckv1alpha1.Placement) {
+func getTotalVReplicasByZone(placements []duckv1alpha1.Placement) map[string]int32 {
+	totalReplicasByZone := make(map[string]int32)
 	for i := 0; i < len(placements); i++ {
-		if placements[i].PodName == podNameFromOrdinal(s.statefulSetName, ordinal) {
-			return placements[i]
+		totalReplicasByZone[placements[i].ZoneName] += placements[i].VReplicas
+	}
+	return totalReplicasByZone
+}
+
+// getPlacementsByOrdinal indexes the placements by pod ordinal, keeping the first placement of each pod.
+func getPlacementsByOrdinal(placements []duckv1alpha1.Placement) map[int32]duckv1alpha1.Placement {
+	placementsByOrdinal := make(map[int32]duckv1alpha1.Placement, len(placements))
+	for i := 0; i < len(placements); i++ {
+		ordinal := ordinalFromPodName(placements[i].PodName)
+		if _, ok := placementsByOrdinal[ordinal]; !ok {
+			placementsByOrdinal[ordinal] = placements[i]
 		}
 	}
-	return placement
+	return placementsByOrdinal
 }
 
 // pendingReplicas returns the total number of vreplicas
@@ -489,14 +940,147 @@ func (s *StatefulSetScheduler) updateStatefulset(obj interface{}) {
 	}
 
 	s.lock.Lock()
-	defer s.lock.Unlock()
 
+	replicas := s.replicas
 	if statefulset.Spec.Replicas == nil {
 		s.replicas = 1
 	} else if s.replicas != *statefulset.Spec.Replicas {
 		s.replicas = *statefulset.Spec.Replicas
 		s.logger.Infow("statefulset replicas updated", zap.Int32("replicas", s.replicas))
 	}
+
+	newReplicas := s.replicas
+	scaledDown := newReplicas < replicas
+	if scaledDown {
+		s.removeReservedAbove(newReplicas)
+	}
+
+	s.lock.Unlock()
+
+	if scaledDown {
+		// Evict outside of the lock since evicting may trigger a new scheduling.
+		s.evictAbove(newReplicas)
+	}
+}
+
+// Evict marks the pod as unavailable and evicts all vreplicas placed on it so that
+// they get rescheduled on other pods, according to the scheduler policy. The pod is
+// marked before evicting, so that the evicted vreplicas aren't placed back on it, and
+// is made available again if any eviction fails.
+func (s *StatefulSetScheduler) Evict(podName string) error {
+	if s.evictor == nil {
+		return errors.New("no evictor configured")
+	}
+
+	s.lock.Lock()
+	s.evicted[ordinalFromPodName(podName)] = true
+	s.removeReservedOn(podName)
+	s.lock.Unlock()
+
+	if err := s.evictOn(podName); err != nil {
+		s.Restore(podName)
+		return err
+	}
+	return nil
+}
+
+// evictOn evicts all vreplicas placed on the given pod.
+func (s *StatefulSetScheduler) evictOn(podName string) error {
+	vpods, err := s.vpodLister()
+	if err != nil {
+		return fmt.Errorf("failed to list vpods for eviction: %w", err)
+	}
+
+	// Evict outside of the lock since evicting may trigger a new scheduling.
+	for _, vpod := range vpods {
+		placement := scheduler.GetPlacementForPod(vpod.GetPlacements(), podName)
+		if placement == nil || placement.VReplicas == 0 {
+			continue
+		}
+
+		s.logger.Infow("evicting vreplica(s) from drained pod",
+			zap.String("name", vpod.GetKey().Name),
+			zap.String("namespace", vpod.GetKey().Namespace),
+			zap.String("podname", podName),
+			zap.Int32("vreplicas", placement.VReplicas))
+
+		if err := s.evictor(vpod, placement); err != nil {
+			return fmt.Errorf("failed to evict vreplica(s) of %s: %w", vpod.GetKey(), err)
+		}
+	}
+	return nil
+}
+
+// Restore makes a pod drained with Evict available again for new vreplicas.
+func (s *StatefulSetScheduler) Restore(podName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.evicted, ordinalFromPodName(podName))
+}
+
+// removeReservedOn removes all reserved vreplicas placed on the given pod.
+func (s *StatefulSetScheduler) removeReservedOn(podName string) {
+	for key, ps := range s.reserved {
+		delete(ps, podName)
+		if len(ps) == 0 {
+			delete(s.reserved, key)
+		}
+	}
+}
+
+// removeReservedAbove removes all reserved vreplicas placed on pods
+// with an ordinal greater than or equal to the given number of replicas.
+func (s *StatefulSetScheduler) removeReservedAbove(replicas int32) {
+	for key, ps := range s.reserved {
+		for podName := range ps {
+			if ordinalFromPodName(podName) >= replicas {
+				delete(ps, podName)
+			}
+		}
+		if len(ps) == 0 {
+			delete(s.reserved, key)
+		}
+	}
+}
+
+// evictAbove evicts all vreplicas placed on pods with an ordinal greater
+// than or equal to the given number of replicas so that they get rescheduled.
+func (s *StatefulSetScheduler) evictAbove(replicas int32) {
+	if s.evictor == nil {
+		return
+	}
+
+	// Only the leader evicts, otherwise every replica would evict the same vreplicas.
+	if !s.isLeader() {
+		s.logger.Debug("not the leader, skipping eviction of vreplicas placed on removed pods")
+		return
+	}
+
+	vpods, err := s.vpodLister()
+	if err != nil {
+		s.logger.Errorw("failed to list vpods for eviction", zap.Error(err))
+		return
+	}
+
+	for _, vpod := range vpods {
+		placements := vpod.GetPlacements()
+		for i := range placements {
+			if ordinalFromPodName(placements[i].PodName) < replicas {
+				continue
+			}
+
+			s.logger.Infow("evicting vreplica(s) from removed pod",
+				zap.String("name", vpod.GetKey().Name),
+				zap.String("namespace", vpod.GetKey().Namespace),
+				zap.String("podname", placements[i].PodName),
+				zap.Int32("vreplicas", placements[i].VReplicas))
+
+			if err := s.evictor(vpod, &placements[i]); err != nil {
+				s.logger.Errorw("failed to evict vreplica(s)", zap.Error(err))
+			}
+		}
+	}
 }
 
 func (s *StatefulSetScheduler) reservePlacements(vpod scheduler.VPod, placements []duckv1alpha1.Placement) {
@@ -17,22 +17,36 @@ limitations under the License.
 package statefulset
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	gtesting "k8s.io/client-go/testing"
+	"k8s.io/utils/integer"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/statefulset/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
 	"knative.dev/pkg/controller"
 	rectesting "knative.dev/pkg/reconciler/testing"
 
@@ -305,6 +319,59 @@ func TestStatefulsetScheduler(t *testing.T) {
 			},
 			schedulerPolicy: EVENSPREAD,
 		},
+		{
+			name:            "one replica, 3 vreplicas, preferred HA scheduling",
+			vreplicas:       3,
+			replicas:        int32(1),
+			expected:        []duckv1alpha1.Placement{{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 3}},
+			schedulerPolicy: PREFERREDEVENSPREAD,
+		},
+		{
+			name:            "one replica, 15 vreplicas, unschedulable, preferred HA scheduling",
+			vreplicas:       15,
+			replicas:        int32(1),
+			err:             scheduler.ErrNotEnoughReplicas,
+			expected:        []duckv1alpha1.Placement{{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 10}},
+			schedulerPolicy: PREFERREDEVENSPREAD,
+		},
+		{
+			name:      "two replicas, 15 vreplicas, scheduled, preferred HA scheduling",
+			vreplicas: 15,
+			replicas:  int32(2),
+			expected: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 10},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 5},
+			},
+			schedulerPolicy: PREFERREDEVENSPREAD,
+		},
+		{
+			name:      "three replicas, 20 vreplicas, spread satisfiable, preferred HA scheduling",
+			vreplicas: 20,
+			replicas:  int32(3),
+			expected: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 7},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 7},
+				{PodName: "statefulset-name-2", ZoneName: "zone2", VReplicas: 6},
+			},
+			schedulerPolicy: PREFERREDEVENSPREAD,
+		},
+		{
+			name:      "three replicas, 7 vreplicas, too much scheduled (scale down), preferred HA scheduling",
+			vreplicas: 7,
+			replicas:  int32(3),
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 4},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 3},
+				{PodName: "statefulset-name-2", ZoneName: "zone1", VReplicas: 4},
+				{PodName: "statefulset-name-3", ZoneName: "zone2", VReplicas: 3},
+			},
+			expected: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 2},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 2},
+				{PodName: "statefulset-name-3", ZoneName: "zone2", VReplicas: 3},
+			},
+			schedulerPolicy: PREFERREDEVENSPREAD,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -314,7 +381,7 @@ func TestStatefulsetScheduler(t *testing.T) {
 			podlist := make([]runtime.Object, 0, tc.replicas)
 			vpodClient := tscheduler.NewVPodClient()
 
-			if tc.schedulerPolicy == EVENSPREAD {
+			if tc.schedulerPolicy.isEvenSpread() {
 				for i := int32(0); i < numZones; i++ {
 					nodeName := "node" + fmt.Sprint(i)
 					zoneName := "zone" + fmt.Sprint(i)
@@ -340,9 +407,9 @@ func TestStatefulsetScheduler(t *testing.T) {
 				t.Fatal("unexpected error", err)
 			}
 			lsn := listers.NewListers(nodelist)
-			sa := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, lsn.GetNodeLister())
+			sa := newStateBuilder(ctx, vpodClient.List, 10, tc.schedulerPolicy, lsn.GetNodeLister(), false, ZoneLabel, RegionLabel)
 			lsp := listers.NewListers(podlist)
-			s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, lsp.GetPodLister().Pods(testNs)).(*StatefulSetScheduler)
+			s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, lsp.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
 
 			// Give some time for the informer to notify the scheduler and set the number of replicas
 			time.Sleep(200 * time.Millisecond)
@@ -374,6 +441,1031 @@ func TestStatefulsetScheduler(t *testing.T) {
 	}
 }
 
+func TestStatefulsetSchedulerCapacityOne(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 1), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 1, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	// Vreplicas placed earlier in the same scheduling pass must count against the pod capacity
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 3, nil)
+	placements, err := s.Schedule(vpod)
+	if !errors.Is(err, scheduler.ErrNotEnoughReplicas) {
+		t.Fatalf("got error %v, want %v", err, scheduler.ErrNotEnoughReplicas)
+	}
+	var notEnough *scheduler.NotEnoughReplicasError
+	if !errors.As(err, &notEnough) || notEnough.Placed != 1 || notEnough.Pending != 2 {
+		t.Fatalf("got error %v, want 1 vreplica placed and 2 pending", err)
+	}
+
+	expected := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 1},
+	}
+	if !reflect.DeepEqual(placements, expected) {
+		t.Errorf("got %v, want %v", placements, expected)
+	}
+}
+
+func TestStatefulsetSchedulerNotReadyPod(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 4), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// The first pod is crash looping and the second one is pending, neither must receive any vreplicas
+	ls := listers.NewListers([]runtime.Object{
+		makeNotReadyPod(testNs, "statefulset-name-0", "node0"),
+		makePendingPod(testNs, "statefulset-name-1"),
+		makePod(testNs, "statefulset-name-2", "node2"),
+		makePod(testNs, "statefulset-name-3", "node3"),
+	})
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Wait for the informer to notify the scheduler and set the number of replicas
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.replicas == 4, nil
+	})
+	if err != nil {
+		t.Fatal("the scheduler never observed the statefulset replicas", err)
+	}
+
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 15, nil)
+	placements, err := s.Schedule(vpod)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	expected := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-2", VReplicas: 10},
+		{PodName: "statefulset-name-3", VReplicas: 5},
+	}
+	if !reflect.DeepEqual(placements, expected) {
+		t.Errorf("got %v, want %v", placements, expected)
+	}
+}
+
+func TestStatefulsetSchedulerEvenSpreadByRegion(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 3), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// The first region has two zones, the second one a single zone
+	ls := listers.NewListers([]runtime.Object{
+		makeRegionNode("node0", "zone0", "region0"),
+		makeRegionNode("node1", "zone1", "region0"),
+		makeRegionNode("node2", "zone2", "region1"),
+		makePod(testNs, "statefulset-name-0", "node0"),
+		makePod(testNs, "statefulset-name-1", "node1"),
+		makePod(testNs, "statefulset-name-2", "node2"),
+	})
+	sa := newStateBuilder(ctx, vpodClient.List, 10, EVENSPREAD_BYREGION, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	// Spreading by zone would place 4 vreplicas in the first region, spreading by region places 3 in each
+	placements, err := s.Schedule(vpodClient.Create(vpodNamespace, vpodName, 6, nil))
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 3},
+		{PodName: "statefulset-name-2", ZoneName: "zone2", VReplicas: 3},
+	}
+	if !reflect.DeepEqual(placements, want) {
+		t.Errorf("got placements %v, want %v", placements, want)
+	}
+
+	// Scaling down leaves as many vreplicas in each region
+	placements, err = s.Schedule(tscheduler.NewVPod(vpodNamespace, vpodName, 2, placements))
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want = []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 1},
+		{PodName: "statefulset-name-2", ZoneName: "zone2", VReplicas: 1},
+	}
+	if !reflect.DeepEqual(placements, want) {
+		t.Errorf("got placements %v, want %v", placements, want)
+	}
+}
+
+func TestStatefulsetSchedulerWeightedVPod(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 2), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// 4 vreplicas are already placed on the first pod
+	vpodClient.Create(vpodNamespace, "other-source", 4, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 4},
+	})
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	// Each vreplica consumes twice the capacity, so only 3 fit on the first pod and 5 on the second one
+	vpod := tscheduler.NewWeightedVPod(vpodNamespace, vpodName, 9, 2, nil)
+	vpodClient.Append(vpod)
+	placements, err := s.Schedule(vpod)
+	var notEnough *scheduler.NotEnoughReplicasError
+	if !errors.As(err, &notEnough) || notEnough.Placed != 8 || notEnough.Pending != 1 {
+		t.Fatalf("got error %v, want 8 vreplicas placed and 1 pending", err)
+	}
+
+	expected := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 3},
+		{PodName: "statefulset-name-1", VReplicas: 5},
+	}
+	if !reflect.DeepEqual(placements, expected) {
+		t.Errorf("got %v, want %v", placements, expected)
+	}
+
+	// The weighted vreplicas must also be accounted for when rebuilding the state
+	vpodClient2 := tscheduler.NewVPodClient()
+	vpodClient2.Append(tscheduler.NewWeightedVPod(vpodNamespace, vpodName, 8, 2, expected))
+	state, err := newStateBuilder(ctx, vpodClient2.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel).State(nil)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if got, want := state.Snapshot(), []int32{4, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got free capacity %v, want %v", got, want)
+	}
+	if got := s.pendingVReplicas(); got != 2 {
+		t.Errorf("got %d pending capacity, want 2", got)
+	}
+}
+
+func TestStatefulsetSchedulerLogCandidates(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 3), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	ls := listers.NewListers([]runtime.Object{
+		makePod(testNs, "statefulset-name-0", "node0"),
+		makeNotReadyPod(testNs, "statefulset-name-1", "node1"),
+		makePod(testNs, "statefulset-name-2", "node2"),
+	})
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.DebugLevel)
+	s.logger = zap.New(core).Sugar()
+
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 12, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 4},
+	})
+	if _, err := s.Schedule(vpod); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	var entry struct {
+		Msg        string         `json:"msg"`
+		Policy     string         `json:"policy"`
+		Candidates []podCandidate `json:"candidates"`
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "placement candidates") {
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatal("unexpected error", err)
+			}
+		}
+	}
+
+	want := []podCandidate{
+		{PodName: "statefulset-name-0", Free: 6, VReplicas: 4},
+		{PodName: "statefulset-name-1", Free: 10, NotReady: true},
+		{PodName: "statefulset-name-2", Free: 10},
+	}
+	if entry.Policy != string(MAXFILLUP) || !reflect.DeepEqual(entry.Candidates, want) {
+		t.Errorf("got candidates %v (policy %q), want %v (policy %q)", entry.Candidates, entry.Policy, want, MAXFILLUP)
+	}
+
+	// Nothing is logged at info level
+	buf.Reset()
+	s.logger = zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.InfoLevel)).Sugar()
+	if _, err := s.Schedule(vpod); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if strings.Contains(buf.String(), "placement candidates") {
+		t.Errorf("got candidates logged at info level: %s", buf.String())
+	}
+}
+
+func TestStatefulsetSchedulerWarm(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+	vpodClient.Create(vpodNamespace, vpodName, 2, []duckv1alpha1.Placement{{PodName: "statefulset-name-1", VReplicas: 2}})
+
+	nodelist := []runtime.Object{makeNode("node0", "zone0"), makeNode("node1", "zone1")}
+	lsn := listers.NewListers(nodelist)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, EVENSPREAD, lsn.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, lsn.GetPodLister().Pods(testNs), nil, false, nil)
+
+	warmer, ok := s.(scheduler.Warmer)
+	if !ok {
+		t.Fatal("expected the statefulset scheduler to implement scheduler.Warmer")
+	}
+	if err := warmer.Warm(ctx); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// Warming populates the node to zone cache
+	sb := sa.(*stateBuilder)
+	wantNodeToZoneMap := map[string]string{"node0": "zone0", "node1": "zone1"}
+	if !reflect.DeepEqual(sb.nodeToZoneMap, wantNodeToZoneMap) || sb.numZones != 2 {
+		t.Errorf("got node cache %v (%d zones), want %v (2 zones)", sb.nodeToZoneMap, sb.numZones, wantNodeToZoneMap)
+	}
+
+	// A subsequent State is consistent with the warmed caches
+	got, err := sa.State(nil)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := &state{free: []int32{10, 8}, lastOrdinal: 1, capacity: 10, numZones: 2, schedulerPolicy: EVENSPREAD, nodeToZoneMap: wantNodeToZoneMap}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got state %v, want %v", got, want)
+	}
+
+	// A cancelled context is reported
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := warmer.Warm(cancelledCtx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestStatefulsetSchedulerCheck(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), nil, false, nil)
+
+	checker, ok := s.(scheduler.Checker)
+	if !ok {
+		t.Fatal("expected the statefulset scheduler to implement scheduler.Checker")
+	}
+
+	// The statefulset does not exist
+	err := checker.Check()
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("got error %v, want a not found error", err)
+	}
+
+	_, err = kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 1), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// Wait for the informer to observe the statefulset
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return checker.Check() == nil, nil
+	})
+	if err != nil {
+		t.Error("unexpected error", checker.Check())
+	}
+
+	// The informer has not synced
+	s.(*StatefulSetScheduler).statefulSetSynced = func() bool { return false }
+	if err := checker.Check(); err == nil {
+		t.Error("expected an error when the informer has not synced")
+	}
+}
+
+func TestListSchedulerPolicies(t *testing.T) {
+	got := ListSchedulerPolicies()
+	want := []string{string(EVENSPREAD), string(EVENSPREAD_BYREGION), string(MAXFILLUP), string(PREFERREDEVENSPREAD)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got policies %v, want %v", got, want)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	for _, policy := range ListSchedulerPolicies() {
+		if err := ValidatePolicy(SchedulerPolicyType(policy)); err != nil {
+			t.Errorf("unexpected error for policy %s: %v", policy, err)
+		}
+	}
+
+	err := ValidatePolicy("SPREADALL")
+	if err == nil {
+		t.Fatal("expected an error for an unknown policy")
+	}
+	want := `unknown scheduler policy "SPREADALL", available policies: [EVENSPREAD EVENSPREAD_BYREGION MAXFILLUP PREFERREDEVENSPREAD]`
+	if err.Error() != want {
+		t.Errorf("got error %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNewSchedulerRefreshPeriod(t *testing.T) {
+	testCases := []struct {
+		name          string
+		refreshPeriod time.Duration
+		want          time.Duration
+	}{
+		{
+			name:          "zero refresh period",
+			refreshPeriod: 0,
+			want:          minRefreshPeriod,
+		},
+		{
+			name:          "negative refresh period",
+			refreshPeriod: -10 * time.Second,
+			want:          minRefreshPeriod,
+		},
+		{
+			name:          "valid refresh period",
+			refreshPeriod: 30 * time.Second,
+			want:          30 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := setupFakeContext(t)
+			defer cancel()
+
+			vpodClient := tscheduler.NewVPodClient()
+			ls := listers.NewListers(nil)
+			noopEvictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+				return nil
+			}
+
+			s := NewScheduler(ctx, testNs, sfsName, vpodClient.List, tc.refreshPeriod, 10, MAXFILLUP, false, false, ZoneLabel, RegionLabel, ls.GetNodeLister(), noopEvictor, nil).(*StatefulSetScheduler)
+
+			got := s.autoscaler.(*autoscaler).refreshPeriod
+			if got != tc.want {
+				t.Errorf("got refresh period %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatefulsetSchedulerReplicasDecrease(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+	defer cancel()
+
+	vpodClient := tscheduler.NewVPodClient()
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 10, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 2},
+		{PodName: "statefulset-name-1", VReplicas: 2},
+		{PodName: "statefulset-name-2", VReplicas: 2},
+		{PodName: "statefulset-name-3", VReplicas: 2},
+		{PodName: "statefulset-name-4", VReplicas: 2},
+	})
+
+	var lock sync.Mutex
+	evicted := make([]duckv1alpha1.Placement, 0)
+	evictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		lock.Lock()
+		defer lock.Unlock()
+		evicted = append(evicted, *from)
+		return nil
+	}
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 5), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), evictor, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.reserved[vpod.GetKey()] = map[string]int32{"statefulset-name-4": 3}
+	}()
+
+	_, err = kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Update(ctx, makeStatefulset(testNs, sfsName, 3), metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.replicas != 3 {
+			t.Fatalf("expected number of statefulset replica to be 3 (got %d)", s.replicas)
+		}
+		if len(s.reserved) != 0 {
+			t.Errorf("expected reserved vreplicas on removed pods to be released, got %v", s.reserved)
+		}
+	}()
+
+	lock.Lock()
+	wantEvicted := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-3", VReplicas: 2},
+		{PodName: "statefulset-name-4", VReplicas: 2},
+	}
+	if !reflect.DeepEqual(evicted, wantEvicted) {
+		t.Errorf("got evictions %v, want %v", evicted, wantEvicted)
+	}
+	lock.Unlock()
+
+	// Reschedule the vpod once the evictions have been committed.
+	placements, err := s.Schedule(tscheduler.NewVPod(vpodNamespace, vpodName, 10, vpod.GetPlacements()[:3]))
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	want := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 6},
+		{PodName: "statefulset-name-1", VReplicas: 2},
+		{PodName: "statefulset-name-2", VReplicas: 2},
+	}
+	if !reflect.DeepEqual(placements, want) {
+		t.Errorf("got %v, want %v", placements, want)
+	}
+}
+
+func TestStatefulsetSchedulerReplicasDecreaseNotLeader(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+	defer cancel()
+
+	vpodClient := tscheduler.NewVPodClient()
+	vpodClient.Create(vpodNamespace, vpodName, 4, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 2},
+		{PodName: "statefulset-name-1", VReplicas: 2},
+	})
+
+	evictions := 0
+	evictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		evictions++
+		return nil
+	}
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	notLeader := func() bool { return false }
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), evictor, false, notLeader).(*StatefulSetScheduler)
+
+	s.evictAbove(1)
+	if evictions != 0 {
+		t.Errorf("got %d evictions on a non-leader replica, want 0", evictions)
+	}
+}
+
+func TestStatefulsetSchedulerEvict(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+	defer cancel()
+
+	vpodClient := tscheduler.NewVPodClient()
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 12, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 4},
+		{PodName: "statefulset-name-1", VReplicas: 4},
+		{PodName: "statefulset-name-2", VReplicas: 4},
+	})
+	vpodClient.Create(vpodNamespace, "other-source", 5, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 5},
+	})
+
+	var lock sync.Mutex
+	evicted := make([]duckv1alpha1.Placement, 0)
+	evictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		lock.Lock()
+		defer lock.Unlock()
+		evicted = append(evicted, *from)
+		return nil
+	}
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 3), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), evictor, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	if err := s.Evict("statefulset-name-2"); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	lock.Lock()
+	wantEvicted := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-2", VReplicas: 4},
+	}
+	if !reflect.DeepEqual(evicted, wantEvicted) {
+		t.Errorf("got evictions %v, want %v", evicted, wantEvicted)
+	}
+	lock.Unlock()
+
+	// Reschedule the vpod once the eviction has been committed.
+	vpodClient = tscheduler.NewVPodClient()
+	vpodClient.Create(vpodNamespace, "other-source", 5, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 5},
+	})
+	vpod = vpodClient.Create(vpodNamespace, vpodName, 12, vpod.GetPlacements()[:2])
+	s.stateAccessor = newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+
+	placements, err := s.Schedule(vpod)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	want := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 5},
+		{PodName: "statefulset-name-1", VReplicas: 7},
+	}
+	if !reflect.DeepEqual(placements, want) {
+		t.Errorf("got placements %v, want %v", placements, want)
+	}
+
+	// Once restored, the pod can receive new vreplicas again
+	s.Restore("statefulset-name-2")
+	placements, err = s.Schedule(tscheduler.NewVPod(vpodNamespace, vpodName, 18, placements))
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if p := scheduler.GetPlacementForPod(placements, "statefulset-name-2"); p == nil || p.VReplicas != 3 {
+		t.Errorf("got placements %v, want 3 vreplicas on statefulset-name-2", placements)
+	}
+}
+
+func TestStatefulsetSchedulerEvictFailure(t *testing.T) {
+	ctx, cancel := setupFakeContext(t)
+	defer cancel()
+
+	vpodClient := tscheduler.NewVPodClient()
+	vpodClient.Create(vpodNamespace, vpodName, 8, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 4},
+		{PodName: "statefulset-name-1", VReplicas: 4},
+	})
+
+	evictor := func(vpod scheduler.VPod, from *duckv1alpha1.Placement) error {
+		return errors.New("eviction failed")
+	}
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, ls.GetPodLister().Pods(testNs), evictor, false, nil).(*StatefulSetScheduler)
+
+	if err := s.Evict("statefulset-name-1"); err == nil {
+		t.Fatal("expected an error when the eviction fails")
+	}
+
+	// The pod wasn't drained and must remain available for new vreplicas
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.evicted[1] {
+		t.Error("got statefulset-name-1 marked as evicted after a failed eviction")
+	}
+}
+
+func TestStatefulsetSchedulerPlanSchedule(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, 2), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	vpod := vpodClient.Create(vpodNamespace, vpodName, 25, []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 3},
+	})
+
+	ls := listers.NewListers(nil)
+	sa := newStateBuilder(ctx, vpodClient.List, 10, MAXFILLUP, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	autoscaler := &recordingAutoscaler{}
+	s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, autoscaler, ls.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+	// Give some time for the informer to notify the scheduler and set the number of replicas
+	time.Sleep(200 * time.Millisecond)
+
+	// Another vpod has reserved, but not yet committed, vreplicas on the second pod
+	otherKey := types.NamespacedName{Namespace: vpodNamespace, Name: "other-source"}
+	s.reserved[otherKey] = map[string]int32{"statefulset-name-1": 4}
+	reserved := copyReserved(s.reserved)
+
+	planned, pending, err := s.PlanSchedule(vpod)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	wantPlacements := []duckv1alpha1.Placement{
+		{PodName: "statefulset-name-0", VReplicas: 10},
+		{PodName: "statefulset-name-1", VReplicas: 6},
+	}
+	if !reflect.DeepEqual(planned, wantPlacements) || pending != 9 {
+		t.Errorf("got planned placements %v (pending %d), want %v (pending 9)", planned, pending, wantPlacements)
+	}
+	if !reflect.DeepEqual(s.reserved, reserved) {
+		t.Errorf("got reserved %v after planning, want %v", s.reserved, reserved)
+	}
+	if len(s.pending) != 0 || autoscaler.calls != 0 {
+		t.Errorf("got pending %v and %d autoscaler calls after planning, want none", s.pending, autoscaler.calls)
+	}
+
+	placements, err := s.Schedule(vpod)
+	if !errors.Is(err, scheduler.ErrNotEnoughReplicas) {
+		t.Fatalf("got error %v, want %v", err, scheduler.ErrNotEnoughReplicas)
+	}
+	if !reflect.DeepEqual(placements, planned) {
+		t.Errorf("got placements %v, want the planned placements %v", placements, planned)
+	}
+	if autoscaler.calls != 1 {
+		t.Errorf("got %d autoscaler calls, want 1", autoscaler.calls)
+	}
+}
+
+// recordingAutoscaler counts the autoscaling requests
+type recordingAutoscaler struct {
+	calls int
+}
+
+func (a *recordingAutoscaler) Start(ctx context.Context) {}
+
+func (a *recordingAutoscaler) Autoscale(pending int32) {
+	a.calls++
+}
+
+// TestEvenSpreadPlacementsUnchanged verifies the EVENSPREAD placements are identical to the ones computed by
+// the original implementation, which looked up placements and zone totals by scanning the placements.
+func TestEvenSpreadPlacementsUnchanged(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < 500; i++ {
+		replicas := int32(1 + rnd.Intn(30))
+		podlist := make([]runtime.Object, 0, replicas)
+		nodeToZoneMap := make(map[string]string, numZones)
+		for z := 0; z < numZones; z++ {
+			nodeToZoneMap["node"+fmt.Sprint(z)] = "zone" + fmt.Sprint(z)
+		}
+		free := make([]int32, replicas)
+		var placements []duckv1alpha1.Placement
+		for ordinal := int32(0); ordinal < replicas; ordinal++ {
+			nodeName := "node" + fmt.Sprint(ordinal%numZones)
+			podName := podNameFromOrdinal(sfsName, ordinal)
+			podlist = append(podlist, makePod(testNs, podName, nodeName))
+			free[ordinal] = int32(rnd.Intn(11))
+			if rnd.Intn(2) == 0 {
+				placements = append(placements, duckv1alpha1.Placement{PodName: podName, ZoneName: nodeToZoneMap[nodeName], VReplicas: int32(1 + rnd.Intn(5))})
+			}
+		}
+		newState := func() *state {
+			return &state{free: append([]int32(nil), free...), lastOrdinal: replicas - 1, capacity: 10, numZones: numZones, schedulerPolicy: EVENSPREAD, nodeToZoneMap: nodeToZoneMap}
+		}
+		lsp := listers.NewListers(podlist)
+		s := &StatefulSetScheduler{
+			logger:          zap.NewNop().Sugar(),
+			statefulSetName: sfsName,
+			podLister:       lsp.GetPodLister().Pods(testNs),
+			replicas:        replicas,
+		}
+		diff := int32(1 + rnd.Intn(50))
+		evenSpread := int32(1 + rnd.Intn(20))
+
+		wantState, gotState := newState(), newState()
+		wantPlacements, wantLeft := addReplicasEvenSpreadReference(s, wantState, diff, placements, evenSpread)
+		gotPlacements, gotLeft := s.addReplicasEvenSpread(gotState, diff, placements, evenSpread, 1)
+		if !reflect.DeepEqual(gotPlacements, wantPlacements) || gotLeft != wantLeft || !reflect.DeepEqual(gotState.free, wantState.free) {
+			t.Fatalf("add replicas: got %v (left %d, free %v), want %v (left %d, free %v)", gotPlacements, gotLeft, gotState.free, wantPlacements, wantLeft, wantState.free)
+		}
+
+		wantPlacements = removeReplicasEvenSpreadReference(s, diff, placements, evenSpread)
+		gotPlacements = s.removeReplicasEvenSpread(gotState, diff, placements, evenSpread)
+		if !reflect.DeepEqual(gotPlacements, wantPlacements) {
+			t.Fatalf("remove replicas: got %v, want %v", gotPlacements, wantPlacements)
+		}
+	}
+}
+
+func TestStickyPlacementsScaleDown(t *testing.T) {
+	testCases := []struct {
+		name       string
+		placements []duckv1alpha1.Placement
+		diff       int32
+		evenSpread int32 // zero for MAXFILLUP
+		sticky     bool
+		wantMoved  int
+	}{
+		{
+			name: "maxfillup, default",
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", VReplicas: 10},
+				{PodName: "statefulset-name-1", VReplicas: 5},
+				{PodName: "statefulset-name-2", VReplicas: 1},
+				{PodName: "statefulset-name-3", VReplicas: 1},
+			},
+			diff:      3,
+			wantMoved: 3,
+		},
+		{
+			name: "maxfillup, sticky",
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", VReplicas: 10},
+				{PodName: "statefulset-name-1", VReplicas: 5},
+				{PodName: "statefulset-name-2", VReplicas: 1},
+				{PodName: "statefulset-name-3", VReplicas: 1},
+			},
+			diff:      3,
+			sticky:    true,
+			wantMoved: 1,
+		},
+		{
+			name: "maxfillup, sticky, no single pod can absorb the scale down",
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", VReplicas: 2},
+				{PodName: "statefulset-name-1", VReplicas: 2},
+			},
+			diff:      3,
+			sticky:    true,
+			wantMoved: 2,
+		},
+		{
+			name: "evenspread, default",
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 3},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 2},
+				{PodName: "statefulset-name-2", ZoneName: "zone2", VReplicas: 2},
+				{PodName: "statefulset-name-3", ZoneName: "zone0", VReplicas: 1},
+			},
+			diff:       2,
+			evenSpread: 2,
+			wantMoved:  2,
+		},
+		{
+			name: "evenspread, sticky",
+			placements: []duckv1alpha1.Placement{
+				{PodName: "statefulset-name-0", ZoneName: "zone0", VReplicas: 3},
+				{PodName: "statefulset-name-1", ZoneName: "zone1", VReplicas: 2},
+				{PodName: "statefulset-name-2", ZoneName: "zone2", VReplicas: 2},
+				{PodName: "statefulset-name-3", ZoneName: "zone0", VReplicas: 1},
+			},
+			diff:       2,
+			evenSpread: 2,
+			sticky:     true,
+			wantMoved:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &StatefulSetScheduler{
+				logger:           zap.NewNop().Sugar(),
+				statefulSetName:  sfsName,
+				stickyPlacements: tc.sticky,
+			}
+
+			var placements []duckv1alpha1.Placement
+			if tc.evenSpread > 0 {
+				placements = s.removeReplicasEvenSpread(&state{schedulerPolicy: EVENSPREAD}, tc.diff, tc.placements, tc.evenSpread)
+			} else {
+				placements = s.removeReplicas(tc.diff, tc.placements)
+			}
+
+			if got, want := scheduler.GetTotalVReplicas(placements), scheduler.GetTotalVReplicas(tc.placements)-tc.diff; got != want {
+				t.Errorf("got %d vreplicas, want %d", got, want)
+			}
+			if moved := movedPods(tc.placements, placements); moved != tc.wantMoved {
+				t.Errorf("got %d pods with changed vreplicas, want %d (placements %v)", moved, tc.wantMoved, placements)
+			}
+		})
+	}
+}
+
+// movedPods returns the number of pods whose vreplicas differ between the two placements.
+func movedPods(before, after []duckv1alpha1.Placement) int {
+	vreplicas := make(map[string]int32, len(before))
+	for _, p := range before {
+		vreplicas[p.PodName] += p.VReplicas
+	}
+	for _, p := range after {
+		vreplicas[p.PodName] -= p.VReplicas
+	}
+	moved := 0
+	for _, v := range vreplicas {
+		if v != 0 {
+			moved++
+		}
+	}
+	return moved
+}
+
+// addReplicasEvenSpreadReference is the original implementation of addReplicasEvenSpread.
+func addReplicasEvenSpreadReference(s *StatefulSetScheduler, state *state, diff int32, placements []duckv1alpha1.Placement, evenSpread int32) ([]duckv1alpha1.Placement, int32) {
+	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
+
+	placementsByZone := getPlacementsByZoneKey(placements)
+	zoneNames := make([]string, 0, len(placementsByZone))
+	for zoneName := range placementsByZone {
+		zoneNames = append(zoneNames, zoneName)
+	}
+	sort.Strings(zoneNames)
+
+	for i := 0; i < len(zoneNames); i++ {
+		totalInZone := getTotalVReplicasInZone(placements, zoneNames[i])
+		placementOrdinals := placementsByZone[zoneNames[i]]
+		for j := 0; j < len(placementOrdinals); j++ {
+			ordinal := placementOrdinals[j]
+			placement := getPlacementFromPodOrdinalReference(s, placements, ordinal)
+			f := state.Free(ordinal)
+			if diff >= 0 && f > 0 && totalInZone < evenSpread && !s.isOnUnschedulableNode(state, placement.PodName) {
+				allocation := integer.Int32Min(diff, integer.Int32Min(f, (evenSpread-totalInZone)))
+				newPlacements = append(newPlacements, duckv1alpha1.Placement{
+					PodName:   placement.PodName,
+					ZoneName:  placement.ZoneName,
+					VReplicas: placement.VReplicas + allocation,
+				})
+				diff -= allocation
+				state.SetFree(ordinal, f-allocation)
+				totalInZone += allocation
+			} else {
+				newPlacements = append(newPlacements, placement)
+			}
+		}
+	}
+
+	if diff > 0 {
+		for ordinal := int32(0); ordinal < s.replicas; ordinal++ {
+			f := state.Free(ordinal)
+			if f > 0 {
+				podName := podNameFromOrdinal(s.statefulSetName, ordinal)
+				if s.isOnUnschedulableNode(state, podName) {
+					continue
+				}
+				zoneName, err := s.getZoneNameFromPod(state, podName)
+				if err != nil {
+					continue
+				}
+				totalInZone := getTotalVReplicasInZone(newPlacements, zoneName)
+				if totalInZone >= evenSpread {
+					continue
+				}
+				allocation := integer.Int32Min(diff, integer.Int32Min(f, (evenSpread-totalInZone)))
+				newPlacements = append(newPlacements, duckv1alpha1.Placement{
+					PodName:   podName,
+					ZoneName:  zoneName,
+					VReplicas: allocation,
+				})
+				diff -= allocation
+				state.SetFree(ordinal, f-allocation)
+				placementsByZone[zoneName] = append(placementsByZone[zoneName], ordinal)
+			}
+			if diff == 0 {
+				break
+			}
+		}
+	}
+	return newPlacements, diff
+}
+
+// removeReplicasEvenSpreadReference is the original implementation of removeReplicasEvenSpread.
+func removeReplicasEvenSpreadReference(s *StatefulSetScheduler, diff int32, placements []duckv1alpha1.Placement, evenSpread int32) []duckv1alpha1.Placement {
+	newPlacements := make([]duckv1alpha1.Placement, 0, len(placements))
+
+	placementsByZone := getPlacementsByZoneKey(placements)
+	zoneNames := make([]string, 0, len(placementsByZone))
+	for zoneName := range placementsByZone {
+		zoneNames = append(zoneNames, zoneName)
+	}
+	sort.Strings(zoneNames)
+
+	for i := 0; i < len(zoneNames); i++ {
+		totalInZone := getTotalVReplicasInZone(placements, zoneNames[i])
+		placementOrdinals := placementsByZone[zoneNames[i]]
+		for j := len(placementOrdinals) - 1; j >= 0; j-- {
+			ordinal := placementOrdinals[j]
+			placement := getPlacementFromPodOrdinalReference(s, placements, ordinal)
+			if diff > 0 && totalInZone >= evenSpread {
+				deallocation := integer.Int32Min(diff, integer.Int32Min(placement.VReplicas, totalInZone-evenSpread))
+				if deallocation > 0 && deallocation < placement.VReplicas {
+					newPlacements = append(newPlacements, duckv1alpha1.Placement{
+						PodName:   placement.PodName,
+						ZoneName:  placement.ZoneName,
+						VReplicas: placement.VReplicas - deallocation,
+					})
+					diff -= deallocation
+					totalInZone -= deallocation
+				} else if deallocation >= placement.VReplicas {
+					diff -= placement.VReplicas
+					totalInZone -= placement.VReplicas
+				} else {
+					newPlacements = append(newPlacements, placement)
+				}
+			} else {
+				newPlacements = append(newPlacements, placement)
+			}
+		}
+	}
+	return newPlacements
+}
+
+func getPlacementFromPodOrdinalReference(s *StatefulSetScheduler, placements []duckv1alpha1.Placement, ordinal int32) (placement duckv1alpha1.Placement) {
+	for i := 0; i < len(placements); i++ {
+		if placements[i].PodName == podNameFromOrdinal(s.statefulSetName, ordinal) {
+			return placements[i]
+		}
+	}
+	return placement
+}
+
+func BenchmarkStatefulsetScheduler(b *testing.B) {
+	const (
+		replicas   = 100
+		vreplicas  = 500
+		numVPods   = 200
+		capacity   = 20
+		vpodPrefix = "vpod-"
+	)
+
+	for _, policy := range []SchedulerPolicyType{MAXFILLUP, EVENSPREAD} {
+		b.Run(string(policy), func(b *testing.B) {
+			ctx, cancel := setupFakeContext(b)
+			defer cancel()
+
+			nodelist := make([]runtime.Object, 0, numZones)
+			for i := int32(0); i < numZones; i++ {
+				nodelist = append(nodelist, makeNode("node"+fmt.Sprint(i), "zone"+fmt.Sprint(i)))
+			}
+			podlist := make([]runtime.Object, 0, replicas)
+			for i := int32(0); i < replicas; i++ {
+				podlist = append(podlist, makePod(testNs, podNameFromOrdinal(sfsName, i), "node"+fmt.Sprint(i%numZones)))
+			}
+
+			// Existing vpods each placed on a single pod
+			vpodClient := tscheduler.NewVPodClient()
+			for i := int32(0); i < numVPods; i++ {
+				podName := podNameFromOrdinal(sfsName, i%replicas)
+				zoneName := "zone" + fmt.Sprint((i%replicas)%numZones)
+				vpodClient.Create(vpodNamespace, vpodPrefix+fmt.Sprint(i), 1, []duckv1alpha1.Placement{{PodName: podName, ZoneName: zoneName, VReplicas: 1}})
+			}
+
+			_, err := kubeclient.Get(ctx).AppsV1().StatefulSets(testNs).Create(ctx, makeStatefulset(testNs, sfsName, replicas), metav1.CreateOptions{})
+			if err != nil {
+				b.Fatal("unexpected error", err)
+			}
+			lsn := listers.NewListers(nodelist)
+			sa := newStateBuilder(ctx, vpodClient.List, capacity, policy, lsn.GetNodeLister(), false, ZoneLabel, RegionLabel)
+			lsp := listers.NewListers(podlist)
+			s := NewStatefulSetScheduler(ctx, testNs, sfsName, vpodClient.List, sa, nil, lsp.GetPodLister().Pods(testNs), nil, false, nil).(*StatefulSetScheduler)
+
+			// Give some time for the informer to notify the scheduler and set the number of replicas
+			time.Sleep(200 * time.Millisecond)
+
+			vpod := tscheduler.NewVPod(vpodNamespace, vpodName, vreplicas, nil)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Schedule(vpod); err != nil {
+					b.Fatal("unexpected error", err)
+				}
+
+				// Drop the reservations so that each iteration schedules from the same state
+				s.lock.Lock()
+				s.reserved = make(map[types.NamespacedName]map[string]int32)
+				s.lock.Unlock()
+			}
+		})
+	}
+}
+
 func makeStatefulset(ns, name string, replicas int32) *appsv1.StatefulSet {
 	obj := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -403,6 +1495,12 @@ func makeNode(name, zonename string) *corev1.Node {
 	return obj
 }
 
+func makeRegionNode(name, zonename, regionname string) *corev1.Node {
+	obj := makeNode(name, zonename)
+	obj.Labels[RegionLabel] = regionname
+	return obj
+}
+
 func makeNodeNoLabel(name string) *corev1.Node {
 	obj := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -425,7 +1523,37 @@ func makePod(ns, name, nodename string) *corev1.Pod {
 	return obj
 }
 
-func setupFakeContext(t *testing.T) (context.Context, context.CancelFunc) {
+func makeNotReadyPod(ns, name, nodename string) *corev1.Pod {
+	obj := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodename,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	return obj
+}
+
+func makePendingPod(ns, name string) *corev1.Pod {
+	obj := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+	}
+	return obj
+}
+
+func setupFakeContext(t testing.TB) (context.Context, context.CancelFunc) {
 	ctx, cancel, informers := rectesting.SetupFakeContextWithCancel(t)
 	err := controller.StartInformers(ctx.Done(), informers...)
 	if err != nil {
@@ -18,6 +18,8 @@ package statefulset
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/labels"
@@ -27,6 +29,9 @@ import (
 	"knative.dev/pkg/logging"
 )
 
+// nodeCacheTTL is how long the node to zone mapping is reused before listing the nodes again
+const nodeCacheTTL = 5 * time.Second
+
 type stateAccessor interface {
 	// State returns the current state (snapshot) about placed vpods
 	// Take into account reserved vreplicas and update `reserved` to reflect
@@ -55,6 +60,16 @@ type state struct {
 
 	// Mapping node names of nodes currently in cluster to their zone info
 	nodeToZoneMap map[string]string
+
+	// Names of the unschedulable (cordoned) nodes counted in the zone info above.
+	// No new vreplicas are placed on pods running on these nodes.
+	unschedulableNodes map[string]bool
+
+	// Number of regions in cluster (EVENSPREAD_BYREGION only)
+	numRegions int32
+
+	// Mapping node names of nodes currently in cluster to their region info (EVENSPREAD_BYREGION only)
+	nodeToRegionMap map[string]string
 }
 
 // Free safely returns the free capacity at the given ordinal
@@ -65,18 +80,35 @@ func (s *state) Free(ordinal int32) int32 {
 	return s.free[ordinal]
 }
 
+// numSpreadDomains returns the number of failure domains the vreplicas are evenly spread across:
+// the number of regions for the EVENSPREAD_BYREGION policy, the number of zones otherwise
+func (s *state) numSpreadDomains() int32 {
+	if s.schedulerPolicy == EVENSPREAD_BYREGION {
+		return s.numRegions
+	}
+	return s.numZones
+}
+
 // SetFree safely sets the free capacity at the given ordinal
 func (s *state) SetFree(ordinal int32, value int32) {
 	s.free = grow(s.free, ordinal, s.capacity)
 	s.free[int(ordinal)] = value
 }
 
+// Snapshot returns a copy of the free capacity of each pod, indexed by ordinal.
+// Prefer Snapshot and Free over reading the free slice directly.
+func (s *state) Snapshot() []int32 {
+	snapshot := make([]int32, len(s.free))
+	copy(snapshot, s.free)
+	return snapshot
+}
+
 // freeCapacity returns the number of vreplicas that can be used,
 // up to the last ordinal
 func (s *state) freeCapacity() int32 {
 	t := int32(0)
 	for i := int32(0); i <= s.lastOrdinal; i++ {
-		t += s.free[i]
+		t += s.Free(i)
 	}
 	return t
 }
@@ -89,17 +121,58 @@ type stateBuilder struct {
 	capacity        int32
 	schedulerPolicy SchedulerPolicyType
 	nodeLister      corev1.NodeLister
+
+	// excludeUnschedulableNodes removes the zones (regions) only made of unschedulable
+	// (cordoned) nodes from the zone (region) counts, reshaping the spread target while
+	// the nodes are cordoned. By default they are counted so that short cordons don't.
+	excludeUnschedulableNodes bool
+
+	// zoneLabel is the node label holding the zone of the node
+	zoneLabel string
+
+	// regionLabel is the node label holding the region of the node
+	regionLabel string
+
+	// nodeCacheLock protects the cached node to zone (and region) mapping below
+	nodeCacheLock      sync.Mutex
+	nodeToZoneMap      map[string]string
+	unschedulableNodes map[string]bool
+	numZones           int32
+	nodeToRegionMap    map[string]string
+	numRegions         int32
+	nodeCacheExpiry    time.Time
+}
+
+// zoneInfo is the node to zone (and region) mapping the state is built from
+type zoneInfo struct {
+	nodeToZoneMap      map[string]string
+	unschedulableNodes map[string]bool
+	numZones           int32
+	nodeToRegionMap    map[string]string
+	numRegions         int32
 }
 
-// newStateBuilder returns a StateAccessor recreating the state from scratch each time it is requested
-func newStateBuilder(ctx context.Context, lister scheduler.VPodLister, podCapacity int32, schedulerPolicy SchedulerPolicyType, nodeLister corev1.NodeLister) stateAccessor {
+// newStateBuilder returns a StateAccessor recreating the state from scratch each time it is requested.
+// The zone (region) of the nodes is read from the zoneLabel (regionLabel) node label, ZoneLabel
+// (RegionLabel) when empty.
+func newStateBuilder(ctx context.Context, lister scheduler.VPodLister, podCapacity int32, schedulerPolicy SchedulerPolicyType, nodeLister corev1.NodeLister, excludeUnschedulableNodes bool, zoneLabel, regionLabel string) stateAccessor {
+	if zoneLabel == "" {
+		zoneLabel = ZoneLabel
+	}
+	if regionLabel == "" {
+		regionLabel = RegionLabel
+	}
+
 	return &stateBuilder{
-		ctx:             ctx,
-		logger:          logging.FromContext(ctx),
-		vpodLister:      lister,
-		capacity:        podCapacity,
-		schedulerPolicy: schedulerPolicy,
-		nodeLister:      nodeLister,
+		ctx:                       ctx,
+		logger:                    logging.FromContext(ctx),
+		vpodLister:                lister,
+		capacity:                  podCapacity,
+		schedulerPolicy:           schedulerPolicy,
+		nodeLister:                nodeLister,
+		excludeUnschedulableNodes: excludeUnschedulableNodes,
+		zoneLabel:                 zoneLabel,
+		regionLabel:               regionLabel,
 	}
 }
 
@@ -115,10 +188,17 @@ func (s *stateBuilder) State(reserved map[types.NamespacedName]map[string]int32)
 	// keep track of (vpod key, podname) pairs with existing placements
 	withPlacement := make(map[types.NamespacedName]map[string]bool)
 
+	// keep track of the capacity consumed by each vreplica of weighted vpods
+	weights := make(map[types.NamespacedName]int32)
+
 	for _, vpod := range vpods {
 		ps := vpod.GetPlacements()
+		weight := scheduler.GetVReplicaWeight(vpod)
 
 		withPlacement[vpod.GetKey()] = make(map[string]bool)
+		if weight > 1 {
+			weights[vpod.GetKey()] = weight
+		}
 
 		for i := 0; i < len(ps); i++ {
 			podName := ps[i].PodName
@@ -127,7 +207,7 @@ func (s *stateBuilder) State(reserved map[types.NamespacedName]map[string]int32)
 			// Account for reserved vreplicas
 			vreplicas = withReserved(vpod.GetKey(), podName, vreplicas, reserved)
 
-			free, last = s.updateFreeCapacity(free, last, podName, vreplicas)
+			free, last = s.updateFreeCapacity(free, last, podName, vreplicas*weight)
 
 			withPlacement[vpod.GetKey()][podName] = true
 		}
@@ -143,36 +223,114 @@ func (s *stateBuilder) State(reserved map[types.NamespacedName]map[string]int32)
 				}
 			}
 
-			free, last = s.updateFreeCapacity(free, last, podName, rvreplicas)
+			weight, ok := weights[key]
+			if !ok {
+				weight = 1
+			}
+			free, last = s.updateFreeCapacity(free, last, podName, rvreplicas*weight)
 		}
 	}
 
-	if s.schedulerPolicy == EVENSPREAD {
-		//TODO: need a node watch to see if # nodes/ # zones have gone up or down
-		nodes, err := s.nodeLister.List(labels.Everything())
+	if s.schedulerPolicy.isEvenSpread() {
+		zones, err := s.zones()
 		if err != nil {
 			return nil, err
 		}
 
-		nodeToZoneMap := make(map[string]string, len(nodes))
-		zoneMap := make(map[string]struct{})
-		for i := 0; i < len(nodes); i++ {
-			node := nodes[i]
-			zoneName, ok := node.GetLabels()[ZoneLabel]
-			if !ok {
-				continue //ignore node that doesn't have zone info (maybe a test setup or control node)
+		return &state{free: free, lastOrdinal: last, capacity: s.capacity, numZones: zones.numZones, schedulerPolicy: s.schedulerPolicy, nodeToZoneMap: zones.nodeToZoneMap, unschedulableNodes: zones.unschedulableNodes,
+			numRegions: zones.numRegions, nodeToRegionMap: zones.nodeToRegionMap}, nil
+
+	}
+	return &state{free: free, lastOrdinal: last, capacity: s.capacity, schedulerPolicy: s.schedulerPolicy}, nil
+}
+
+// zones returns the node to zone mapping, the unschedulable nodes counted in that
+// mapping and the number of zones, as well as the node to region mapping and the
+// number of regions for the EVENSPREAD_BYREGION policy. The cached values are reused
+// until they expire or are invalidated by a node change. The returned maps must not
+// be modified.
+func (s *stateBuilder) zones() (zoneInfo, error) {
+	s.nodeCacheLock.Lock()
+	defer s.nodeCacheLock.Unlock()
+
+	if s.nodeToZoneMap != nil && time.Now().Before(s.nodeCacheExpiry) {
+		return s.cachedZones(), nil
+	}
+
+	nodes, err := s.nodeLister.List(labels.Everything())
+	if err != nil {
+		return zoneInfo{}, err
+	}
+
+	nodeToZoneMap := make(map[string]string, len(nodes))
+	var unschedulableNodes map[string]bool
+	zoneMap := make(map[string]struct{})
+	var nodeToRegionMap map[string]string
+	regionMap := make(map[string]struct{})
+	if s.schedulerPolicy == EVENSPREAD_BYREGION {
+		nodeToRegionMap = make(map[string]string, len(nodes))
+	}
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[i]
+		// No new vreplicas are placed on cordoned nodes, but the pods already running there
+		// still need their zone (region)
+		counted := true
+		if node.Spec.Unschedulable {
+			if unschedulableNodes == nil {
+				unschedulableNodes = make(map[string]bool)
+			}
+			unschedulableNodes[node.Name] = true
+			counted = !s.excludeUnschedulableNodes
+		}
+
+		if regionName, ok := node.GetLabels()[s.regionLabel]; ok && nodeToRegionMap != nil {
+			nodeToRegionMap[node.Name] = regionName
+			if counted {
+				regionMap[regionName] = struct{}{}
 			}
+		}
+
+		zoneName, ok := node.GetLabels()[s.zoneLabel]
+		if !ok {
+			continue //ignore node that doesn't have zone info (maybe a test setup or control node)
+		}
 
-			nodeToZoneMap[node.Name] = zoneName
+		nodeToZoneMap[node.Name] = zoneName
+		if counted {
 			zoneMap[zoneName] = struct{}{}
 		}
+	}
 
-		return &state{free: free, lastOrdinal: last, capacity: s.capacity, numZones: int32(len(zoneMap)), schedulerPolicy: s.schedulerPolicy, nodeToZoneMap: nodeToZoneMap}, nil
+	s.nodeToZoneMap = nodeToZoneMap
+	s.unschedulableNodes = unschedulableNodes
+	s.numZones = int32(len(zoneMap))
+	s.nodeToRegionMap = nodeToRegionMap
+	s.numRegions = int32(len(regionMap))
+	s.nodeCacheExpiry = time.Now().Add(nodeCacheTTL)
 
+	return s.cachedZones(), nil
+}
+
+// cachedZones returns the cached zone info. The node cache lock must be held.
+func (s *stateBuilder) cachedZones() zoneInfo {
+	return zoneInfo{
+		nodeToZoneMap:      s.nodeToZoneMap,
+		unschedulableNodes: s.unschedulableNodes,
+		numZones:           s.numZones,
+		nodeToRegionMap:    s.nodeToRegionMap,
+		numRegions:         s.numRegions,
 	}
-	return &state{free: free, lastOrdinal: last, capacity: s.capacity, schedulerPolicy: s.schedulerPolicy}, nil
 }
 
+// invalidateNodeCache forces the next State call to list the nodes again
+func (s *stateBuilder) invalidateNodeCache() {
+	s.nodeCacheLock.Lock()
+	defer s.nodeCacheLock.Unlock()
+
+	s.nodeToZoneMap = nil
+}
+
+// updateFreeCapacity consumes the given capacity (vreplicas times their weight) on podName
 func (s *stateBuilder) updateFreeCapacity(free []int32, last int32, podName string, vreplicas int32) ([]int32, int32) {
 	ordinal := ordinalFromPodName(podName)
 	free = grow(free, ordinal, s.capacity)
@@ -23,8 +23,10 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	duckv1alpha1 "knative.dev/eventing-kafka/pkg/apis/duck/v1alpha1"
 	tscheduler "knative.dev/eventing-kafka/pkg/common/scheduler/testing"
 	listers "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -176,7 +178,7 @@ func TestStateBuilder(t *testing.T) {
 			}
 
 			ls := listers.NewListers(nodelist)
-			stateBuilder := newStateBuilder(ctx, vpodClient.List, int32(10), tc.schedulerPolicy, ls.GetNodeLister())
+			stateBuilder := newStateBuilder(ctx, vpodClient.List, int32(10), tc.schedulerPolicy, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
 			state, err := stateBuilder.State(tc.reserved)
 			if err != nil {
 				t.Fatal("unexpected error", err)
@@ -192,3 +194,181 @@ func TestStateBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestStateSnapshot(t *testing.T) {
+	s := &state{capacity: 10, free: []int32{int32(9), int32(5)}, lastOrdinal: 1, schedulerPolicy: MAXFILLUP}
+
+	snapshot := s.Snapshot()
+	if !reflect.DeepEqual(snapshot, []int32{int32(9), int32(5)}) {
+		t.Errorf("unexpected snapshot, got %v, want %v", snapshot, []int32{int32(9), int32(5)})
+	}
+
+	// Mutating the snapshot must not affect the state
+	snapshot[0] = 0
+	if s.Free(0) != 9 {
+		t.Errorf("unexpected free capacity after mutating the snapshot, got %d, want 9", s.Free(0))
+	}
+	if s.freeCapacity() != 14 {
+		t.Errorf("unexpected total free capacity, got %d, want 14", s.freeCapacity())
+	}
+}
+
+// countingNodeLister counts the calls to List
+type countingNodeLister struct {
+	corev1listers.NodeLister
+	listCalls int
+}
+
+func (l *countingNodeLister) List(selector labels.Selector) ([]*v1.Node, error) {
+	l.listCalls++
+	return l.NodeLister.List(selector)
+}
+
+func TestStateBuilderNodeCache(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	ls := listers.NewListers([]runtime.Object{makeNode("node-0", "zone-0"), makeNode("node-1", "zone-1")})
+	nodeLister := &countingNodeLister{NodeLister: ls.GetNodeLister()}
+	sb := newStateBuilder(ctx, vpodClient.List, int32(10), EVENSPREAD, nodeLister, false, ZoneLabel, RegionLabel).(*stateBuilder)
+
+	// Repeated calls within the TTL reuse the node to zone mapping
+	for i := 0; i < 3; i++ {
+		state, err := sb.State(nil)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if state.numZones != 2 {
+			t.Errorf("unexpected number of zones, got %d, want 2", state.numZones)
+		}
+	}
+	if nodeLister.listCalls != 1 {
+		t.Errorf("unexpected number of node list calls, got %d, want 1", nodeLister.listCalls)
+	}
+
+	// Invalidating the cache lists the nodes again
+	sb.invalidateNodeCache()
+	if _, err := sb.State(nil); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if nodeLister.listCalls != 2 {
+		t.Errorf("unexpected number of node list calls, got %d, want 2", nodeLister.listCalls)
+	}
+}
+
+func TestStateBuilderUnschedulableNodes(t *testing.T) {
+	cordoned := makeNode("node-2", "zone-2")
+	cordoned.Spec.Unschedulable = true
+	nodes := []runtime.Object{makeNode("node-0", "zone-0"), makeNode("node-1", "zone-1"), cordoned}
+
+	testCases := []struct {
+		name                      string
+		excludeUnschedulableNodes bool
+		expected                  state
+	}{
+		{
+			name:                      "cordoned node counted",
+			excludeUnschedulableNodes: false,
+			expected:                  state{capacity: 10, free: []int32{}, lastOrdinal: -1, numZones: 3, schedulerPolicy: EVENSPREAD, nodeToZoneMap: map[string]string{"node-0": "zone-0", "node-1": "zone-1", "node-2": "zone-2"}, unschedulableNodes: map[string]bool{"node-2": true}},
+		},
+		{
+			name:                      "cordoned node excluded",
+			excludeUnschedulableNodes: true,
+			expected:                  state{capacity: 10, free: []int32{}, lastOrdinal: -1, numZones: 2, schedulerPolicy: EVENSPREAD, nodeToZoneMap: map[string]string{"node-0": "zone-0", "node-1": "zone-1", "node-2": "zone-2"}, unschedulableNodes: map[string]bool{"node-2": true}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := setupFakeContext(t)
+			vpodClient := tscheduler.NewVPodClient()
+
+			ls := listers.NewListers(nodes)
+			stateBuilder := newStateBuilder(ctx, vpodClient.List, int32(10), EVENSPREAD, ls.GetNodeLister(), tc.excludeUnschedulableNodes, ZoneLabel, RegionLabel)
+			state, err := stateBuilder.State(nil)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+
+			if !reflect.DeepEqual(*state, tc.expected) {
+				t.Errorf("unexpected state, got %v, want %v", state, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStateBuilderZoneLabel(t *testing.T) {
+	const regionLabel = "topology.kubernetes.io/region"
+
+	node := func(name, zone, region string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{ZoneLabel: zone, regionLabel: region},
+			},
+		}
+	}
+	nodes := []runtime.Object{node("node-0", "zone-0", "region-0"), node("node-1", "zone-1", "region-0"), node("node-2", "zone-2", "region-1")}
+
+	testCases := []struct {
+		name      string
+		zoneLabel string
+		expected  state
+	}{
+		{
+			name:      "default zone label",
+			zoneLabel: "",
+			expected:  state{capacity: 10, free: []int32{}, lastOrdinal: -1, numZones: 3, schedulerPolicy: EVENSPREAD, nodeToZoneMap: map[string]string{"node-0": "zone-0", "node-1": "zone-1", "node-2": "zone-2"}},
+		},
+		{
+			name:      "custom zone label",
+			zoneLabel: regionLabel,
+			expected:  state{capacity: 10, free: []int32{}, lastOrdinal: -1, numZones: 2, schedulerPolicy: EVENSPREAD, nodeToZoneMap: map[string]string{"node-0": "region-0", "node-1": "region-0", "node-2": "region-1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := setupFakeContext(t)
+			vpodClient := tscheduler.NewVPodClient()
+
+			ls := listers.NewListers(nodes)
+			stateBuilder := newStateBuilder(ctx, vpodClient.List, int32(10), EVENSPREAD, ls.GetNodeLister(), false, tc.zoneLabel, RegionLabel)
+			state, err := stateBuilder.State(nil)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+
+			if !reflect.DeepEqual(*state, tc.expected) {
+				t.Errorf("unexpected state, got %v, want %v", state, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStateBuilderRegions(t *testing.T) {
+	ctx, _ := setupFakeContext(t)
+	vpodClient := tscheduler.NewVPodClient()
+
+	ls := listers.NewListers([]runtime.Object{
+		makeRegionNode("node-0", "zone-0", "region-0"),
+		makeRegionNode("node-1", "zone-1", "region-0"),
+		makeRegionNode("node-2", "zone-2", "region-1"),
+	})
+	stateBuilder := newStateBuilder(ctx, vpodClient.List, int32(10), EVENSPREAD_BYREGION, ls.GetNodeLister(), false, ZoneLabel, RegionLabel)
+	got, err := stateBuilder.State(nil)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	expected := state{capacity: 10, free: []int32{}, lastOrdinal: -1, numZones: 3, schedulerPolicy: EVENSPREAD_BYREGION,
+		nodeToZoneMap:   map[string]string{"node-0": "zone-0", "node-1": "zone-1", "node-2": "zone-2"},
+		numRegions:      2,
+		nodeToRegionMap: map[string]string{"node-0": "region-0", "node-1": "region-0", "node-2": "region-1"}}
+	if !reflect.DeepEqual(*got, expected) {
+		t.Errorf("unexpected state, got %v, want %v", got, expected)
+	}
+	if n := got.numSpreadDomains(); n != 2 {
+		t.Errorf("got %d spread domains, want 2", n)
+	}
+}
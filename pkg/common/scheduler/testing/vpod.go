@@ -27,6 +27,11 @@ type sampleVPod struct {
 	placements []duckv1alpha1.Placement
 }
 
+type sampleWeightedVPod struct {
+	*sampleVPod
+	weight int32
+}
+
 func NewVPod(ns, name string, vreplicas int32, placements []duckv1alpha1.Placement) *sampleVPod {
 	return &sampleVPod{
 		key: types.NamespacedName{
@@ -38,6 +43,13 @@ func NewVPod(ns, name string, vreplicas int32, placements []duckv1alpha1.Placeme
 	}
 }
 
+func NewWeightedVPod(ns, name string, vreplicas int32, weight int32, placements []duckv1alpha1.Placement) *sampleWeightedVPod {
+	return &sampleWeightedVPod{
+		sampleVPod: NewVPod(ns, name, vreplicas, placements),
+		weight:     weight,
+	}
+}
+
 func (d *sampleVPod) GetKey() types.NamespacedName {
 	return d.key
 }
@@ -49,3 +61,7 @@ func (d *sampleVPod) GetVReplicas() int32 {
 func (d *sampleVPod) GetPlacements() []duckv1alpha1.Placement {
 	return d.placements
 }
+
+func (d *sampleWeightedVPod) GetVReplicaWeight() int32 {
+	return d.weight
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"errors"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+)
+
+// Resource Is The Name Of The KafkaChannel Resource In The Fake Clientset Actions
+const Resource = "kafkachannels"
+
+// StatusSubresource Is The Name Of The KafkaChannel Status Subresource (As Used By UpdateStatus)
+const StatusSubresource = "status"
+
+// Clientset Wraps The Generated Fake Clientset With Helpers For Injecting KafkaChannel API Errors
+type Clientset struct {
+	*fake.Clientset
+}
+
+// NewClientset Returns A Fake Clientset Tracking The Specified Objects
+func NewClientset(objects ...runtime.Object) *Clientset {
+	return &Clientset{Clientset: fake.NewSimpleClientset(objects...)}
+}
+
+// FailOn Makes Every KafkaChannel Action With The Specified Verb (e.g. "update") And Subresource
+// ("" For The KafkaChannel Itself, "status" For UpdateStatus) Return The Specified Error
+func (c *Clientset) FailOn(verb string, subresource string, err error) {
+	c.PrependReactor(verb, Resource, Failure(verb, subresource, err))
+}
+
+// FailOnce Is Like FailOn But Only Fails The First Matching Action, Letting Any Retries Succeed
+func (c *Clientset) FailOnce(verb string, subresource string, err error) {
+	var lock sync.Mutex
+	failed := false
+	failure := Failure(verb, subresource, err)
+	c.PrependReactor(verb, Resource, func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failed {
+			return false, nil, nil
+		}
+		handled, ret, failureErr := failure(action) // Only Matching Actions Are Handled (And Use Up The Failure)
+		failed = handled
+		return handled, ret, failureErr
+	})
+}
+
+// ConflictOnUpdateStatus Makes Every KafkaChannel UpdateStatus Fail With A Conflict Error
+func (c *Clientset) ConflictOnUpdateStatus() {
+	c.PrependReactor("update", Resource, Conflict("update", StatusSubresource))
+}
+
+// Failure Returns A ReactionFunc (e.g. For A TableTest's WithReactors) Failing The KafkaChannel
+// Actions With The Specified Verb And Subresource With The Specified Error
+func Failure(verb string, subresource string, err error) clientgotesting.ReactionFunc {
+	return func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if !action.Matches(verb, Resource) || action.GetSubresource() != subresource {
+			return false, nil, nil
+		}
+		return true, nil, err
+	}
+}
+
+// Conflict Returns A ReactionFunc Failing The KafkaChannel Actions With The Specified Verb And
+// Subresource With A Conflict Error For The KafkaChannel Being Acted Upon
+func Conflict(verb string, subresource string) clientgotesting.ReactionFunc {
+	return func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if !action.Matches(verb, Resource) || action.GetSubresource() != subresource {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewConflict(v1beta1.Resource(Resource), actionName(action),
+			errors.New("the object has been modified; please apply your changes to the latest version and try again"))
+	}
+}
+
+// actionName Returns The Name Of The Object An Action Refers To (Empty If Unknown)
+func actionName(action clientgotesting.Action) string {
+	switch typedAction := action.(type) {
+	case clientgotesting.GetAction:
+		return typedAction.GetName()
+	case clientgotesting.DeleteAction:
+		return typedAction.GetName()
+	case clientgotesting.PatchAction:
+		return typedAction.GetName()
+	case clientgotesting.UpdateAction:
+		if accessor, err := meta.Accessor(typedAction.GetObject()); err == nil {
+			return accessor.GetName()
+		}
+	case clientgotesting.CreateAction:
+		if accessor, err := meta.Accessor(typedAction.GetObject()); err == nil {
+			return accessor.GetName()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-channel"
+)
+
+// Test That An Injected Conflict Is Observed By UpdateStatus Only
+func TestConflictOnUpdateStatus(t *testing.T) {
+	ctx := context.TODO()
+	clientset := NewClientset(newKafkaChannel())
+	clientset.ConflictOnUpdateStatus()
+	channels := clientset.MessagingV1beta1().KafkaChannels(testNamespace)
+
+	_, err := channels.UpdateStatus(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.True(t, apierrors.IsConflict(err))
+	statusErr, ok := err.(*apierrors.StatusError)
+	assert.True(t, ok)
+	assert.Equal(t, testName, statusErr.ErrStatus.Details.Name)
+
+	_, err = channels.Update(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Nil(t, err)
+}
+
+// Test That FailOn Fails Every Matching Action
+func TestFailOn(t *testing.T) {
+	ctx := context.TODO()
+	testErr := errors.New("test error")
+	clientset := NewClientset(newKafkaChannel())
+	clientset.FailOn("get", "", testErr)
+	channels := clientset.MessagingV1beta1().KafkaChannels(testNamespace)
+
+	for i := 0; i < 2; i++ {
+		_, err := channels.Get(ctx, testName, metav1.GetOptions{})
+		assert.Equal(t, testErr, err)
+	}
+
+	list, err := channels.List(ctx, metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, list.Items, 1)
+}
+
+// Test That FailOnce Lets The Retries Succeed
+func TestFailOnce(t *testing.T) {
+	ctx := context.TODO()
+	testErr := errors.New("test error")
+	clientset := NewClientset(newKafkaChannel())
+	clientset.FailOnce("update", StatusSubresource, testErr)
+	channels := clientset.MessagingV1beta1().KafkaChannels(testNamespace)
+
+	_, err := channels.UpdateStatus(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Equal(t, testErr, err)
+
+	_, err = channels.UpdateStatus(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Nil(t, err)
+}
+
+// Test That FailOnce Ignores Non-Matching Actions Rather Than Using Up Its Failure On Them
+func TestFailOnceNonMatchingAction(t *testing.T) {
+	ctx := context.TODO()
+	testErr := errors.New("test error")
+	clientset := NewClientset(newKafkaChannel())
+	clientset.FailOnce("update", StatusSubresource, testErr)
+	channels := clientset.MessagingV1beta1().KafkaChannels(testNamespace)
+
+	_, err := channels.Update(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Nil(t, err)
+
+	_, err = channels.UpdateStatus(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Equal(t, testErr, err)
+
+	_, err = channels.UpdateStatus(ctx, newKafkaChannel(), metav1.UpdateOptions{})
+	assert.Nil(t, err)
+}
+
+// newKafkaChannel Returns A Minimal KafkaChannel For Testing
+func newKafkaChannel() *v1beta1.KafkaChannel {
+	return &v1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/kelseyhightower/envconfig"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 )
@@ -61,6 +62,9 @@ type KafkaEnvConfig struct {
 	KafkaConfigJson  string   `envconfig:"K_KAFKA_CONFIG"`
 	BootstrapServers []string `envconfig:"KAFKA_BOOTSTRAP_SERVERS" required:"true"`
 	Net              AdapterNet
+
+	// InitialOffset is the offset (Latest or Earliest) a new consumer group starts consuming from.
+	InitialOffset sourcesv1beta1.Offset `envconfig:"KAFKA_INITIAL_OFFSET" required:"false"`
 }
 
 // NewConfig extracts the Kafka configuration from the environment.
@@ -75,27 +79,9 @@ func NewConfigFromEnv(ctx context.Context) ([]string, *sarama.Config, error) {
 
 // NewConfig extracts the Kafka configuration from the environment.
 func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sarama.Config, error) {
-	kafkaAuthConfig := &client.KafkaAuthConfig{}
-
-	if env.Net.TLS.Enable {
-		kafkaAuthConfig.TLS = &client.KafkaTlsConfig{
-			Cacert:   env.Net.TLS.CACert,
-			Usercert: env.Net.TLS.Cert,
-			Userkey:  env.Net.TLS.Key,
-		}
-	}
-
-	if env.Net.SASL.Enable {
-		kafkaAuthConfig.SASL = &client.KafkaSaslConfig{
-			User:     env.Net.SASL.User,
-			Password: env.Net.SASL.Password,
-			SaslType: env.Net.SASL.Type,
-		}
-	}
-
 	configBuilder := client.NewConfigBuilder().
 		WithDefaults().
-		WithAuth(kafkaAuthConfig)
+		WithAuth(newKafkaAuthConfig(env.Net))
 
 	if env.KafkaConfigJson != "" {
 		kafkaCfg := &KafkaConfig{}
@@ -112,6 +98,13 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 		return nil, nil, fmt.Errorf("error creating Sarama config: %w", err)
 	}
 
+	switch env.InitialOffset {
+	case sourcesv1beta1.OffsetEarliest:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	case sourcesv1beta1.OffsetLatest:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	}
+
 	return env.BootstrapServers, cfg, nil
 }
 
@@ -126,55 +119,99 @@ func NewConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *source
 
 // NewEnvConfigFromSpec validates and creates a KafkaEnvConfig from a KafkaSource
 func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sourcesv1beta1.KafkaSource) (KafkaEnvConfig, error) {
-	saslUser, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.User.SecretKeyRef)
+	net, err := resolveNet(ctx, kc, obj.Namespace, &obj.Spec.Net)
 	if err != nil {
 		return KafkaEnvConfig{}, err
 	}
 
-	saslPassword, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Password.SecretKeyRef)
+	config := KafkaEnvConfig{
+		BootstrapServers: obj.Spec.BootstrapServers,
+		Net:              net,
+		InitialOffset:    obj.Spec.InitialOffset,
+	}
+
+	return config, nil
+}
+
+// NewKafkaAuthConfigFromSpec resolves the secrets referenced by the KafkaAuthSpec into a KafkaAuthConfig
+// which is ready to be applied with the client.ConfigBuilder.
+func NewKafkaAuthConfigFromSpec(ctx context.Context, kc kubernetes.Interface, namespace string, spec *bindingsv1beta1.KafkaAuthSpec) (*client.KafkaAuthConfig, error) {
+	net, err := resolveNet(ctx, kc, namespace, &spec.Net)
 	if err != nil {
-		return KafkaEnvConfig{}, err
+		return nil, err
 	}
+	return newKafkaAuthConfig(net), nil
+}
+
+// newKafkaAuthConfig creates the KafkaAuthConfig for the enabled TLS/SASL settings
+func newKafkaAuthConfig(net AdapterNet) *client.KafkaAuthConfig {
+	kafkaAuthConfig := &client.KafkaAuthConfig{}
 
-	saslType, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Type.SecretKeyRef)
+	if net.TLS.Enable {
+		kafkaAuthConfig.TLS = &client.KafkaTlsConfig{
+			Cacert:   net.TLS.CACert,
+			Usercert: net.TLS.Cert,
+			Userkey:  net.TLS.Key,
+		}
+	}
+
+	if net.SASL.Enable {
+		kafkaAuthConfig.SASL = &client.KafkaSaslConfig{
+			User:     net.SASL.User,
+			Password: net.SASL.Password,
+			SaslType: net.SASL.Type,
+		}
+	}
+
+	return kafkaAuthConfig
+}
+
+// resolveNet resolves the secrets referenced by the KafkaNetSpec
+func resolveNet(ctx context.Context, kc kubernetes.Interface, ns string, spec *bindingsv1beta1.KafkaNetSpec) (AdapterNet, error) {
+	saslUser, err := resolveSecret(ctx, kc, ns, spec.SASL.User.SecretKeyRef)
 	if err != nil {
-		return KafkaEnvConfig{}, err
+		return AdapterNet{}, err
 	}
 
-	tlsCert, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.Cert.SecretKeyRef)
+	saslPassword, err := resolveSecret(ctx, kc, ns, spec.SASL.Password.SecretKeyRef)
 	if err != nil {
-		return KafkaEnvConfig{}, err
+		return AdapterNet{}, err
 	}
 
-	tlsKey, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.Key.SecretKeyRef)
+	saslType, err := resolveSecret(ctx, kc, ns, spec.SASL.Type.SecretKeyRef)
 	if err != nil {
-		return KafkaEnvConfig{}, err
+		return AdapterNet{}, err
 	}
 
-	tlsCACert, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.CACert.SecretKeyRef)
+	tlsCert, err := resolveSecret(ctx, kc, ns, spec.TLS.Cert.SecretKeyRef)
 	if err != nil {
-		return KafkaEnvConfig{}, err
+		return AdapterNet{}, err
 	}
 
-	config := KafkaEnvConfig{
-		BootstrapServers: obj.Spec.BootstrapServers,
-		Net: AdapterNet{
-			SASL: AdapterSASL{
-				Enable:   obj.Spec.Net.SASL.Enable,
-				User:     saslUser,
-				Password: saslPassword,
-				Type:     saslType,
-			},
-			TLS: AdapterTLS{
-				Enable: obj.Spec.Net.TLS.Enable,
-				Cert:   tlsCert,
-				Key:    tlsKey,
-				CACert: tlsCACert,
-			},
-		},
+	tlsKey, err := resolveSecret(ctx, kc, ns, spec.TLS.Key.SecretKeyRef)
+	if err != nil {
+		return AdapterNet{}, err
 	}
 
-	return config, nil
+	tlsCACert, err := resolveSecret(ctx, kc, ns, spec.TLS.CACert.SecretKeyRef)
+	if err != nil {
+		return AdapterNet{}, err
+	}
+
+	return AdapterNet{
+		SASL: AdapterSASL{
+			Enable:   spec.SASL.Enable,
+			User:     saslUser,
+			Password: saslPassword,
+			Type:     saslType,
+		},
+		TLS: AdapterTLS{
+			Enable: spec.TLS.Enable,
+			Cert:   tlsCert,
+			Key:    tlsKey,
+			CACert: tlsCACert,
+		},
+	}, nil
 }
 
 // NewProducer is a helper method for constructing a client for producing kafka methods.
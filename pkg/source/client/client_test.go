@@ -22,6 +22,12 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -43,6 +49,7 @@ func TestNewConfig(t *testing.T) {
 		bootstrapServer string
 		saslUser        string
 		saslPassword    string
+		initialOffset   int64
 	}{
 		"Just bootstrap Server": {
 			env: map[string]string{
@@ -149,6 +156,14 @@ func TestNewConfig(t *testing.T) {
 			saslMechanism:   sarama.SASLTypeSCRAMSHA512,
 			bootstrapServer: defaultBootstrapServer,
 		},
+		"Earliest Initial Offset": {
+			env: map[string]string{
+				"KAFKA_BOOTSTRAP_SERVERS": defaultBootstrapServer,
+				"KAFKA_INITIAL_OFFSET":    "Earliest",
+			},
+			bootstrapServer: defaultBootstrapServer,
+			initialOffset:   sarama.OffsetOldest,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -179,6 +194,9 @@ func TestNewConfig(t *testing.T) {
 				}
 			}
 			require.NotNil(t, config)
+			if tc.initialOffset != 0 && config.Consumer.Offsets.Initial != tc.initialOffset {
+				t.Fatalf("Incorrect initial offset, got: %d vs want: %d", config.Consumer.Offsets.Initial, tc.initialOffset)
+			}
 			for k := range tc.env {
 				_ = os.Unsetenv(k)
 			}
@@ -186,6 +204,39 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
+func TestNewConfigFromSpecInitialOffset(t *testing.T) {
+	testCases := map[string]struct {
+		initialOffset sourcesv1beta1.Offset
+		want          int64
+	}{
+		"Default": {
+			want: sarama.OffsetNewest,
+		},
+		"Latest": {
+			initialOffset: sourcesv1beta1.OffsetLatest,
+			want:          sarama.OffsetNewest,
+		},
+		"Earliest": {
+			initialOffset: sourcesv1beta1.OffsetEarliest,
+			want:          sarama.OffsetOldest,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &sourcesv1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-source"},
+				Spec: sourcesv1beta1.KafkaSourceSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{"server1"}},
+					InitialOffset: tc.initialOffset,
+				},
+			}
+			_, config, err := NewConfigFromSpec(context.Background(), kubefake.NewSimpleClientset(), src)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, config.Consumer.Offsets.Initial)
+		})
+	}
+}
+
 func TestAdminClient(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	ctx := logging.WithLogger(context.TODO(), logger)
@@ -217,3 +268,85 @@ func TestAdminClient(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, admin)
 }
+
+func TestNewKafkaAuthConfigFromSpec(t *testing.T) {
+	namespace := "test-namespace"
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "kafka-auth"},
+		Data: map[string][]byte{
+			"user":     []byte("secret-user"),
+			"password": []byte("super-seekrit-password"),
+			"type":     []byte(sarama.SASLTypeSCRAMSHA512),
+			"ca.crt":   []byte("ca-cert"),
+		},
+	}
+	secretRef := func(key string) bindingsv1beta1.SecretValueFromSource {
+		return bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Key:                  key,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		spec    bindingsv1beta1.KafkaAuthSpec
+		want    *client.KafkaAuthConfig
+		wantErr bool
+	}{
+		"No Auth": {
+			spec: bindingsv1beta1.KafkaAuthSpec{},
+			want: &client.KafkaAuthConfig{},
+		},
+		"SASL And TLS": {
+			spec: bindingsv1beta1.KafkaAuthSpec{
+				Net: bindingsv1beta1.KafkaNetSpec{
+					SASL: bindingsv1beta1.KafkaSASLSpec{
+						Enable:   true,
+						User:     secretRef("user"),
+						Password: secretRef("password"),
+						Type:     secretRef("type"),
+					},
+					TLS: bindingsv1beta1.KafkaTLSSpec{
+						Enable: true,
+						CACert: secretRef("ca.crt"),
+					},
+				},
+			},
+			want: &client.KafkaAuthConfig{
+				SASL: &client.KafkaSaslConfig{
+					User:     "secret-user",
+					Password: "super-seekrit-password",
+					SaslType: sarama.SASLTypeSCRAMSHA512,
+				},
+				TLS: &client.KafkaTlsConfig{
+					Cacert: "ca-cert",
+				},
+			},
+		},
+		"Missing Secret Key": {
+			spec: bindingsv1beta1.KafkaAuthSpec{
+				Net: bindingsv1beta1.KafkaNetSpec{
+					SASL: bindingsv1beta1.KafkaSASLSpec{
+						Enable: true,
+						User:   secretRef("missing"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		tc := tc
+		t.Run(n, func(t *testing.T) {
+			kc := kubefake.NewSimpleClientset(secret)
+			authConfig, err := NewKafkaAuthConfigFromSpec(context.Background(), kc, namespace, &tc.spec)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, authConfig)
+		})
+	}
+}
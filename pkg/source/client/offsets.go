@@ -32,6 +32,7 @@ import (
 // is closed before at least one message is consumed from ALL partitions.
 // Without InitOffsets, an event sent to a partition with an uninitialized offset
 // will not be forwarded when the session is closed (or a rebalancing is in progress).
+// The uninitialized offsets are set according to the Consumer.Offsets.Initial setting of the client.
 func InitOffsets(ctx context.Context, kafkaClient sarama.Client, topics []string, consumerGroup string) error {
 	offsetManager, err := sarama.NewOffsetManagerFromClient(consumerGroup, kafkaClient)
 	if err != nil {
@@ -57,7 +58,7 @@ func InitOffsets(ctx context.Context, kafkaClient sarama.Client, topics []string
 	}
 
 	// Fetch topic offsets
-	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, kafkaClient.Config().Consumer.Offsets.Initial)
 	if err != nil {
 		return fmt.Errorf("failed to get the topic offsets: %w", err)
 	}
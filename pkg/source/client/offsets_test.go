@@ -26,10 +26,11 @@ import (
 
 func TestInitOffsets(t *testing.T) {
 	testCases := map[string]struct {
-		topics       []string
-		topicOffsets map[string]map[int32]int64
-		cgOffsets    map[string]map[int32]int64
-		wantCommit   bool
+		topics        []string
+		initialOffset int64
+		topicOffsets  map[string]map[int32]int64
+		cgOffsets     map[string]map[int32]int64
+		wantCommit    bool
 	}{
 		"one topic, one partition, initialized": {
 			topics: []string{"my-topic"},
@@ -59,6 +60,21 @@ func TestInitOffsets(t *testing.T) {
 			},
 			wantCommit: true,
 		},
+		"one topic, one partition, uninitialized, earliest": {
+			topics:        []string{"my-topic"},
+			initialOffset: sarama.OffsetOldest,
+			topicOffsets: map[string]map[int32]int64{
+				"my-topic": {
+					0: 1,
+				},
+			},
+			cgOffsets: map[string]map[int32]int64{
+				"my-topic": {
+					0: -1,
+				},
+			},
+			wantCommit: true,
+		},
 		"several topics, several partitions, not all initialized": {
 			topics: []string{"my-topic", "my-topic-2", "my-topic-3"},
 			topicOffsets: map[string]map[int32]int64{
@@ -82,10 +98,15 @@ func TestInitOffsets(t *testing.T) {
 
 			group := "my-group"
 
+			initialOffset := sarama.OffsetNewest
+			if tc.initialOffset != 0 {
+				initialOffset = tc.initialOffset
+			}
+
 			offsetResponse := sarama.NewMockOffsetResponse(t).SetVersion(1)
 			for topic, partitions := range tc.topicOffsets {
 				for partition, offset := range partitions {
-					offsetResponse = offsetResponse.SetOffset(topic, partition, -1, offset)
+					offsetResponse = offsetResponse.SetOffset(topic, partition, initialOffset, offset)
 				}
 			}
 
@@ -131,6 +152,7 @@ func TestInitOffsets(t *testing.T) {
 
 			config := sarama.NewConfig()
 			config.Version = sarama.MaxVersion
+			config.Consumer.Offsets.Initial = initialOffset
 
 			sc, err := sarama.NewClient([]string{broker.Addr()}, config)
 			if err != nil {
@@ -19,8 +19,10 @@ package binding
 import (
 	"context"
 
+	"github.com/Shopify/sarama"
 	kfkinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/bindings/v1beta1/kafkabinding"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/podspecable"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/reconciler"
 
@@ -80,6 +82,10 @@ func NewController(
 		Recorder: record.NewBroadcaster().NewRecorder(
 			scheme.Scheme, corev1.EventSource{Component: controllerAgentName}),
 		NamespaceLister: namespaceInformer.Lister(),
+		SubResourcesReconciler: &Reconciler{
+			kubeClientSet: kubeclient.Get(ctx),
+			newClient:     sarama.NewClient,
+		},
 	}
 	impl := controller.NewImpl(c, logger, "KafkaBindings")
 
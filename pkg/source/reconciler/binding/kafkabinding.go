@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	commonclient "knative.dev/eventing-kafka/pkg/common/client"
+	sourceclient "knative.dev/eventing-kafka/pkg/source/client"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/webhook/psbinding"
+)
+
+const (
+	// secretNotResolvedReason is the Ready condition reason used when the referenced auth secrets can't be read
+	secretNotResolvedReason = "SecretNotResolved"
+
+	// invalidConfigurationReason is the Ready condition reason used when no Sarama config can be built from the secrets
+	invalidConfigurationReason = "InvalidConfiguration"
+
+	// connectionFailedReason is the Ready condition reason used when the bootstrap servers can't be reached
+	connectionFailedReason = "ConnectionFailed"
+
+	// probeDialTimeout bounds the connection attempt to each bootstrap server (the Sarama default is 30s)
+	probeDialTimeout = 5 * time.Second
+
+	// probeMetadataRetryMax is the number of metadata retries of the probe (the Sarama default is 3)
+	probeMetadataRetryMax = 1
+)
+
+// newClientFunc creates a Sarama client for the given brokers (sarama.NewClient outside of the tests)
+type newClientFunc func(addrs []string, config *sarama.Config) (sarama.Client, error)
+
+// Reconciler reconciles the Kafka side of a KafkaBinding, after the psbinding.BaseReconciler has bound
+// its subject, by resolving the referenced auth secrets and checking that the bootstrap servers are
+// reachable with them.  The Ready condition of the binding is marked False when either step fails.
+// The bootstrap servers are only probed again once the spec or the content of the secrets changes.
+type Reconciler struct {
+	kubeClientSet kubernetes.Interface
+	newClient     newClientFunc
+
+	probedLock sync.Mutex
+	probed     map[types.NamespacedName]probedState
+}
+
+// probedState is what the last successful probe of a KafkaBinding was made with
+type probedState struct {
+	generation int64
+	authConfig *commonclient.KafkaAuthConfig
+}
+
+// Verify The Reconciler Implements The SubResourcesReconcilerInterface
+var _ psbinding.SubResourcesReconcilerInterface = (*Reconciler)(nil)
+
+// Reconcile implements psbinding.SubResourcesReconcilerInterface
+func (r *Reconciler) Reconcile(ctx context.Context, fb psbinding.Bindable) error {
+	kfb, ok := fb.(*v1beta1.KafkaBinding)
+	if !ok {
+		return fmt.Errorf("expected a KafkaBinding, got %T", fb)
+	}
+	logger := logging.FromContext(ctx)
+
+	authConfig, err := sourceclient.NewKafkaAuthConfigFromSpec(ctx, r.kubeClientSet, kfb.Namespace, &kfb.Spec.KafkaAuthSpec)
+	if err != nil {
+		logger.Errorw("unable to resolve the KafkaBinding auth secrets", zap.Error(err))
+		kfb.Status.MarkBindingUnavailable(secretNotResolvedReason, err.Error())
+		return err
+	}
+
+	key := types.NamespacedName{Namespace: kfb.Namespace, Name: kfb.Name}
+	state := probedState{generation: kfb.Generation, authConfig: authConfig}
+	if r.isProbed(key, state) {
+		kfb.Status.MarkBindingAvailable()
+		return nil
+	}
+
+	config, err := commonclient.NewConfigBuilder().
+		WithDefaults().
+		WithAuth(authConfig).
+		Build(ctx)
+	if err != nil {
+		logger.Errorw("unable to build Kafka configuration", zap.Error(err))
+		kfb.Status.MarkBindingUnavailable(invalidConfigurationReason, err.Error())
+		return err
+	}
+
+	// Fail fast rather than holding the reconciler worker on unreachable brokers
+	config.Net.DialTimeout = probeDialTimeout
+	config.Metadata.Retry.Max = probeMetadataRetryMax
+
+	c, err := r.newClient(kfb.Spec.BootstrapServers, config)
+	if err != nil {
+		logger.Errorw("unable to connect to the KafkaBinding bootstrap servers", zap.Error(err))
+		kfb.Status.MarkBindingUnavailable(connectionFailedReason, err.Error())
+		return err
+	}
+	if err = c.Close(); err != nil {
+		logger.Warnw("failed to close the Kafka client", zap.Error(err))
+	}
+
+	r.setProbed(key, state)
+	kfb.Status.MarkBindingAvailable()
+	return nil
+}
+
+// ReconcileDeletion implements psbinding.SubResourcesReconcilerInterface (only the probe state is cleaned up)
+func (r *Reconciler) ReconcileDeletion(ctx context.Context, fb psbinding.Bindable) error {
+	if kfb, ok := fb.(*v1beta1.KafkaBinding); ok {
+		r.probedLock.Lock()
+		delete(r.probed, types.NamespacedName{Namespace: kfb.Namespace, Name: kfb.Name})
+		r.probedLock.Unlock()
+	}
+	return nil
+}
+
+// isProbed returns true if the bootstrap servers were last probed successfully with the same state
+func (r *Reconciler) isProbed(key types.NamespacedName, state probedState) bool {
+	r.probedLock.Lock()
+	defer r.probedLock.Unlock()
+	probed, ok := r.probed[key]
+	return ok && reflect.DeepEqual(probed, state)
+}
+
+// setProbed records the state of a successful probe of the bootstrap servers
+func (r *Reconciler) setProbed(key types.NamespacedName, state probedState) {
+	r.probedLock.Lock()
+	defer r.probedLock.Unlock()
+	if r.probed == nil {
+		r.probed = make(map[types.NamespacedName]probedState)
+	}
+	r.probed[key] = state
+}
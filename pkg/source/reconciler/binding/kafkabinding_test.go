@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testSecretName = "test-secret"
+	testBroker     = "test-broker:9092"
+)
+
+func TestReconcile(t *testing.T) {
+	saslSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+		Data: map[string][]byte{
+			"user":     []byte("test-user"),
+			"password": []byte("test-password"),
+			"type":     []byte(sarama.SASLTypeSCRAMSHA512),
+		},
+	}
+
+	testCases := map[string]struct {
+		secrets        []runtime.Object
+		clientErr      error
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		"resolvable binding": {
+			secrets:        []runtime.Object{saslSecret},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		"unresolvable secret": {
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: secretNotResolvedReason,
+		},
+		"unreachable bootstrap servers": {
+			secrets:        []runtime.Object{saslSecret},
+			clientErr:      errors.New("kafka: client has run out of available brokers"),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: connectionFailedReason,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			kfb := newKafkaBinding()
+			kfb.Status.InitializeConditions()
+
+			mockClient := controllertesting.NewMockClient()
+			mockClient.On("Close").Return(nil)
+			var clientBrokers []string
+			var clientConfig *sarama.Config
+			r := &Reconciler{
+				kubeClientSet: kubefake.NewSimpleClientset(tc.secrets...),
+				newClient: func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+					clientBrokers = addrs
+					clientConfig = config
+					if tc.clientErr != nil {
+						return nil, tc.clientErr
+					}
+					return mockClient, nil
+				},
+			}
+
+			err := r.Reconcile(ctx, kfb)
+
+			ready := kfb.Status.GetCondition(apis.ConditionReady)
+			assert.NotNil(t, ready)
+			assert.Equal(t, tc.expectedStatus, ready.Status)
+			if tc.expectedStatus == corev1.ConditionTrue {
+				assert.Nil(t, err)
+				assert.Equal(t, []string{testBroker}, clientBrokers)
+				assert.True(t, clientConfig.Net.SASL.Enable)
+				assert.Equal(t, "test-user", clientConfig.Net.SASL.User)
+				assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), clientConfig.Net.SASL.Mechanism)
+				assert.Equal(t, probeDialTimeout, clientConfig.Net.DialTimeout)
+				assert.Equal(t, probeMetadataRetryMax, clientConfig.Metadata.Retry.Max)
+				mockClient.AssertCalled(t, "Close")
+			} else {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedReason, ready.Reason)
+				assert.NotEmpty(t, ready.Message)
+				mockClient.AssertNotCalled(t, "Close")
+			}
+		})
+	}
+}
+
+func TestReconcileProbesOnlyOnChange(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	saslSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+		Data: map[string][]byte{
+			"user":     []byte("test-user"),
+			"password": []byte("test-password"),
+			"type":     []byte(sarama.SASLTypeSCRAMSHA512),
+		},
+	}
+	kubeClient := kubefake.NewSimpleClientset(saslSecret)
+
+	mockClient := controllertesting.NewMockClient()
+	mockClient.On("Close").Return(nil)
+	probes := 0
+	var clientErr error
+	r := &Reconciler{
+		kubeClientSet: kubeClient,
+		newClient: func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+			probes++
+			if clientErr != nil {
+				return nil, clientErr
+			}
+			return mockClient, nil
+		},
+	}
+
+	kfb := newKafkaBinding()
+	kfb.Status.InitializeConditions()
+	reconcile := func(wantProbes int) {
+		t.Helper()
+		assert.Nil(t, r.Reconcile(ctx, kfb))
+		assert.Equal(t, wantProbes, probes)
+		assert.True(t, kfb.Status.GetCondition(apis.ConditionReady).IsTrue())
+	}
+
+	// The first reconcile probes, an unchanged binding does not
+	reconcile(1)
+	reconcile(1)
+
+	// A spec change probes again
+	kfb.Generation++
+	reconcile(2)
+	reconcile(2)
+
+	// A change to the content of the secret probes again
+	saslSecret.Data["password"] = []byte("rotated-password")
+	_, err := kubeClient.CoreV1().Secrets(testNamespace).Update(ctx, saslSecret, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+	reconcile(3)
+	reconcile(3)
+
+	// A failed probe is retried on the next reconcile
+	kfb.Generation++
+	clientErr = errors.New("kafka: client has run out of available brokers")
+	assert.NotNil(t, r.Reconcile(ctx, kfb))
+	assert.Equal(t, 4, probes)
+	clientErr = nil
+	reconcile(5)
+
+	// A deleted binding is forgotten
+	assert.Nil(t, r.ReconcileDeletion(ctx, kfb))
+	reconcile(6)
+}
+
+func TestReconcileDeletion(t *testing.T) {
+	r := &Reconciler{}
+	assert.Nil(t, r.ReconcileDeletion(context.TODO(), newKafkaBinding()))
+}
+
+// newKafkaBinding returns a KafkaBinding with SASL credentials from the test secret
+func newKafkaBinding() *v1beta1.KafkaBinding {
+	secretKeyRef := func(key string) v1beta1.SecretValueFromSource {
+		return v1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+				Key:                  key,
+			},
+		}
+	}
+	return &v1beta1.KafkaBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-binding"},
+		Spec: v1beta1.KafkaBindingSpec{
+			KafkaAuthSpec: v1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{testBroker},
+				Net: v1beta1.KafkaNetSpec{
+					SASL: v1beta1.KafkaSASLSpec{
+						Enable:   true,
+						User:     secretKeyRef("user"),
+						Password: secretKeyRef("password"),
+						Type:     secretKeyRef("type"),
+					},
+				},
+			},
+		},
+	}
+}
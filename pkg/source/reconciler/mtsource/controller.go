@@ -26,6 +26,7 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis/duck"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
@@ -50,6 +51,18 @@ type envConfig struct {
 	SchedulerRefreshPeriod int64                            `envconfig:"AUTOSCALER_REFRESH_PERIOD" required:"true"`
 	PodCapacity            int32                            `envconfig:"POD_CAPACITY" required:"true"`
 	SchedulerPolicy        stsscheduler.SchedulerPolicyType `envconfig:"SCHEDULER_POLICY_TYPE" required:"true"`
+
+	// ExcludeUnschedulableNodes removes cordoned nodes from the EVENSPREAD and PREFERREDEVENSPREAD zone math (no new vreplicas are placed there either way)
+	ExcludeUnschedulableNodes bool `envconfig:"SCHEDULER_EXCLUDE_UNSCHEDULABLE_NODES" default:"false"`
+
+	// StickyPlacements makes scale downs take the vreplicas from as few adapter pods as possible, avoiding consumer rebalances
+	StickyPlacements bool `envconfig:"SCHEDULER_STICKY_PLACEMENTS" default:"false"`
+
+	// ZoneLabel is the node label the EVENSPREAD and PREFERREDEVENSPREAD policies read the zone from
+	ZoneLabel string `envconfig:"SCHEDULER_ZONE_LABEL" default:"topology.kubernetes.io/zone"`
+
+	// RegionLabel is the node label the EVENSPREAD_BYREGION policy reads the region from
+	RegionLabel string `envconfig:"SCHEDULER_REGION_LABEL" default:"topology.kubernetes.io/region"`
 }
 
 func NewController(
@@ -62,6 +75,9 @@ func NewController(
 	if err := envconfig.Process("", env); err != nil {
 		logger.Panicf("unable to process required environment variables: %v", err)
 	}
+	if err := stsscheduler.ValidatePolicy(env.SchedulerPolicy); err != nil {
+		logger.Panicf("invalid SCHEDULER_POLICY_TYPE: %v", err)
+	}
 
 	kafkaInformer := kafkainformer.Get(ctx)
 	nodeInformer := nodeinformer.Get(ctx)
@@ -125,9 +141,29 @@ func NewController(
 		return nil
 	}
 
+	// Only the leader for the adapter statefulset key runs the autoscaler
+	isLeader := func() bool {
+		la, ok := impl.Reconciler.(interface {
+			IsLeaderFor(types.NamespacedName) bool
+		})
+		return !ok || la.IsLeaderFor(types.NamespacedName{Namespace: system.Namespace(), Name: mtadapterName})
+	}
+
 	c.scheduler = stsscheduler.NewScheduler(ctx,
-		system.Namespace(), mtadapterName, c.vpodLister, rp, env.PodCapacity, env.SchedulerPolicy,
-		nodeInformer.Lister(), evictor)
+		system.Namespace(), mtadapterName, c.vpodLister, rp, env.PodCapacity, env.SchedulerPolicy, env.ExcludeUnschedulableNodes, env.StickyPlacements, env.ZoneLabel, env.RegionLabel,
+		nodeInformer.Lister(), evictor, isLeader)
+
+	// Warm the scheduler state as soon as the informers backing it have synced
+	if warmer, ok := c.scheduler.(scheduler.Warmer); ok {
+		go func() {
+			if !cache.WaitForCacheSync(ctx.Done(), kafkaInformer.Informer().HasSynced, nodeInformer.Informer().HasSynced) {
+				return
+			}
+			if err := warmer.Warm(ctx); err != nil {
+				logging.FromContext(ctx).Warnw("Failed to warm the scheduler", zap.Error(err))
+			}
+		}()
+	}
 
 	logging.FromContext(ctx).Info("Setting up kafka event handlers")
 	kafkaInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
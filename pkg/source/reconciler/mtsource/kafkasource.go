@@ -141,13 +141,24 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 func (r *Reconciler) reconcileMTReceiveAdapter(src *v1beta1.KafkaSource) error {
 	placements, err := r.scheduler.Schedule(src)
 
-	// Update placements, even partial ones.
-	if placements != nil {
+	// Update placements, even partial ones, leaving the status untouched when they are identical
+	// (regardless of their order) to avoid unnecessary status updates.
+	if placements != nil && scheduler.PlacementsChanged(src.Status.Placement, placements) {
 		src.Status.Placement = placements
 	}
 
+	// The placed vreplicas are the running consumers reported by the scale subresource
+	src.Status.Consumers = scheduler.GetTotalVReplicas(src.Status.Placement)
+
 	if err != nil {
-		src.Status.MarkNotScheduled("Unschedulable", err.Error())
+		var notEnough *scheduler.NotEnoughReplicasError
+		if errors.As(err, &notEnough) {
+			// Partially placed, let users know the remaining vreplicas are waiting for capacity
+			src.Status.MarkNotScheduled("InsufficientCapacity", "%d of %d vreplicas placed, %d pending (waiting for capacity)",
+				notEnough.Placed, notEnough.Placed+notEnough.Pending, notEnough.Pending)
+		} else {
+			src.Status.MarkNotScheduled("Unschedulable", err.Error())
+		}
 		return err // retrying...
 	}
 	src.Status.MarkScheduled()
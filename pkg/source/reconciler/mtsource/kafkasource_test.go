@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	duckv1alpha1 "knative.dev/eventing-kafka/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	"knative.dev/eventing-kafka/pkg/common/scheduler"
+)
+
+type fakeScheduler struct {
+	placements []duckv1alpha1.Placement
+	err        error
+}
+
+func (s *fakeScheduler) Schedule(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
+	return s.placements, s.err
+}
+
+func TestReconcileMTReceiveAdapterScheduledCondition(t *testing.T) {
+	testCases := map[string]struct {
+		placements []duckv1alpha1.Placement
+		err        error
+		status     corev1.ConditionStatus
+		reason     string
+		message    string
+		consumers  int32
+	}{
+		"fully placed": {
+			placements: []duckv1alpha1.Placement{{PodName: "pod-0", VReplicas: 3}},
+			status:     corev1.ConditionTrue,
+			consumers:  3,
+		},
+		"partially placed": {
+			placements: []duckv1alpha1.Placement{{PodName: "pod-0", VReplicas: 1}},
+			err:        &scheduler.NotEnoughReplicasError{Placed: 1, Pending: 2},
+			status:     corev1.ConditionFalse,
+			consumers:  1,
+			reason:     "InsufficientCapacity",
+			message:    "1 of 3 vreplicas placed, 2 pending (waiting for capacity)",
+		},
+		"unschedulable": {
+			err:     errors.New("boom"),
+			status:  corev1.ConditionFalse,
+			reason:  "Unschedulable",
+			message: "boom",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			three := int32(3)
+			src := &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source"},
+				Spec:       v1beta1.KafkaSourceSpec{Consumers: &three},
+			}
+			src.Status.InitializeConditions()
+
+			r := &Reconciler{scheduler: &fakeScheduler{placements: tc.placements, err: tc.err}}
+			err := r.reconcileMTReceiveAdapter(src)
+			if err != tc.err {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(src.Status.Placement, tc.placements) {
+				t.Errorf("got placements %v, want %v", src.Status.Placement, tc.placements)
+			}
+			if src.Status.Consumers != tc.consumers {
+				t.Errorf("got %d consumers, want %d", src.Status.Consumers, tc.consumers)
+			}
+
+			cond := src.Status.GetCondition(v1beta1.KafkaConditionScheduled)
+			if cond == nil {
+				t.Fatal("expected a Scheduled condition")
+			}
+			if cond.Status != tc.status || cond.Reason != tc.reason || cond.Message != tc.message {
+				t.Errorf("got condition %s/%s/%q, want %s/%s/%q", cond.Status, cond.Reason, cond.Message, tc.status, tc.reason, tc.message)
+			}
+		})
+	}
+}
+
+func TestKafkaSourceScale(t *testing.T) {
+	ctx := context.Background()
+
+	// Serve the scale subresource the way the API server does for the KafkaSource CRD and record the requests
+	type request struct {
+		method string
+		path   string
+		body   []byte
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Error("unexpected error reading the request body:", err)
+		}
+		requests = append(requests, request{method: req.Method, path: req.URL.Path, body: body})
+
+		scale := &autoscalingv1.Scale{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source"},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: 3},
+			Status:     autoscalingv1.ScaleStatus{Replicas: 2, Selector: "control-plane=kafkasource-mt-adapter"},
+		}
+		if req.Method == http.MethodPut {
+			scale = &autoscalingv1.Scale{}
+			if err := json.Unmarshal(body, scale); err != nil {
+				t.Error("unexpected error decoding the scale:", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(scale); err != nil {
+			t.Error("unexpected error encoding the scale:", err)
+		}
+	}))
+	defer server.Close()
+
+	kafkaClient, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal("unexpected error creating the client:", err)
+	}
+	sourceClient := kafkaClient.SourcesV1beta1().KafkaSources("ns")
+
+	scale, err := sourceClient.GetScale(ctx, "source", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("unexpected error getting the scale:", err)
+	}
+	if scale.Spec.Replicas != 3 || scale.Status.Replicas != 2 {
+		t.Errorf("got scale %d/%d, want 3/2", scale.Spec.Replicas, scale.Status.Replicas)
+	}
+
+	scale.Spec.Replicas = 5
+	updated, err := sourceClient.UpdateScale(ctx, "source", scale, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal("unexpected error updating the scale:", err)
+	}
+	if updated.Spec.Replicas != 5 {
+		t.Errorf("got %d replicas, want 5", updated.Spec.Replicas)
+	}
+
+	const scalePath = "/apis/sources.knative.dev/v1beta1/namespaces/ns/kafkasources/source/scale"
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if requests[0].method != http.MethodGet || requests[0].path != scalePath {
+		t.Errorf("got request %s %s, want %s %s", requests[0].method, requests[0].path, http.MethodGet, scalePath)
+	}
+	if requests[1].method != http.MethodPut || requests[1].path != scalePath {
+		t.Errorf("got request %s %s, want %s %s", requests[1].method, requests[1].path, http.MethodPut, scalePath)
+	}
+	sent := &autoscalingv1.Scale{}
+	if err := json.Unmarshal(requests[1].body, sent); err != nil {
+		t.Fatal("unexpected error decoding the request body:", err)
+	}
+	if sent.Namespace != "ns" || sent.Name != "source" || sent.Spec.Replicas != 5 {
+		t.Errorf("got scale %s/%s with %d replicas, want ns/source with 5", sent.Namespace, sent.Name, sent.Spec.Replicas)
+	}
+}
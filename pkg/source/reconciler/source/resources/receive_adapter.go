@@ -63,6 +63,13 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		Value: args.Source.Namespace,
 	}}, args.AdditionalEnvs...)
 
+	if args.Source.Spec.InitialOffset != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_INITIAL_OFFSET",
+			Value: string(args.Source.Spec.InitialOffset),
+		})
+	}
+
 	if val, ok := args.Source.GetLabels()[v1beta1.KafkaKeyTypeLabel]; ok {
 		env = append(env, corev1.EnvVar{
 			Name:  "KEY_TYPE",
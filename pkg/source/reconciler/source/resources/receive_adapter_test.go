@@ -605,3 +605,37 @@ func TestMakeReceiveAdapterKeyType(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterInitialOffset(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetEarliest,
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	want := corev1.EnvVar{Name: "KAFKA_INITIAL_OFFSET", Value: "Earliest"}
+	for _, env := range got.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == want.Name {
+			if env != want {
+				t.Errorf("unexpected initial offset env, got: %v vs want: %v", env, want)
+			}
+			return
+		}
+	}
+	t.Errorf("missing %s env", want.Name)
+}